@@ -1,12 +1,199 @@
 package serve
 
 import (
+	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/sig-0/fxrates/ingest"
+	"github.com/sig-0/fxrates/provider/ecb"
+	"github.com/sig-0/fxrates/provider/p2p"
+	"github.com/sig-0/fxrates/provider/scrape"
 	"github.com/sig-0/fxrates/provider/ves"
+	"github.com/sig-0/fxrates/server/config"
+	"github.com/sig-0/fxrates/storage"
+	"github.com/sig-0/fxrates/storage/types"
 )
 
+// fetchTimeout is the upper bound for a single provider fetch, retries included
+const fetchTimeout = time.Minute * 2
+
+// newOrchestrator creates the ingestion service, with the default providers registered.
+// Fetched rates are validated with the configured policy, or the default one
+func newOrchestrator(
+	store storage.Storage,
+	cfg *config.Ingest,
+	logger *slog.Logger,
+) (*ingest.Orchestrator, error) {
+	var (
+		policy     = ingest.DefaultValidationPolicy()
+		deviations map[string]float64
+		providers  = defaultProviders()
+	)
+
+	if cfg != nil {
+		maxAsOfLead, err := cfg.MaxAsOfLeadDuration()
+		if err != nil {
+			return nil, err
+		}
+
+		policy = ingest.ValidationPolicy{
+			MaxDeviation:           cfg.MaxRateDeviation,
+			MaxAsOfLead:            maxAsOfLead,
+			DeviationConfirmations: cfg.RateDeviationConfirmations,
+		}
+
+		deviations = cfg.ProviderMaxRateDeviation
+
+		// Register the additional P2P markets
+		for _, market := range cfg.BinanceP2P {
+			opts, err := newP2POptions(market)
+			if err != nil {
+				return nil, err
+			}
+
+			providers = append(providers, ves.NewBinanceP2PProvider(time.Second*30, opts...))
+		}
+
+		for _, market := range cfg.BybitP2P {
+			opts, err := newP2POptions(market)
+			if err != nil {
+				return nil, err
+			}
+
+			providers = append(providers, ves.NewBybitP2PProvider(time.Second*30, opts...))
+		}
+
+		for _, market := range cfg.OKXP2P {
+			opts, err := newP2POptions(market)
+			if err != nil {
+				return nil, err
+			}
+
+			providers = append(providers, ves.NewOKXP2PProvider(time.Second*30, opts...))
+		}
+
+		// Register the scraped sources
+		for _, source := range cfg.Scrape {
+			provider, err := newScrapeProvider(source)
+			if err != nil {
+				return nil, err
+			}
+
+			providers = append(providers, provider)
+		}
+	}
+
+	orchestrator := ingest.New(
+		store,
+		ingest.WithLogger(logger),
+		ingest.WithDefaultValidationPolicy(policy),
+		ingest.WithDefaultMiddleware(
+			ingest.Logging(logger),
+			ingest.Timeout(fetchTimeout),
+		),
+	)
+
+	for _, provider := range providers {
+		var opts []ingest.RegisterOption
+
+		// Check if the provider has a dedicated deviation threshold
+		if deviation, ok := deviations[provider.Name()]; ok {
+			providerPolicy := policy
+			providerPolicy.MaxDeviation = deviation
+
+			opts = append(opts, ingest.WithValidationPolicy(providerPolicy))
+		}
+
+		if err := orchestrator.Register(provider, opts...); err != nil {
+			return nil, fmt.Errorf("unable to register provider: %w", err)
+		}
+	}
+
+	return orchestrator, nil
+}
+
+// newP2POptions creates the P2P provider options for the configured market
+func newP2POptions(market *config.P2PMarket) ([]p2p.Option, error) {
+	opts := []p2p.Option{
+		p2p.WithMarket(types.Currency(market.Asset), types.Currency(market.Fiat)),
+		p2p.WithSource(types.Source(market.Source)),
+		p2p.WithPayTypes(market.PayTypes...),
+		p2p.WithDepth(market.Pages, market.Rows),
+		p2p.WithTopOffers(market.TopOffers),
+		p2p.WithNotional(market.Notional),
+	}
+
+	if market.Name != "" {
+		opts = append(opts, p2p.WithName(market.Name))
+	}
+
+	if market.Aggregation != "" {
+		aggregation, err := p2p.ParseAggregation(market.Aggregation)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, p2p.WithAggregation(aggregation))
+	}
+
+	// The set thresholds override both the strict and relaxed defaults
+	strict, relaxed := p2p.DefaultFilters()
+
+	for _, filter := range []*p2p.Filter{&strict, &relaxed} {
+		if market.MinOrders > 0 {
+			filter.MinOrders = market.MinOrders
+		}
+
+		if market.MinFinishRate > 0 {
+			filter.MinFinishRate = market.MinFinishRate
+		}
+
+		if market.MinAvailable > 0 {
+			filter.MinAvailable = market.MinAvailable
+		}
+
+		if market.TypicalAmount > 0 {
+			filter.TypicalAmount = market.TypicalAmount
+		}
+	}
+
+	return append(opts, p2p.WithFilters(strict, relaxed)), nil
+}
+
+// newScrapeProvider creates a scraping provider for the configured source
+func newScrapeProvider(source *config.ScrapeSource) (*scrape.Provider, error) {
+	interval, err := source.IntervalDuration()
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := source.Location()
+	if err != nil {
+		return nil, err
+	}
+
+	return scrape.NewProvider(&scrape.Config{
+		Selectors: scrape.Selectors{
+			Rows:   source.Selectors.Rows,
+			Rate:   source.Selectors.Rate,
+			Base:   source.Selectors.Base,
+			Target: source.Selectors.Target,
+			Date:   source.Selectors.Date,
+		},
+		Location:   loc,
+		URL:        source.URL,
+		Name:       source.Name,
+		Source:     types.Source(source.Source),
+		Base:       types.Currency(source.Base),
+		Target:     types.Currency(source.Target),
+		RateType:   types.RateType(source.RateType),
+		Locale:     scrape.Locale(source.Locale),
+		DateFormat: source.DateFormat,
+		Interval:   interval,
+	}, time.Second*30), nil
+}
+
 // defaultProviders returns the default ingestion providers
 func defaultProviders() []ingest.Provider {
 	var (
@@ -24,11 +211,27 @@ func defaultProviders() []ingest.Provider {
 
 		// Median Binance P2P USDT rate
 		binanceP2PProvider = ves.NewBinanceP2PProvider(time.Second * 30)
+
+		// Median Bybit P2P USDT rate
+		bybitP2PProvider = ves.NewBybitP2PProvider(time.Second * 30)
+
+		// Median OKX P2P USDT rate
+		okxP2PProvider = ves.NewOKXP2PProvider(time.Second * 30)
+
+		// ECB euro reference rates, backfilled with the last 90 days
+		ecbProvider = ecb.NewProvider(
+			ecb.DailyURL,
+			time.Second*30,
+			ecb.WithBackfill(ecb.HistoryURL),
+		)
 	)
 
 	return []ingest.Provider{
 		bcvProvider,
 		bcvBanksProvider,
 		binanceP2PProvider,
+		bybitP2PProvider,
+		okxP2PProvider,
+		ecbProvider,
 	}
 }
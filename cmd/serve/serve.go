@@ -3,6 +3,7 @@ package serve
 import (
 	"context"
 	"flag"
+	"fmt"
 
 	"github.com/peterbourgon/ff/v3"
 	"github.com/peterbourgon/ff/v3/ffcli"
@@ -16,6 +17,7 @@ type serveCfg struct {
 	config *config.Config
 
 	configPath string
+	adminToken string
 }
 
 // NewServeCmd creates the serve subcommand
@@ -45,6 +47,7 @@ func NewServeCmd() *ffcli.Command {
 	cmd.Subcommands = []*ffcli.Command{
 		newServeSQLCmd(cfg),
 		newServeMemoryCmd(cfg),
+		newServeSQLiteCmd(cfg),
 	}
 
 	return cmd
@@ -64,4 +67,43 @@ func (c *serveCfg) registerFlags(fs *flag.FlagSet) {
 		"",
 		"the path to the server TOML configuration, if any",
 	)
+
+	fs.StringVar(
+		&c.adminToken,
+		"admin-token",
+		"",
+		"the bearer token for the admin API, if any. Overrides the configured token",
+	)
+}
+
+// loadConfig reads the server configuration, if any, applies the flag overrides,
+// and validates the result
+func (c *serveCfg) loadConfig() error {
+	if c.configPath != "" {
+		serverCfg, err := config.Read(c.configPath)
+		if err != nil {
+			return fmt.Errorf("unable to read server config, %w", err)
+		}
+
+		c.config = serverCfg
+	}
+
+	c.applyAdminToken()
+
+	if err := config.ValidateConfig(c.config); err != nil {
+		return fmt.Errorf("invalid server config, %w", err)
+	}
+
+	return nil
+}
+
+// applyAdminToken applies the admin token flag to the server configuration, if set
+func (c *serveCfg) applyAdminToken() {
+	if c.adminToken == "" {
+		return
+	}
+
+	c.config.AdminConfig = &config.Admin{
+		Token: c.adminToken,
+	}
 }
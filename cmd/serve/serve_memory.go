@@ -8,22 +8,26 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/peterbourgon/ff/v3"
 	"github.com/peterbourgon/ff/v3/ffcli"
 	"golang.org/x/sync/errgroup"
 
-	"github.com/sig-0/fxrates/ingest"
-
 	"github.com/sig-0/fxrates/cmd/env"
 	"github.com/sig-0/fxrates/server"
-	"github.com/sig-0/fxrates/server/config"
 	"github.com/sig-0/fxrates/storage/memory"
 )
 
+// defaultSnapshotInterval is the default interval between storage snapshots
+const defaultSnapshotInterval = 5 * time.Minute
+
 type serveMemoryCfg struct {
 	rootCfg *serveCfg
+
+	dataDir          string
+	snapshotInterval time.Duration
 }
 
 // newServeMemoryCmd creates the serve memory command.
@@ -35,6 +39,20 @@ func newServeMemoryCmd(rootCfg *serveCfg) *ffcli.Command {
 	fs := flag.NewFlagSet("memory", flag.ExitOnError)
 	cfg.rootCfg.registerFlags(fs)
 
+	fs.StringVar(
+		&cfg.dataDir,
+		"data-dir",
+		"",
+		"the directory for storage snapshots and the write-ahead log, if any. Restored on startup",
+	)
+
+	fs.DurationVar(
+		&cfg.snapshotInterval,
+		"snapshot-interval",
+		defaultSnapshotInterval,
+		"the interval between storage snapshots, if a data directory is set",
+	)
+
 	return &ffcli.Command{
 		Name:       "memory",
 		ShortUsage: "serve memory [flags]",
@@ -49,14 +67,9 @@ func newServeMemoryCmd(rootCfg *serveCfg) *ffcli.Command {
 }
 
 func (c *serveMemoryCfg) exec(ctx context.Context, _ []string) error {
-	// Read the server configuration, if any
-	if c.rootCfg.configPath != "" {
-		serverCfg, err := config.Read(c.rootCfg.configPath)
-		if err != nil {
-			return fmt.Errorf("unable to read server config, %w", err)
-		}
-
-		c.rootCfg.config = serverCfg
+	// Load the server configuration, before anything is created from it
+	if err := c.rootCfg.loadConfig(); err != nil {
+		return err
 	}
 
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
@@ -66,15 +79,20 @@ func (c *serveMemoryCfg) exec(ctx context.Context, _ []string) error {
 		logger.Warn("unable to load .env file")
 	}
 
-	// Create an in-memory store
-	store := memory.NewStorage()
+	// Create an in-memory store, restoring it if persistence is enabled
+	store, err := memory.NewStorage(
+		memory.WithDataDir(c.dataDir),
+		memory.WithSnapshotInterval(c.snapshotInterval),
+		memory.WithLogger(logger),
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create storage: %w", err)
+	}
 
 	// Create the ingestion service
-	orchestrator := ingest.New(store, ingest.WithLogger(logger))
-	for _, provider := range defaultProviders() {
-		if err := orchestrator.Register(provider); err != nil {
-			return fmt.Errorf("unable to register provider: %w", err)
-		}
+	orchestrator, err := newOrchestrator(store, c.rootCfg.config.IngestConfig, logger)
+	if err != nil {
+		return fmt.Errorf("unable to create ingestion service: %w", err)
 	}
 
 	// Create the server
@@ -82,6 +100,9 @@ func (c *serveMemoryCfg) exec(ctx context.Context, _ []string) error {
 		store,
 		server.WithLogger(logger),
 		server.WithConfig(c.rootCfg.config),
+		server.WithProviders(orchestrator),
+		server.WithProviderController(orchestrator),
+		server.WithEvents(orchestrator),
 	)
 	if err != nil {
 		return fmt.Errorf("unable to create server, %w", err)
@@ -102,8 +123,18 @@ func (c *serveMemoryCfg) exec(ctx context.Context, _ []string) error {
 		return s.Serve(gCtx)
 	})
 
+	// Start the storage snapshots. They're stopped once the ingestion service is,
+	// so the final snapshot covers every saved rate
+	storeCtx, storeCancelFn := context.WithCancel(context.WithoutCancel(gCtx))
+
+	group.Go(func() error {
+		return store.Start(storeCtx)
+	})
+
 	// Start the ingestion service
 	group.Go(func() error {
+		defer storeCancelFn()
+
 		return orchestrator.Start(gCtx)
 	})
 
@@ -8,26 +8,23 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
-	"github.com/jackc/pgx/v5"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/joho/godotenv"
 	"github.com/peterbourgon/ff/v3"
 	"github.com/peterbourgon/ff/v3/ffcli"
 	"golang.org/x/sync/errgroup"
 
-	"github.com/sig-0/fxrates/ingest"
-
 	"github.com/sig-0/fxrates/cmd/env"
 	"github.com/sig-0/fxrates/server"
 	"github.com/sig-0/fxrates/server/config"
 	"github.com/sig-0/fxrates/storage/sql"
-	gen "github.com/sig-0/fxrates/storage/sql/gen"
 )
 
 type serveSQLCfg struct {
 	rootCfg *serveCfg
+
+	autoMigrate bool
 }
 
 // newServeCmd creates the serve command
@@ -39,6 +36,13 @@ func newServeSQLCmd(rootCfg *serveCfg) *ffcli.Command {
 	fs := flag.NewFlagSet("sql", flag.ExitOnError)
 	cfg.rootCfg.registerFlags(fs)
 
+	fs.BoolVar(
+		&cfg.autoMigrate,
+		"auto-migrate",
+		false,
+		"applies the pending DB migrations on startup",
+	)
+
 	return &ffcli.Command{
 		Name:       "sql",
 		ShortUsage: "serve sql [flags]",
@@ -55,14 +59,9 @@ func newServeSQLCmd(rootCfg *serveCfg) *ffcli.Command {
 
 // exec executes the server serve command
 func (c *serveSQLCfg) exec(ctx context.Context, _ []string) error {
-	// Read the server configuration, if any
-	if c.rootCfg.configPath != "" {
-		serverCfg, err := config.Read(c.rootCfg.configPath)
-		if err != nil {
-			return fmt.Errorf("unable to read server config, %w", err)
-		}
-
-		c.rootCfg.config = serverCfg
+	// Load the server configuration, before anything is created from it
+	if err := c.rootCfg.loadConfig(); err != nil {
+		return err
 	}
 
 	// Create a new logger
@@ -79,43 +78,42 @@ func (c *serveSQLCfg) exec(ctx context.Context, _ []string) error {
 		return fmt.Errorf("missing %s", env.Prefix+env.DBURLSuffix)
 	}
 
-	// Open DB connection
-	pool, err := pgx.Connect(ctx, dsn)
+	// Open the DB connection pool
+	poolCfg, err := newPoolConfig(c.rootCfg.config.DatabaseConfig)
+	if err != nil {
+		return fmt.Errorf("invalid DB configuration, %w", err)
+	}
+
+	pool, err := sql.NewPool(ctx, dsn, poolCfg, logger)
 	if err != nil {
-		return fmt.Errorf("unable to open DB connection: %w", err)
+		return fmt.Errorf("unable to open DB pool: %w", err)
 	}
 
-	defer func() {
-		closeCtx, cancelFn := context.WithTimeout(ctx, time.Second*5)
-		defer cancelFn()
+	defer pool.Close()
+
+	logger.Info("DB ping success")
 
-		if err = pool.Close(closeCtx); err != nil {
-			logger.Error(
-				"unable to gracefully close DB connection",
-				"err", err,
-			)
+	// Apply the pending migrations, if enabled
+	if c.autoMigrate {
+		migrator, err := sql.NewMigrator(pool, logger)
+		if err != nil {
+			return fmt.Errorf("unable to load migrations: %w", err)
 		}
-	}()
 
-	// Check DB reachability
-	pingCtx, cancelPing := context.WithTimeout(ctx, time.Second*5)
-	defer cancelPing()
+		if err = migrator.Up(ctx); err != nil {
+			return fmt.Errorf("unable to migrate DB: %w", err)
+		}
 
-	if err = pool.Ping(pingCtx); err != nil {
-		return fmt.Errorf("unable to reach DB (ping): %w", err)
+		logger.Info("DB migrations applied")
 	}
 
-	logger.Info("DB ping success")
-
 	// Create an SQL store
-	store := sql.NewStorage(gen.New(pool))
+	store := sql.NewStorage(pool)
 
 	// Create the ingestion service
-	orchestrator := ingest.New(store, ingest.WithLogger(logger))
-	for _, provider := range defaultProviders() {
-		if err = orchestrator.Register(provider); err != nil {
-			return fmt.Errorf("unable to register provider: %w", err)
-		}
+	orchestrator, err := newOrchestrator(store, c.rootCfg.config.IngestConfig, logger)
+	if err != nil {
+		return fmt.Errorf("unable to create ingestion service: %w", err)
 	}
 
 	// Create the server instance
@@ -123,6 +121,12 @@ func (c *serveSQLCfg) exec(ctx context.Context, _ []string) error {
 		store,
 		server.WithLogger(logger),
 		server.WithConfig(c.rootCfg.config),
+		server.WithProviders(orchestrator),
+		server.WithProviderController(orchestrator),
+		server.WithEvents(orchestrator),
+		server.WithHealthCheck("db", func(ctx context.Context) (any, error) {
+			return sql.CheckPool(ctx, pool)
+		}),
 	)
 	if err != nil {
 		return fmt.Errorf("unable to create server, %w", err)
@@ -152,3 +156,41 @@ func (c *serveSQLCfg) exec(ctx context.Context, _ []string) error {
 
 	return group.Wait()
 }
+
+// newPoolConfig converts the database config to the DB pool config
+func newPoolConfig(cfg *config.Database) (sql.PoolConfig, error) {
+	if cfg == nil {
+		return sql.PoolConfig{}, nil
+	}
+
+	var (
+		poolCfg = sql.PoolConfig{
+			MaxConns: cfg.MaxConns,
+			MinConns: cfg.MinConns,
+		}
+
+		err error
+	)
+
+	if poolCfg.MaxConnLifetime, err = cfg.MaxConnLifetimeDuration(); err != nil {
+		return poolCfg, err
+	}
+
+	if poolCfg.MaxConnIdleTime, err = cfg.MaxConnIdleTimeDuration(); err != nil {
+		return poolCfg, err
+	}
+
+	if poolCfg.HealthCheckPeriod, err = cfg.HealthCheckPeriodDuration(); err != nil {
+		return poolCfg, err
+	}
+
+	if poolCfg.ConnectTimeout, err = cfg.ConnectTimeoutDuration(); err != nil {
+		return poolCfg, err
+	}
+
+	if poolCfg.StartupTimeout, err = cfg.StartupTimeoutDuration(); err != nil {
+		return poolCfg, err
+	}
+
+	return poolCfg, nil
+}
@@ -0,0 +1,143 @@
+package serve
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/joho/godotenv"
+	"github.com/peterbourgon/ff/v3"
+	"github.com/peterbourgon/ff/v3/ffcli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sig-0/fxrates/cmd/env"
+	"github.com/sig-0/fxrates/server"
+	"github.com/sig-0/fxrates/storage/sqlite"
+)
+
+// defaultDBPath is the default SQLite database file path
+const defaultDBPath = "fxrates.db"
+
+type serveSQLiteCfg struct {
+	rootCfg *serveCfg
+
+	dbPath string
+}
+
+// newServeSQLiteCmd creates the serve sqlite command
+func newServeSQLiteCmd(rootCfg *serveCfg) *ffcli.Command {
+	cfg := &serveSQLiteCfg{
+		rootCfg: rootCfg,
+	}
+
+	fs := flag.NewFlagSet("sqlite", flag.ExitOnError)
+	cfg.rootCfg.registerFlags(fs)
+
+	fs.StringVar(
+		&cfg.dbPath,
+		"db-path",
+		defaultDBPath,
+		"the path to the SQLite database file. Created and migrated if needed",
+	)
+
+	return &ffcli.Command{
+		Name:       "sqlite",
+		ShortUsage: "serve sqlite [flags]",
+		LongHelp:   "Serves the fxrates backend, using an embedded SQLite datastore",
+		FlagSet:    fs,
+		Exec:       cfg.exec,
+		Options: []ff.Option{
+			// Allow using ENV variables
+			ff.WithEnvVars(),
+			ff.WithEnvVarPrefix(env.Prefix),
+		},
+	}
+}
+
+// exec executes the server serve sqlite command
+func (c *serveSQLiteCfg) exec(ctx context.Context, _ []string) error {
+	// Load the server configuration, before anything is created from it
+	if err := c.rootCfg.loadConfig(); err != nil {
+		return err
+	}
+
+	// Create a new logger
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	// Load .env
+	if err := godotenv.Load(); err != nil {
+		logger.Warn("unable to load .env file")
+	}
+
+	// Open the DB
+	db, err := sqlite.Open(ctx, c.dbPath)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err = db.Close(); err != nil {
+			logger.Error(
+				"unable to gracefully close DB",
+				"err", err,
+			)
+		}
+	}()
+
+	// Apply any pending migrations
+	if err = sqlite.Migrate(ctx, db); err != nil {
+		return fmt.Errorf("unable to migrate DB: %w", err)
+	}
+
+	logger.Info("DB ready", "path", c.dbPath)
+
+	// Create an SQLite store
+	store := sqlite.NewStorage(db)
+
+	// Create the ingestion service
+	orchestrator, err := newOrchestrator(store, c.rootCfg.config.IngestConfig, logger)
+	if err != nil {
+		return fmt.Errorf("unable to create ingestion service: %w", err)
+	}
+
+	// Create the server instance
+	s, err := server.New(
+		store,
+		server.WithLogger(logger),
+		server.WithConfig(c.rootCfg.config),
+		server.WithProviders(orchestrator),
+		server.WithProviderController(orchestrator),
+		server.WithEvents(orchestrator),
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create server, %w", err)
+	}
+
+	runCtx, cancelFn := signal.NotifyContext(
+		ctx,
+		os.Interrupt,
+		syscall.SIGINT,
+		syscall.SIGTERM,
+		syscall.SIGQUIT,
+	)
+
+	defer cancelFn()
+
+	group, gCtx := errgroup.WithContext(runCtx)
+
+	// Start the HTTP server
+	group.Go(func() error {
+		return s.Serve(gCtx)
+	})
+
+	// Start the ingestion service
+	group.Go(func() error {
+		return orchestrator.Start(gCtx)
+	})
+
+	return group.Wait()
+}
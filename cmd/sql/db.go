@@ -0,0 +1,50 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/joho/godotenv"
+
+	"github.com/sig-0/fxrates/cmd/env"
+)
+
+// connect opens a connection to the DB set in the environment (or .env)
+func connect(ctx context.Context) (*pgx.Conn, error) {
+	// Load .env, if any
+	_ = godotenv.Load()
+
+	dsn := os.Getenv(env.Prefix + env.DBURLSuffix)
+	if dsn == "" {
+		return nil, fmt.Errorf("missing %s", env.Prefix+env.DBURLSuffix)
+	}
+
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open DB connection: %w", err)
+	}
+
+	pingCtx, cancelFn := context.WithTimeout(ctx, time.Second*5)
+	defer cancelFn()
+
+	if err = conn.Ping(pingCtx); err != nil {
+		_ = conn.Close(ctx)
+
+		return nil, fmt.Errorf("unable to ping DB: %w", err)
+	}
+
+	return conn, nil
+}
+
+// closeConn closes the DB connection, reporting any error
+func closeConn(conn *pgx.Conn) {
+	closeCtx, cancelFn := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelFn()
+
+	if err := conn.Close(closeCtx); err != nil {
+		fmt.Printf("Unable to gracefully close DB: %s\n", err.Error())
+	}
+}
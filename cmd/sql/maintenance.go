@@ -0,0 +1,102 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/peterbourgon/ff/v3"
+	"github.com/peterbourgon/ff/v3/ffcli"
+
+	"github.com/sig-0/fxrates/cmd/env"
+	dbpkg "github.com/sig-0/fxrates/storage/sql"
+)
+
+var errNotConfirmed = errors.New("maintenance scripts are destructive, confirm with --yes")
+
+// maintenanceCfg wraps the maintenance configuration
+type maintenanceCfg struct {
+	rootCfg *sqlCfg
+
+	confirmed bool
+}
+
+// newMaintenanceCmd creates the maintenance command
+func newMaintenanceCmd(rootCfg *sqlCfg) *ffcli.Command {
+	cfg := &maintenanceCfg{
+		rootCfg: rootCfg,
+	}
+
+	fs := flag.NewFlagSet("maintenance", flag.ExitOnError)
+	rootCfg.RegisterFlags(fs)
+
+	fs.BoolVar(
+		&cfg.confirmed,
+		"yes",
+		false,
+		"confirms running the destructive maintenance script",
+	)
+
+	return &ffcli.Command{
+		Name:       "maintenance",
+		ShortUsage: "sql maintenance --yes <script>",
+		LongHelp: "Runs a destructive maintenance script. Available scripts: " +
+			strings.Join(maintenanceScripts(), ", "),
+		FlagSet: fs,
+		Exec:    cfg.exec,
+		Options: []ff.Option{
+			// Allow using ENV variables
+			ff.WithEnvVars(),
+			ff.WithEnvVarPrefix(env.Prefix),
+		},
+	}
+}
+
+func (c *maintenanceCfg) exec(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	name := strings.TrimSuffix(args[0], ".sql")
+
+	script, err := dbpkg.MaintenanceFS.ReadFile(fmt.Sprintf("maintenance/%s.sql", name))
+	if err != nil {
+		return fmt.Errorf("unknown maintenance script %q", name)
+	}
+
+	if !c.confirmed {
+		return errNotConfirmed
+	}
+
+	conn, err := connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer closeConn(conn)
+
+	fmt.Printf("Running maintenance script %s...\n", name)
+
+	if _, err = conn.Exec(ctx, string(script)); err != nil {
+		return fmt.Errorf("unable to run maintenance script %q: %w", name, err)
+	}
+
+	fmt.Printf("Maintenance script %q complete\n", name)
+
+	return nil
+}
+
+// maintenanceScripts returns the names of the available maintenance scripts
+func maintenanceScripts() []string {
+	files, _ := fs.Glob(dbpkg.MaintenanceFS, "maintenance/*.sql")
+
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		names = append(names, strings.TrimSuffix(strings.TrimPrefix(file, "maintenance/"), ".sql"))
+	}
+
+	return names
+}
@@ -2,13 +2,16 @@ package sql
 
 import (
 	"context"
-	"database/sql"
+	"errors"
 	"flag"
 	"fmt"
+	"log/slog"
 	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
 
-	_ "github.com/jackc/pgx/v5/stdlib"
-	"github.com/joho/godotenv"
 	"github.com/peterbourgon/ff/v3"
 	"github.com/peterbourgon/ff/v3/ffcli"
 
@@ -16,9 +19,16 @@ import (
 	dbpkg "github.com/sig-0/fxrates/storage/sql"
 )
 
+var (
+	errMissingVersion     = errors.New("missing migration version")
+	errRevertNotConfirmed = errors.New("reverting migrations is destructive, confirm with --yes")
+)
+
 // migrateCfg wraps the migrate configuration
 type migrateCfg struct {
 	rootCfg *sqlCfg
+
+	confirmed bool
 }
 
 // newMigrateCmd creates the migrate command
@@ -30,12 +40,124 @@ func newMigrateCmd(rootCfg *sqlCfg) *ffcli.Command {
 	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
 	rootCfg.RegisterFlags(fs)
 
-	return &ffcli.Command{
+	cmd := &ffcli.Command{
 		Name:       "migrate",
-		ShortUsage: "sql migrate [migration.sql, migration2.sql ...]",
-		LongHelp:   "Runs initial DB migrations",
+		ShortUsage: "sql migrate <subcommand> [<arg>...]",
+		LongHelp: "Manages the DB schema migrations, tracked in the schema_migrations table.\n" +
+			"Applied migrations are verified against their checksums before any migration is run",
+		FlagSet: fs,
+		Exec: func(_ context.Context, _ []string) error {
+			return flag.ErrHelp
+		},
+	}
+
+	cmd.Subcommands = []*ffcli.Command{
+		cfg.newSubcommand(
+			"up",
+			"sql migrate up",
+			"Applies all pending migrations",
+			func(ctx context.Context, m *dbpkg.Migrator, _ []string) error {
+				return m.Up(ctx)
+			},
+		),
+		cfg.withConfirmation(cfg.newSubcommand(
+			"down",
+			"sql migrate down --yes",
+			"Reverts the latest applied migration. Reverting is destructive, and needs --yes",
+			func(ctx context.Context, m *dbpkg.Migrator, _ []string) error {
+				if !cfg.confirmed {
+					return errRevertNotConfirmed
+				}
+
+				return m.Down(ctx)
+			},
+		)),
+		cfg.withConfirmation(cfg.newSubcommand(
+			"to",
+			"sql migrate to [--yes] <version>",
+			"Migrates up or down to the given version. Version 0 reverts all migrations.\n"+
+				"Migrating down is destructive, and needs --yes",
+			func(ctx context.Context, m *dbpkg.Migrator, args []string) error {
+				version, err := parseVersion(args)
+				if err != nil {
+					return err
+				}
+
+				current, err := m.Current(ctx)
+				if err != nil {
+					return err
+				}
+
+				if version < current && !cfg.confirmed {
+					return errRevertNotConfirmed
+				}
+
+				return m.To(ctx, version)
+			},
+		)),
+		cfg.newSubcommand(
+			"status",
+			"sql migrate status",
+			"Shows the status of all migrations",
+			func(ctx context.Context, m *dbpkg.Migrator, _ []string) error {
+				statuses, err := m.Status(ctx)
+				if err != nil {
+					return err
+				}
+
+				printStatus(statuses)
+
+				return nil
+			},
+		),
+		cfg.newSubcommand(
+			"baseline",
+			"sql migrate baseline <version>",
+			"Marks all migrations up to the given version as applied, without running them.\n"+
+				"Use it once for DBs migrated before the migrations were tracked",
+			func(ctx context.Context, m *dbpkg.Migrator, args []string) error {
+				version, err := parseVersion(args)
+				if err != nil {
+					return err
+				}
+
+				return m.Baseline(ctx, version)
+			},
+		),
+	}
+
+	return cmd
+}
+
+// newSubcommand creates a migrate subcommand, running the given action
+func (c *migrateCfg) newSubcommand(
+	name, usage, help string,
+	action func(context.Context, *dbpkg.Migrator, []string) error,
+) *ffcli.Command {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	c.rootCfg.RegisterFlags(fs)
+
+	return &ffcli.Command{
+		Name:       name,
+		ShortUsage: usage,
+		ShortHelp:  strings.SplitN(help, "\n", 2)[0],
+		LongHelp:   help,
 		FlagSet:    fs,
-		Exec:       cfg.exec,
+		Exec: func(ctx context.Context, args []string) error {
+			conn, err := connect(ctx)
+			if err != nil {
+				return err
+			}
+
+			defer closeConn(conn)
+
+			migrator, err := dbpkg.NewMigrator(conn, slog.New(slog.NewTextHandler(os.Stdout, nil)))
+			if err != nil {
+				return err
+			}
+
+			return action(ctx, migrator, args)
+		},
 		Options: []ff.Option{
 			// Allow using ENV variables
 			ff.WithEnvVars(),
@@ -44,54 +166,64 @@ func newMigrateCmd(rootCfg *sqlCfg) *ffcli.Command {
 	}
 }
 
-func (c *migrateCfg) exec(ctx context.Context, args []string) error {
-	// Make sure some migrations are specified
-	if len(args) == 0 {
-		return fmt.Errorf("no migration files provided")
-	}
+// withConfirmation registers the --yes flag, confirming destructive migrations
+func (c *migrateCfg) withConfirmation(cmd *ffcli.Command) *ffcli.Command {
+	cmd.FlagSet.BoolVar(
+		&c.confirmed,
+		"yes",
+		false,
+		"confirms reverting migrations, which drops their tables and columns",
+	)
 
-	// Load .env
-	if err := godotenv.Load(); err != nil {
-		return fmt.Errorf("unable to load .env vars")
-	}
-
-	dsn := os.Getenv(env.Prefix + env.DBURLSuffix)
+	return cmd
+}
 
-	// Open the DB
-	db, err := sql.Open("pgx", dsn)
-	if err != nil {
-		return err
+// parseVersion parses the migration version argument
+func parseVersion(args []string) (int64, error) {
+	if len(args) != 1 {
+		return 0, errMissingVersion
 	}
 
-	// Ping the DB
-	if err = db.PingContext(ctx); err != nil {
-		return fmt.Errorf("unable to ping DB: %w", err)
+	version, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("invalid migration version %q", args[0])
 	}
 
-	defer func() {
-		if err = db.Close(); err != nil {
-			fmt.Printf("Unable to gracefully close DB: %s\n", err.Error())
-		}
-	}()
+	return version, nil
+}
+
+// printStatus prints the migration statuses as a table
+func printStatus(statuses []*dbpkg.MigrationStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 
-	for _, name := range args {
-		path := fmt.Sprintf("schema/%s", name)
+	_, _ = fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
 
-		sqlBytes, err := dbpkg.SchemaFS.ReadFile(path)
-		if err != nil {
-			return fmt.Errorf("unable to read migration %q: %w", name, err)
+	for _, status := range statuses {
+		var (
+			name      = "?"
+			state     = "pending"
+			appliedAt = "-"
+		)
+
+		if status.Migration != nil {
+			name = status.Migration.Name
 		}
 
-		fmt.Printf("Running migration %s...\n", name)
+		switch {
+		case status.Migration == nil:
+			state = "missing"
+		case status.Modified():
+			state = "modified"
+		case status.Applied():
+			state = "applied"
+		}
 
-		if _, err := db.ExecContext(ctx, string(sqlBytes)); err != nil {
-			return fmt.Errorf("unable to run migration %q: %w", name, err)
+		if status.Applied() {
+			appliedAt = status.AppliedAt.UTC().Format(time.RFC3339)
 		}
 
-		fmt.Printf("Migration %q complete\n", name)
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", status.Version, name, state, appliedAt)
 	}
 
-	fmt.Println("All migrations complete!")
-
-	return nil
+	_ = w.Flush()
 }
@@ -30,6 +30,7 @@ func NewSQLCmd() *ffcli.Command {
 	// Add the subcommands
 	cmd.Subcommands = []*ffcli.Command{
 		newMigrateCmd(cfg),
+		newMaintenanceCmd(cfg),
 	}
 
 	return cmd
@@ -0,0 +1,115 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/sig-0/fxrates/storage"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+var (
+	ErrRateNotFound  = errors.New("rate not found")
+	ErrAmbiguousRate = errors.New("ambiguous rate (multiple sources/types match, specify source and type)")
+	ErrSameCurrency  = errors.New("from and to currencies must differ")
+	ErrInvalidAmount = errors.New("invalid amount (must be a finite number)")
+	ErrMixedSources  = errors.New("cross rate legs come from different sources (mixing not allowed)")
+	ErrAsOfSkew      = errors.New("cross rate legs are too far apart in time (skew not allowed)")
+)
+
+const defaultMaxAsOfSkew = time.Hour * 24
+
+// ConversionRequest is a single currency conversion request
+type ConversionRequest struct {
+	RateRequest
+
+	Amount float64
+}
+
+// Conversion is the result of a currency conversion,
+// along with the provenance of the rate used
+type Conversion struct {
+	AsOf        time.Time       `json:"as_of"`
+	FetchedAt   time.Time       `json:"fetched_at"`
+	Pivot       *types.Currency `json:"pivot,omitempty"`
+	From        types.Currency  `json:"from"`
+	To          types.Currency  `json:"to"`
+	RateType    types.RateType  `json:"rate_type"`
+	Source      types.Source    `json:"source"`
+	Legs        []*Leg          `json:"legs"`
+	Amount      float64         `json:"amount"`
+	Result      float64         `json:"result"`       // float approximation of ResultExact
+	ResultExact types.Decimal   `json:"result_exact"` // exact up to types.MaxRateScale decimal places
+	Rate        float64         `json:"rate"`         // the effective from -> to rate
+	RateExact   types.Decimal   `json:"rate_exact"`   // the exact effective from -> to rate
+	Inverted    bool            `json:"inverted"`     // set if the rate was derived from the to -> from pair
+	Derived     bool            `json:"derived"`      // set if the rate was triangulated through a pivot
+}
+
+// Converter resolves rates and converts amounts between currencies using stored rates
+type Converter struct {
+	storage storage.Storage
+
+	sourcePivots map[types.Source]types.Currency
+	defaultPivot types.Currency
+	maxAsOfSkew  time.Duration
+}
+
+// NewConverter creates a new converter on top of the given storage
+func NewConverter(storage storage.Storage, opts ...Option) *Converter {
+	c := &Converter{
+		storage:      storage,
+		defaultPivot: types.CurrencyUSD,
+		sourcePivots: map[types.Source]types.Currency{
+			types.SourceBCV: types.CurrencyVES, // BCV only publishes X/VES pairs
+		},
+		maxAsOfSkew: defaultMaxAsOfSkew,
+	}
+
+	// Apply the options
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Convert converts the requested amount using the latest rate as of the request time.
+// The rate is resolved as described in Rate
+func (c *Converter) Convert(ctx context.Context, req *ConversionRequest) (*Conversion, error) {
+	if math.IsNaN(req.Amount) || math.IsInf(req.Amount, 0) {
+		return nil, ErrInvalidAmount
+	}
+
+	quote, err := c.Rate(ctx, &req.RateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	// The result is computed from the unrounded rate, and rounded once
+	result := types.NewDecimalFromRat(
+		new(big.Rat).Mul(types.NewDecimalFromFloat(req.Amount).Rat(), quote.ratio()),
+		types.MaxRateScale,
+	)
+
+	return &Conversion{
+		AsOf:        quote.AsOf,
+		FetchedAt:   quote.FetchedAt,
+		Pivot:       quote.Pivot,
+		From:        quote.Base,
+		To:          quote.Target,
+		RateType:    quote.RateType,
+		Source:      quote.Source,
+		Legs:        quote.Legs,
+		Amount:      req.Amount,
+		Result:      result.Float64(),
+		ResultExact: result,
+		Rate:        quote.Rate,
+		RateExact:   quote.RateExact,
+		Inverted:    quote.Inverted,
+		Derived:     quote.Derived,
+	}, nil
+}
@@ -0,0 +1,215 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/storage/mock"
+
+	"github.com/sig-0/fxrates/provider/currencies"
+	"github.com/sig-0/fxrates/provider/ves"
+
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+// pairStorage returns a mock storage that serves the given rates by pair
+func pairStorage(t *testing.T, rates ...*types.ExchangeRate) *mock.Storage {
+	t.Helper()
+
+	return &mock.Storage{
+		RateAsOfFn: func(
+			_ context.Context,
+			query *types.RateQuery,
+			_ time.Time,
+		) (*types.Page[*types.ExchangeRate], error) {
+			page := &types.Page[*types.ExchangeRate]{}
+
+			for _, rate := range rates {
+				if rate.Base != query.Base || rate.Target != *query.Target {
+					continue
+				}
+
+				page.Results = append(page.Results, rate)
+				page.Total++
+			}
+
+			return page, nil
+		},
+	}
+}
+
+func TestConverter_Convert(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asOf = time.Date(2026, time.January, 13, 0, 0, 0, 0, time.UTC)
+
+		usdVES = &types.ExchangeRate{
+			AsOf:     asOf,
+			Base:     currencies.USD,
+			Target:   currencies.VES,
+			RateType: types.RateTypeMID,
+			Source:   ves.BCVSource,
+			Rate:     320,
+		}
+	)
+
+	t.Run("same currency", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConverter(&mock.Storage{})
+
+		_, err := c.Convert(context.Background(), &ConversionRequest{
+			RateRequest: RateRequest{
+				Base:   currencies.USD,
+				Target: currencies.USD,
+			},
+			Amount: 1,
+		})
+
+		assert.ErrorIs(t, err, ErrSameCurrency)
+	})
+
+	t.Run("direct pair", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConverter(pairStorage(t, usdVES))
+
+		conversion, err := c.Convert(context.Background(), &ConversionRequest{
+			RateRequest: RateRequest{
+				Base:   currencies.USD,
+				Target: currencies.VES,
+			},
+			Amount: 125.5,
+		})
+
+		require.NoError(t, err)
+
+		assert.False(t, conversion.Inverted)
+		assert.Equal(t, 320.0, conversion.Rate)
+		assert.Equal(t, "320", conversion.RateExact.String())
+		assert.InDelta(t, 40160.0, conversion.Result, 1e-9)
+		assert.Equal(t, "40160", conversion.ResultExact.String())
+		assert.Equal(t, ves.BCVSource, conversion.Source)
+		assert.Equal(t, types.RateTypeMID, conversion.RateType)
+		assert.Equal(t, asOf, conversion.AsOf)
+	})
+
+	t.Run("inverse pair", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConverter(pairStorage(t, usdVES))
+
+		conversion, err := c.Convert(context.Background(), &ConversionRequest{
+			RateRequest: RateRequest{
+				Base:   currencies.VES,
+				Target: currencies.USD,
+			},
+			Amount: 640,
+		})
+
+		require.NoError(t, err)
+
+		assert.True(t, conversion.Inverted)
+		assert.InDelta(t, 1.0/320, conversion.Rate, 1e-12)
+		assert.InDelta(t, 2.0, conversion.Result, 1e-9)
+		assert.Equal(t, currencies.VES, conversion.From)
+		assert.Equal(t, currencies.USD, conversion.To)
+	})
+
+	t.Run("exact result of inverse pair", func(t *testing.T) {
+		t.Parallel()
+
+		usdVES := &types.ExchangeRate{
+			AsOf:      asOf,
+			Base:      currencies.USD,
+			Target:    currencies.VES,
+			RateType:  types.RateTypeMID,
+			Source:    ves.BCVSource,
+			Rate:      341.7412,
+			RateExact: types.MustParseDecimal("341.7412"),
+		}
+
+		c := NewConverter(pairStorage(t, usdVES))
+
+		conversion, err := c.Convert(context.Background(), &ConversionRequest{
+			RateRequest: RateRequest{
+				Base:   currencies.VES,
+				Target: currencies.USD,
+			},
+			Amount: 1000,
+		})
+
+		require.NoError(t, err)
+
+		// The result is rounded once, not multiplied from the rounded rate
+		assert.Equal(t, "0.002926190930446783", conversion.RateExact.String())
+		assert.Equal(t, "2.926190930446782536", conversion.ResultExact.String())
+		assert.InDelta(t, 1000/341.7412, conversion.Result, 1e-12)
+	})
+
+	t.Run("ambiguous rate", func(t *testing.T) {
+		t.Parallel()
+
+		other := *usdVES
+		other.Source = ves.BinanceP2PSource
+
+		c := NewConverter(pairStorage(t, usdVES, &other))
+
+		_, err := c.Convert(context.Background(), &ConversionRequest{
+			RateRequest: RateRequest{
+				Base:   currencies.USD,
+				Target: currencies.VES,
+			},
+			Amount: 1,
+		})
+
+		assert.ErrorIs(t, err, ErrAmbiguousRate)
+	})
+
+	t.Run("rate not found", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConverter(pairStorage(t, usdVES))
+
+		_, err := c.Convert(context.Background(), &ConversionRequest{
+			RateRequest: RateRequest{
+				Base:   currencies.EUR,
+				Target: currencies.USD,
+			},
+			Amount: 1,
+		})
+
+		assert.ErrorIs(t, err, ErrRateNotFound)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConverter(&mock.Storage{
+			RateAsOfFn: func(
+				_ context.Context,
+				_ *types.RateQuery,
+				_ time.Time,
+			) (*types.Page[*types.ExchangeRate], error) {
+				return nil, errors.New("boom")
+			},
+		})
+
+		_, err := c.Convert(context.Background(), &ConversionRequest{
+			RateRequest: RateRequest{
+				Base:   currencies.USD,
+				Target: currencies.VES,
+			},
+			Amount: 1,
+		})
+
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrRateNotFound)
+	})
+}
@@ -0,0 +1,33 @@
+package fx
+
+import (
+	"time"
+
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+type Option func(c *Converter)
+
+// WithDefaultPivot specifies the pivot currency used for cross rates
+// of sources without a dedicated pivot. Defaults to USD
+func WithDefaultPivot(pivot types.Currency) Option {
+	return func(c *Converter) {
+		c.defaultPivot = pivot
+	}
+}
+
+// WithSourcePivot specifies the pivot currency used for cross rates of the given source.
+// Defaults to VES for BCV
+func WithSourcePivot(source types.Source, pivot types.Currency) Option {
+	return func(c *Converter) {
+		c.sourcePivots[source] = pivot
+	}
+}
+
+// WithMaxAsOfSkew specifies the maximum as-of distance between
+// the legs of a cross rate. Defaults to 24h
+func WithMaxAsOfSkew(d time.Duration) Option {
+	return func(c *Converter) {
+		c.maxAsOfSkew = d
+	}
+}
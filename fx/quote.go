@@ -0,0 +1,378 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+// maxLegCandidates is the maximum number of stored rates considered per cross rate leg
+const maxLegCandidates = int32(500)
+
+// RateRequest is a single rate resolution request
+type RateRequest struct {
+	AsOf     time.Time
+	Source   *types.Source
+	RateType *types.RateType
+	Pivot    *types.Currency // pivot override for cross rates
+	Base     types.Currency
+	Target   types.Currency
+
+	AllowMixedSources bool // allow cross rate legs from different sources
+	AllowAsOfSkew     bool // allow cross rate legs too far apart in time
+}
+
+// Leg is a single stored rate used to resolve a quote
+type Leg struct {
+	Rate     *types.ExchangeRate `json:"rate"`
+	Inverted bool                `json:"inverted"` // set if the stored rate was inverted
+}
+
+// value returns the exact effective rate of the leg.
+// Zero stored rates can't be inverted, and must be checked first
+func (l *Leg) value() *big.Rat {
+	r := l.Rate.Exact().Rat()
+	if l.Inverted {
+		return r.Inv(r)
+	}
+
+	return r
+}
+
+// zero returns true if the stored rate of the leg is 0
+func (l *Leg) zero() bool {
+	return l.Rate.Exact().IsZero()
+}
+
+// Quote is a resolved base -> target rate, along with the stored rates used to derive it
+type Quote struct {
+	AsOf      time.Time       `json:"as_of"`
+	FetchedAt time.Time       `json:"fetched_at"`
+	Pivot     *types.Currency `json:"pivot,omitempty"`
+	Base      types.Currency  `json:"base"`
+	Target    types.Currency  `json:"target"`
+	RateType  types.RateType  `json:"rate_type"`
+	Source    types.Source    `json:"source"`
+	Legs      []*Leg          `json:"legs"`
+	Rate      float64         `json:"rate"`       // float approximation of RateExact
+	RateExact types.Decimal   `json:"rate_exact"` // exact up to types.MaxRateScale decimal places
+	Inverted  bool            `json:"inverted"`   // set if the rate is the inverse of the stored target -> base pair
+	Derived   bool            `json:"derived"`    // set if the rate was triangulated through a pivot
+}
+
+// ratio returns the unrounded rate of the quote, from its legs.
+// A cross quote divides the base leg by the target leg
+func (q *Quote) ratio() *big.Rat {
+	r := q.Legs[0].value()
+	if len(q.Legs) == 2 {
+		r.Quo(r, q.Legs[1].value())
+	}
+
+	return r
+}
+
+// Rate resolves the latest base -> target rate as of the request time.
+// The stored base -> target pair is preferred, followed by the inverse of the
+// target -> base pair, followed by a cross rate triangulated through a pivot currency.
+// Cross rate legs must share the rate type, and unless explicitly allowed,
+// the source and (roughly) the as-of time
+func (c *Converter) Rate(ctx context.Context, req *RateRequest) (*Quote, error) {
+	if req.Base == req.Target {
+		return nil, ErrSameCurrency
+	}
+
+	// Check the direct pair
+	direct, err := c.legs(ctx, req, req.Base, req.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	if quote, err := singleLegQuote(req, direct); !errors.Is(err, ErrRateNotFound) {
+		return quote, err
+	}
+
+	// Check the inverse pair
+	inverse, err := c.legs(ctx, req, req.Target, req.Base)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, leg := range inverse {
+		leg.Inverted = true
+	}
+
+	if quote, err := singleLegQuote(req, inverse); !errors.Is(err, ErrRateNotFound) {
+		return quote, err
+	}
+
+	// Triangulate through the pivots
+	for _, pivot := range c.pivots(req) {
+		if pivot == req.Base || pivot == req.Target {
+			continue
+		}
+
+		quote, err := c.crossQuote(ctx, req, pivot)
+		if errors.Is(err, ErrRateNotFound) {
+			continue
+		}
+
+		return quote, err
+	}
+
+	return nil, ErrRateNotFound
+}
+
+// singleLegQuote constructs the quote from a single matching leg
+func singleLegQuote(req *RateRequest, legs []*Leg) (*Quote, error) {
+	switch len(legs) {
+	case 0:
+		return nil, ErrRateNotFound
+	case 1:
+	default:
+		return nil, ErrAmbiguousRate
+	}
+
+	leg := legs[0]
+	if leg.Inverted && leg.zero() {
+		return nil, fmt.Errorf("unable to invert zero rate: %w", ErrRateNotFound)
+	}
+
+	// Direct rates are kept as stored, inverted ones are rounded
+	rate := leg.Rate.Exact()
+	if leg.Inverted {
+		rate = types.NewDecimalFromRat(leg.value(), types.MaxRateScale)
+	}
+
+	return &Quote{
+		AsOf:      leg.Rate.AsOf,
+		FetchedAt: leg.Rate.FetchedAt,
+		Base:      req.Base,
+		Target:    req.Target,
+		RateType:  leg.Rate.RateType,
+		Source:    leg.Rate.Source,
+		Legs:      legs,
+		Rate:      rate.Float64(),
+		RateExact: rate,
+		Inverted:  leg.Inverted,
+	}, nil
+}
+
+// crossQuote triangulates the base -> target rate through the given pivot
+func (c *Converter) crossQuote(
+	ctx context.Context,
+	req *RateRequest,
+	pivot types.Currency,
+) (*Quote, error) {
+	baseLegs, err := c.pivotLegs(ctx, req, req.Base, pivot)
+	if err != nil {
+		return nil, err
+	}
+
+	targetLegs, err := c.pivotLegs(ctx, req, req.Target, pivot)
+	if err != nil {
+		return nil, err
+	}
+
+	type pair struct {
+		base, target *Leg
+	}
+
+	var same, mixed []pair
+
+	for _, bl := range baseLegs {
+		for _, tl := range targetLegs {
+			if bl.Rate.RateType != tl.Rate.RateType {
+				continue
+			}
+
+			if bl.Rate.Source == tl.Rate.Source {
+				same = append(same, pair{base: bl, target: tl})
+
+				continue
+			}
+
+			mixed = append(mixed, pair{base: bl, target: tl})
+		}
+	}
+
+	candidates := same
+	if len(candidates) == 0 && req.AllowMixedSources {
+		candidates = mixed
+	}
+
+	switch len(candidates) {
+	case 0:
+		if len(mixed) > 0 {
+			return nil, ErrMixedSources
+		}
+
+		return nil, ErrRateNotFound
+	case 1:
+	default:
+		return nil, ErrAmbiguousRate
+	}
+
+	var (
+		bl = candidates[0].base
+		tl = candidates[0].target
+	)
+
+	skew := bl.Rate.AsOf.Sub(tl.Rate.AsOf).Abs()
+	if skew > c.maxAsOfSkew && !req.AllowAsOfSkew {
+		return nil, ErrAsOfSkew
+	}
+
+	// A zero stored rate can't be triangulated, whether it's inverted or not
+	if bl.zero() || tl.zero() {
+		return nil, fmt.Errorf("unable to triangulate zero rate: %w", ErrRateNotFound)
+	}
+
+	// Triangulated from the exact leg rates, and rounded once
+	rate := types.NewDecimalFromRat(
+		new(big.Rat).Quo(bl.value(), tl.value()),
+		types.MaxRateScale,
+	)
+
+	// The quote is only as fresh as its oldest leg
+	var (
+		asOf      = bl.Rate.AsOf
+		fetchedAt = bl.Rate.FetchedAt
+		source    = bl.Rate.Source
+	)
+
+	if tl.Rate.AsOf.Before(asOf) {
+		asOf = tl.Rate.AsOf
+	}
+
+	if tl.Rate.FetchedAt.Before(fetchedAt) {
+		fetchedAt = tl.Rate.FetchedAt
+	}
+
+	if tl.Rate.Source != source {
+		source = types.Source(source.String() + "+" + tl.Rate.Source.String())
+	}
+
+	return &Quote{
+		AsOf:      asOf,
+		FetchedAt: fetchedAt,
+		Pivot:     &pivot,
+		Base:      req.Base,
+		Target:    req.Target,
+		RateType:  bl.Rate.RateType,
+		Source:    source,
+		Legs:      []*Leg{bl, tl},
+		Rate:      rate.Float64(),
+		RateExact: rate,
+		Derived:   true,
+	}, nil
+}
+
+// pivotLegs fetches all currency -> pivot legs, using the inverse pairs
+// for the (source, rate type) buckets not stored directly
+func (c *Converter) pivotLegs(
+	ctx context.Context,
+	req *RateRequest,
+	currency, pivot types.Currency,
+) ([]*Leg, error) {
+	direct, err := c.legs(ctx, req, currency, pivot)
+	if err != nil {
+		return nil, err
+	}
+
+	inverse, err := c.legs(ctx, req, pivot, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	type bucket struct {
+		source   types.Source
+		rateType types.RateType
+	}
+
+	seen := make(map[bucket]struct{}, len(direct))
+	for _, leg := range direct {
+		seen[bucket{source: leg.Rate.Source, rateType: leg.Rate.RateType}] = struct{}{}
+	}
+
+	for _, leg := range inverse {
+		if _, ok := seen[bucket{source: leg.Rate.Source, rateType: leg.Rate.RateType}]; ok {
+			continue
+		}
+
+		leg.Inverted = true
+		direct = append(direct, leg)
+	}
+
+	return direct, nil
+}
+
+// legs fetches the stored base -> target rates matching the request filters
+func (c *Converter) legs(
+	ctx context.Context,
+	req *RateRequest,
+	base, target types.Currency,
+) ([]*Leg, error) {
+	q := &types.RateQuery{
+		Base:     base,
+		Target:   &target,
+		Source:   req.Source,
+		RateType: req.RateType,
+		Limit:    maxLegCandidates,
+	}
+
+	page, err := c.storage.RateAsOf(ctx, q, req.AsOf)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch rates: %w", err)
+	}
+
+	if page == nil {
+		return nil, nil
+	}
+
+	legs := make([]*Leg, 0, len(page.Results))
+	for _, rate := range page.Results {
+		legs = append(legs, &Leg{Rate: rate})
+	}
+
+	return legs, nil
+}
+
+// pivots returns the pivot currencies to triangulate through, in order of preference
+func (c *Converter) pivots(req *RateRequest) []types.Currency {
+	if req.Pivot != nil {
+		return []types.Currency{*req.Pivot}
+	}
+
+	if req.Source != nil {
+		if pivot, ok := c.sourcePivots[*req.Source]; ok {
+			return []types.Currency{pivot}
+		}
+
+		return []types.Currency{c.defaultPivot}
+	}
+
+	// No source preference, try the default pivot first
+	pivots := []types.Currency{c.defaultPivot}
+
+	sourcePivots := make([]types.Currency, 0, len(c.sourcePivots))
+	for _, pivot := range c.sourcePivots {
+		sourcePivots = append(sourcePivots, pivot)
+	}
+
+	sort.Slice(sourcePivots, func(i, j int) bool {
+		return sourcePivots[i] < sourcePivots[j]
+	})
+
+	for _, pivot := range sourcePivots {
+		if pivot != c.defaultPivot && pivots[len(pivots)-1] != pivot {
+			pivots = append(pivots, pivot)
+		}
+	}
+
+	return pivots
+}
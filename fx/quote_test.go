@@ -0,0 +1,268 @@
+package fx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/provider/currencies"
+	"github.com/sig-0/fxrates/provider/ves"
+
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+func TestConverter_Rate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asOf = time.Date(2026, time.January, 13, 0, 0, 0, 0, time.UTC)
+
+		newRate = func(
+			base, target types.Currency,
+			source types.Source,
+			rate float64,
+			asOf time.Time,
+		) *types.ExchangeRate {
+			return &types.ExchangeRate{
+				AsOf:      asOf,
+				FetchedAt: asOf,
+				Base:      base,
+				Target:    target,
+				RateType:  types.RateTypeMID,
+				Source:    source,
+				Rate:      rate,
+			}
+		}
+
+		eurVES = newRate(currencies.EUR, currencies.VES, ves.BCVSource, 350, asOf)
+		usdVES = newRate(currencies.USD, currencies.VES, ves.BCVSource, 320, asOf)
+	)
+
+	t.Run("triangulated through source pivot", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConverter(pairStorage(t, eurVES, usdVES))
+
+		quote, err := c.Rate(context.Background(), &RateRequest{
+			Base:   currencies.EUR,
+			Target: currencies.USD,
+		})
+
+		require.NoError(t, err)
+
+		assert.True(t, quote.Derived)
+		assert.False(t, quote.Inverted)
+		assert.InDelta(t, 350.0/320.0, quote.Rate, 1e-12)
+		assert.Equal(t, "1.09375", quote.RateExact.String())
+		assert.Equal(t, ves.BCVSource, quote.Source)
+
+		require.NotNil(t, quote.Pivot)
+		assert.Equal(t, currencies.VES, *quote.Pivot)
+
+		require.Len(t, quote.Legs, 2)
+		assert.Equal(t, eurVES, quote.Legs[0].Rate)
+		assert.Equal(t, usdVES, quote.Legs[1].Rate)
+	})
+
+	t.Run("triangulated through inverted legs", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			usdEUR = newRate(currencies.USD, currencies.EUR, "ECB", 0.9, asOf)
+			usdCNY = newRate(currencies.USD, currencies.CNY, "ECB", 7.2, asOf)
+
+			c = NewConverter(pairStorage(t, usdEUR, usdCNY))
+		)
+
+		quote, err := c.Rate(context.Background(), &RateRequest{
+			Base:   currencies.EUR,
+			Target: currencies.CNY,
+		})
+
+		require.NoError(t, err)
+
+		assert.True(t, quote.Derived)
+		assert.InDelta(t, 7.2/0.9, quote.Rate, 1e-12)
+
+		require.NotNil(t, quote.Pivot)
+		assert.Equal(t, currencies.USD, *quote.Pivot)
+
+		require.Len(t, quote.Legs, 2)
+		assert.True(t, quote.Legs[0].Inverted)
+		assert.True(t, quote.Legs[1].Inverted)
+	})
+
+	t.Run("exact inverted rate", func(t *testing.T) {
+		t.Parallel()
+
+		usdVES := newRate(currencies.USD, currencies.VES, ves.BCVSource, 341.7412, asOf)
+		usdVES.RateExact = types.MustParseDecimal("341.7412")
+
+		c := NewConverter(pairStorage(t, usdVES))
+
+		quote, err := c.Rate(context.Background(), &RateRequest{
+			Base:   currencies.VES,
+			Target: currencies.USD,
+		})
+
+		require.NoError(t, err)
+
+		assert.True(t, quote.Inverted)
+		assert.Equal(t, "0.002926190930446783", quote.RateExact.String())
+		assert.InDelta(t, 1/341.7412, quote.Rate, 1e-18)
+	})
+
+	t.Run("exact cross rate", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			eur = newRate(currencies.EUR, currencies.VES, ves.BCVSource, 399.6871, asOf)
+			usd = newRate(currencies.USD, currencies.VES, ves.BCVSource, 341.7412, asOf)
+
+			c = NewConverter(pairStorage(t, eur, usd))
+		)
+
+		quote, err := c.Rate(context.Background(), &RateRequest{
+			Base:   currencies.EUR,
+			Target: currencies.USD,
+		})
+
+		require.NoError(t, err)
+
+		assert.True(t, quote.Derived)
+		assert.Equal(t, "1.169560767036576216", quote.RateExact.String())
+	})
+
+	t.Run("zero inverted leg refused", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			usdEUR = newRate(currencies.USD, currencies.EUR, "ECB", 0, asOf)
+			usdCNY = newRate(currencies.USD, currencies.CNY, "ECB", 7.2, asOf)
+
+			c = NewConverter(pairStorage(t, usdEUR, usdCNY))
+		)
+
+		// The EUR -> USD leg inverts the zero USD -> EUR rate
+		quote, err := c.Rate(context.Background(), &RateRequest{
+			Base:   currencies.EUR,
+			Target: currencies.CNY,
+		})
+
+		assert.ErrorIs(t, err, ErrRateNotFound)
+		assert.Nil(t, quote)
+	})
+
+	t.Run("mixed sources refused", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			usdVESP2P = newRate(currencies.USD, currencies.VES, ves.BinanceP2PSource, 500, asOf)
+
+			c = NewConverter(pairStorage(t, eurVES, usdVESP2P))
+		)
+
+		_, err := c.Rate(context.Background(), &RateRequest{
+			Base:   currencies.EUR,
+			Target: currencies.USD,
+		})
+
+		assert.ErrorIs(t, err, ErrMixedSources)
+	})
+
+	t.Run("mixed sources allowed", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			usdVESP2P = newRate(currencies.USD, currencies.VES, ves.BinanceP2PSource, 500, asOf)
+
+			c = NewConverter(pairStorage(t, eurVES, usdVESP2P))
+		)
+
+		quote, err := c.Rate(context.Background(), &RateRequest{
+			Base:              currencies.EUR,
+			Target:            currencies.USD,
+			AllowMixedSources: true,
+		})
+
+		require.NoError(t, err)
+
+		assert.InDelta(t, 350.0/500.0, quote.Rate, 1e-12)
+		assert.Equal(t, types.Source("BCV+BinanceP2P"), quote.Source)
+	})
+
+	t.Run("as-of skew refused", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			staleUSDVES = newRate(currencies.USD, currencies.VES, ves.BCVSource, 300, asOf.Add(-time.Hour*72))
+
+			c = NewConverter(pairStorage(t, eurVES, staleUSDVES))
+		)
+
+		_, err := c.Rate(context.Background(), &RateRequest{
+			Base:   currencies.EUR,
+			Target: currencies.USD,
+		})
+
+		assert.ErrorIs(t, err, ErrAsOfSkew)
+	})
+
+	t.Run("as-of skew allowed", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			staleUSDVES = newRate(currencies.USD, currencies.VES, ves.BCVSource, 300, asOf.Add(-time.Hour*72))
+
+			c = NewConverter(pairStorage(t, eurVES, staleUSDVES))
+		)
+
+		quote, err := c.Rate(context.Background(), &RateRequest{
+			Base:          currencies.EUR,
+			Target:        currencies.USD,
+			AllowAsOfSkew: true,
+		})
+
+		require.NoError(t, err)
+
+		// The quote is only as fresh as its oldest leg
+		assert.Equal(t, staleUSDVES.AsOf, quote.AsOf)
+	})
+
+	t.Run("max as-of skew option", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			staleUSDVES = newRate(currencies.USD, currencies.VES, ves.BCVSource, 300, asOf.Add(-time.Hour*72))
+
+			c = NewConverter(
+				pairStorage(t, eurVES, staleUSDVES),
+				WithMaxAsOfSkew(time.Hour*96),
+			)
+		)
+
+		_, err := c.Rate(context.Background(), &RateRequest{
+			Base:   currencies.EUR,
+			Target: currencies.USD,
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("pivot override", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConverter(pairStorage(t, eurVES, usdVES))
+
+		_, err := c.Rate(context.Background(), &RateRequest{
+			Base:   currencies.EUR,
+			Target: currencies.USD,
+			Pivot:  &currencies.CNY,
+		})
+
+		assert.ErrorIs(t, err, ErrRateNotFound)
+	})
+}
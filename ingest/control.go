@@ -0,0 +1,109 @@
+package ingest
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrProviderNotFound is returned when no provider is registered under the given name
+var ErrProviderNotFound = errors.New("provider not found")
+
+// Controller provides manual control over registered providers
+type Controller interface {
+	// Trigger queues up an immediate fetch for the provider
+	Trigger(name string) error
+
+	// Pause stops scheduling fetches for the provider
+	Pause(name string) error
+
+	// Resume resumes scheduling fetches for a paused provider
+	Resume(name string) error
+}
+
+// Trigger queues up an immediate fetch for the provider with the given name,
+// replacing its currently scheduled fetch. A paused provider is fetched once, and remains paused
+func (o *Orchestrator) Trigger(name string) error {
+	id, rp, err := o.lookupProvider(name)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+
+	o.pushIngest(now, id, rp, rp.trigger(now))
+	o.publishStatus(now, rp)
+
+	o.logger.Info(
+		"triggered provider",
+		"name", name,
+	)
+
+	return nil
+}
+
+// Pause stops scheduling fetches for the provider with the given name.
+// A fetch that's already running is completed, but not rescheduled
+func (o *Orchestrator) Pause(name string) error {
+	_, rp, err := o.lookupProvider(name)
+	if err != nil {
+		return err
+	}
+
+	if !rp.pause() {
+		return nil
+	}
+
+	o.publishStatus(time.Now().UTC(), rp)
+
+	o.logger.Info(
+		"paused provider",
+		"name", name,
+	)
+
+	return nil
+}
+
+// Resume resumes scheduling fetches for the paused provider with the given name,
+// starting with an immediate fetch. Resuming an active provider is a no-op
+func (o *Orchestrator) Resume(name string) error {
+	id, rp, err := o.lookupProvider(name)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+
+	generation, resumed := rp.resume(now)
+	if !resumed {
+		return nil
+	}
+
+	o.pushIngest(now, id, rp, generation)
+	o.publishStatus(now, rp)
+
+	o.logger.Info(
+		"resumed provider",
+		"name", name,
+	)
+
+	return nil
+}
+
+// Deregister removes the provider with the given name from the orchestrator.
+// The results of a fetch that's already running are discarded
+func (o *Orchestrator) Deregister(name string) error {
+	id, _, err := o.lookupProvider(name)
+	if err != nil {
+		return err
+	}
+
+	o.registeredProviders.Delete(id)
+	o.providerNames.Delete(name)
+
+	o.logger.Info(
+		"deregistered provider",
+		"name", name,
+	)
+
+	return nil
+}
@@ -0,0 +1,223 @@
+package ingest
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/storage/mock"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+// newCountingProvider creates a provider that signals each fetch on the returned channel
+func newCountingProvider(interval time.Duration) (*mockProvider, <-chan struct{}) {
+	fetchCh := make(chan struct{}, 10)
+
+	return &mockProvider{
+		nameFn: func() string {
+			return testProviderName
+		},
+		intervalFn: func() time.Duration {
+			return interval
+		},
+		fetchFn: func(_ context.Context) ([]*types.ExchangeRate, error) {
+			fetchCh <- struct{}{}
+
+			return nil, nil
+		},
+	}, fetchCh
+}
+
+// startOrchestrator starts the orchestrator, and stops it on test cleanup
+func startOrchestrator(t *testing.T, o *Orchestrator) {
+	t.Helper()
+
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		errCh       = make(chan error, 1)
+	)
+
+	go func() {
+		errCh <- o.Start(ctx)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+
+		assert.NoError(t, <-errCh)
+	})
+}
+
+// waitFetch waits for a single provider fetch
+func waitFetch(t *testing.T, fetchCh <-chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-fetchCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for fetch")
+	}
+}
+
+// assertNoFetch asserts no provider fetch happens for a short while
+func assertNoFetch(t *testing.T, fetchCh <-chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-fetchCh:
+		t.Fatal("unexpected fetch")
+	case <-time.After(time.Millisecond * 200):
+	}
+}
+
+func TestOrchestrator_Control(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown provider", func(t *testing.T) {
+		t.Parallel()
+
+		o := New(&mock.Storage{})
+
+		assert.ErrorIs(t, o.Trigger(testProviderName), ErrProviderNotFound)
+		assert.ErrorIs(t, o.Pause(testProviderName), ErrProviderNotFound)
+		assert.ErrorIs(t, o.Resume(testProviderName), ErrProviderNotFound)
+		assert.ErrorIs(t, o.Deregister(testProviderName), ErrProviderNotFound)
+	})
+
+	t.Run("trigger", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			provider, fetchCh = newCountingProvider(time.Hour)
+
+			o = New(&mock.Storage{}, WithQueryInterval(time.Millisecond*10))
+		)
+
+		require.NoError(t, o.Register(provider))
+		startOrchestrator(t, o)
+
+		waitFetch(t, fetchCh)
+
+		// Wait for the regular reschedule
+		require.Eventually(t, func() bool {
+			status, _ := o.Provider(testProviderName)
+
+			return status.NextRun != nil
+		}, 5*time.Second, time.Millisecond*10)
+
+		require.NoError(t, o.Trigger(testProviderName))
+		waitFetch(t, fetchCh)
+
+		// The replaced hourly fetch is rescheduled only once
+		require.Eventually(t, func() bool {
+			status, _ := o.Provider(testProviderName)
+
+			return status.NextRun != nil
+		}, 5*time.Second, time.Millisecond*10)
+
+		o.qMux.Lock()
+		defer o.qMux.Unlock()
+
+		assert.Equal(t, 2, o.q.Len()) // the stale entry is dropped when due
+	})
+
+	t.Run("pause and resume", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			provider, fetchCh = newCountingProvider(time.Hour)
+
+			o = New(&mock.Storage{}, WithQueryInterval(time.Millisecond*10))
+		)
+
+		require.NoError(t, o.Register(provider))
+		require.NoError(t, o.Pause(testProviderName))
+		require.NoError(t, o.Pause(testProviderName)) // no-op
+
+		status, found := o.Provider(testProviderName)
+		require.True(t, found)
+
+		assert.True(t, status.Paused)
+		assert.Nil(t, status.NextRun)
+
+		startOrchestrator(t, o)
+		assertNoFetch(t, fetchCh)
+
+		require.NoError(t, o.Resume(testProviderName))
+		require.NoError(t, o.Resume(testProviderName)) // no-op
+
+		waitFetch(t, fetchCh)
+		assertNoFetch(t, fetchCh)
+
+		status, _ = o.Provider(testProviderName)
+		assert.False(t, status.Paused)
+	})
+
+	t.Run("trigger paused provider", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			provider, fetchCh = newCountingProvider(time.Millisecond * 10)
+
+			o = New(&mock.Storage{}, WithQueryInterval(time.Millisecond*10))
+		)
+
+		require.NoError(t, o.Register(provider))
+		require.NoError(t, o.Pause(testProviderName))
+
+		startOrchestrator(t, o)
+
+		require.NoError(t, o.Trigger(testProviderName))
+
+		// Fetched once, and not rescheduled
+		waitFetch(t, fetchCh)
+		assertNoFetch(t, fetchCh)
+
+		status, _ := o.Provider(testProviderName)
+
+		assert.True(t, status.Paused)
+		assert.Nil(t, status.NextRun)
+	})
+
+	t.Run("deregister", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			fetchCount atomic.Int32
+
+			provider = &mockProvider{
+				nameFn: func() string {
+					return testProviderName
+				},
+				intervalFn: func() time.Duration {
+					return time.Hour
+				},
+				fetchFn: func(_ context.Context) ([]*types.ExchangeRate, error) {
+					fetchCount.Add(1)
+
+					return nil, nil
+				},
+			}
+
+			o = New(&mock.Storage{}, WithQueryInterval(time.Millisecond*10))
+		)
+
+		require.NoError(t, o.Register(provider))
+		require.NoError(t, o.Deregister(testProviderName))
+
+		assert.Empty(t, o.Providers())
+
+		startOrchestrator(t, o)
+		time.Sleep(time.Millisecond * 200)
+
+		assert.Zero(t, fetchCount.Load())
+
+		// The name can be registered again
+		require.NoError(t, o.Register(provider))
+		assert.Len(t, o.Providers(), 1)
+	})
+}
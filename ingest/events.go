@@ -0,0 +1,210 @@
+package ingest
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+// defaultEventBufferSize is the default number of undelivered events kept per subscriber
+const defaultEventBufferSize = 100
+
+// RateSavedEvent is emitted for every fetched rate accepted by the storage
+type RateSavedEvent struct {
+	SavedAt  time.Time
+	Rate     *types.ExchangeRate // shared between subscribers, read-only
+	Provider string              // the name of the provider that fetched the rate
+}
+
+// RatesSavedEvent is emitted once per successful provider run, with all the rates accepted by the storage.
+// Large runs (i.e. a history backfill) are a single event, so they fit the subscription buffers
+type RatesSavedEvent struct {
+	SavedAt  time.Time
+	Rates    []*types.ExchangeRate // in save order, shared between subscribers, read-only
+	Provider string                // the name of the provider that fetched the rates
+}
+
+// ProviderErrorEvent is emitted for every failed provider run (fetch, validation or save)
+type ProviderErrorEvent struct {
+	At       time.Time
+	NextRun  time.Time // the next scheduled fetch
+	Err      error
+	Provider string
+	Failures int  // consecutive failures, including this one
+	Retrying bool // whether the next fetch is a retry, as opposed to a regular run
+}
+
+// ProviderRecoveredEvent is emitted when a provider run succeeds after failed ones
+type ProviderRecoveredEvent struct {
+	At       time.Time
+	Provider string
+	Failures int // consecutive failures before the recovery
+}
+
+// ProviderStatusChangedEvent is emitted whenever the run status of a provider changes:
+// on registration, at the start and end of every run, and on manual control
+type ProviderStatusChangedEvent struct {
+	At     time.Time
+	Status *ProviderStatus // snapshot of the provider's status after the change
+}
+
+// EventSubscriber provides subscriptions to orchestrator events.
+// Handlers run on a dedicated routine per subscription, and receive events
+// in order. Events that don't fit the subscription's buffer (slow handler) are dropped.
+// The returned function unsubscribes the handler
+type EventSubscriber interface {
+	// OnRateSaved subscribes to newly saved rates
+	OnRateSaved(handler func(*RateSavedEvent)) (unsubscribe func())
+
+	// OnRatesSaved subscribes to the rates saved by each provider run
+	OnRatesSaved(handler func(*RatesSavedEvent)) (unsubscribe func())
+
+	// OnProviderError subscribes to failed provider runs
+	OnProviderError(handler func(*ProviderErrorEvent)) (unsubscribe func())
+
+	// OnProviderRecovered subscribes to provider recoveries from failed runs
+	OnProviderRecovered(handler func(*ProviderRecoveredEvent)) (unsubscribe func())
+
+	// OnProviderStatusChanged subscribes to provider status changes
+	OnProviderStatusChanged(handler func(*ProviderStatusChangedEvent)) (unsubscribe func())
+}
+
+// OnRateSaved subscribes the handler to newly saved rates
+func (o *Orchestrator) OnRateSaved(handler func(*RateSavedEvent)) func() {
+	return o.rateSaved.subscribe(handler)
+}
+
+// OnRatesSaved subscribes the handler to the rates saved by each provider run
+func (o *Orchestrator) OnRatesSaved(handler func(*RatesSavedEvent)) func() {
+	return o.ratesSaved.subscribe(handler)
+}
+
+// OnProviderError subscribes the handler to failed provider runs
+func (o *Orchestrator) OnProviderError(handler func(*ProviderErrorEvent)) func() {
+	return o.providerError.subscribe(handler)
+}
+
+// OnProviderRecovered subscribes the handler to provider recoveries from failed runs
+func (o *Orchestrator) OnProviderRecovered(handler func(*ProviderRecoveredEvent)) func() {
+	return o.providerRecovered.subscribe(handler)
+}
+
+// OnProviderStatusChanged subscribes the handler to provider status changes
+func (o *Orchestrator) OnProviderStatusChanged(handler func(*ProviderStatusChangedEvent)) func() {
+	return o.providerStatus.subscribe(handler)
+}
+
+// publishStatus publishes the provider's current status
+func (o *Orchestrator) publishStatus(at time.Time, rp *registeredProvider) {
+	o.providerStatus.publish(&ProviderStatusChangedEvent{
+		At:     at,
+		Status: rp.snapshot(),
+	})
+}
+
+// eventSubscription is a single event handler subscription
+type eventSubscription[E any] struct {
+	handler func(E)
+	events  chan E
+	done    chan struct{}
+}
+
+// eventBus fans out events of a single type to subscribed handlers,
+// through bounded per-subscription buffers
+type eventBus[E any] struct {
+	logger *slog.Logger
+	subs   map[uint64]*eventSubscription[E]
+	name   string // the event name, for logging
+
+	bufferSize int
+	nextID     uint64
+	mux        sync.RWMutex
+}
+
+// newEventBus creates a new event bus, with the given per-subscription buffer size
+func newEventBus[E any](name string, bufferSize int, logger *slog.Logger) *eventBus[E] {
+	return &eventBus[E]{
+		logger:     logger,
+		subs:       make(map[uint64]*eventSubscription[E]),
+		name:       name,
+		bufferSize: max(bufferSize, 1),
+	}
+}
+
+// subscribe starts delivering events to the handler, until the returned function is called
+func (b *eventBus[E]) subscribe(handler func(E)) func() {
+	sub := &eventSubscription[E]{
+		handler: handler,
+		events:  make(chan E, b.bufferSize),
+		done:    make(chan struct{}),
+	}
+
+	b.mux.Lock()
+	id := b.nextID
+	b.nextID++
+	b.subs[id] = sub
+	b.mux.Unlock()
+
+	go b.deliver(sub)
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			b.mux.Lock()
+			delete(b.subs, id)
+			b.mux.Unlock()
+
+			close(sub.done)
+		})
+	}
+}
+
+// deliver runs the subscription's handler on its buffered events
+func (b *eventBus[E]) deliver(sub *eventSubscription[E]) {
+	for {
+		select {
+		case <-sub.done:
+			return
+		case event := <-sub.events:
+			b.handle(sub, event)
+		}
+	}
+}
+
+// handle runs the subscription's handler on a single event.
+// A handler panic is logged, and doesn't stop the delivery of further events
+func (b *eventBus[E]) handle(sub *eventSubscription[E], event E) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.logger.Error(
+				"event handler panicked",
+				"event", b.name,
+				"panic", fmt.Sprint(r),
+			)
+		}
+	}()
+
+	sub.handler(event)
+}
+
+// publish hands the event to all subscriptions, without blocking.
+// Subscriptions with a full buffer drop the event
+func (b *eventBus[E]) publish(event E) {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+
+	for _, sub := range b.subs {
+		select {
+		case sub.events <- event:
+		default:
+			b.logger.Warn(
+				"event subscriber too slow, event dropped",
+				"event", b.name,
+			)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/storage/mock"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+// receive waits for the next event on the channel
+func receive[E any](t *testing.T, ch <-chan E) E {
+	t.Helper()
+
+	select {
+	case event := <-ch:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("event not delivered in time")
+	}
+
+	var zero E
+
+	return zero
+}
+
+func TestEventBus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fan-out in order", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			bus = newEventBus[int]("test", 10, New(&mock.Storage{}).logger)
+
+			firstCh  = make(chan int, 10)
+			secondCh = make(chan int, 10)
+		)
+
+		defer bus.subscribe(func(e int) { firstCh <- e })()
+		defer bus.subscribe(func(e int) { secondCh <- e })()
+
+		for i := range 3 {
+			bus.publish(i)
+		}
+
+		for i := range 3 {
+			assert.Equal(t, i, receive(t, firstCh))
+			assert.Equal(t, i, receive(t, secondCh))
+		}
+	})
+
+	t.Run("slow subscriber drops events", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			bus = newEventBus[int]("test", 1, New(&mock.Storage{}).logger)
+
+			blockCh    = make(chan struct{})
+			receivedCh = make(chan int, 10)
+			started    = make(chan struct{})
+		)
+
+		var once atomic.Bool
+
+		defer bus.subscribe(func(e int) {
+			if once.CompareAndSwap(false, true) {
+				close(started)
+				<-blockCh
+			}
+
+			receivedCh <- e
+		})()
+
+		bus.publish(0)
+		<-started // the handler is blocked on the first event
+
+		bus.publish(1) // buffered
+		bus.publish(2) // dropped, doesn't block
+
+		close(blockCh)
+
+		assert.Equal(t, 0, receive(t, receivedCh))
+		assert.Equal(t, 1, receive(t, receivedCh))
+
+		select {
+		case e := <-receivedCh:
+			t.Fatalf("unexpected event %d", e)
+		case <-time.After(time.Millisecond * 50):
+		}
+	})
+
+	t.Run("unsubscribed", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			bus        = newEventBus[int]("test", 10, New(&mock.Storage{}).logger)
+			receivedCh = make(chan int, 10)
+		)
+
+		unsubscribe := bus.subscribe(func(e int) { receivedCh <- e })
+
+		unsubscribe()
+		unsubscribe() // no-op
+
+		bus.publish(1)
+
+		select {
+		case e := <-receivedCh:
+			t.Fatalf("unexpected event %d", e)
+		case <-time.After(time.Millisecond * 50):
+		}
+	})
+
+	t.Run("handler panic", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			bus        = newEventBus[int]("test", 10, New(&mock.Storage{}).logger)
+			receivedCh = make(chan int, 10)
+		)
+
+		defer bus.subscribe(func(e int) {
+			if e == 0 {
+				panic("handler error")
+			}
+
+			receivedCh <- e
+		})()
+
+		bus.publish(0)
+		bus.publish(1)
+
+		assert.Equal(t, 1, receive(t, receivedCh))
+	})
+}
+
+func TestOrchestrator_Events(t *testing.T) {
+	t.Parallel()
+
+	var (
+		calls    atomic.Int32
+		fetchErr = errors.New("upstream error")
+		rate     = newValidRate("341.7412")
+
+		provider = newFetchProvider(func(context.Context) ([]*types.ExchangeRate, error) {
+			if calls.Add(1) == 1 {
+				return nil, fetchErr
+			}
+
+			return []*types.ExchangeRate{rate}, nil
+		})
+
+		o = New(
+			&mock.Storage{},
+			WithQueryInterval(time.Millisecond*10),
+			WithDefaultRetryPolicy(RetryPolicy{InitialDelay: time.Millisecond}),
+		)
+
+		errorCh     = make(chan *ProviderErrorEvent, 10)
+		recoveredCh = make(chan *ProviderRecoveredEvent, 10)
+		savedCh     = make(chan *RateSavedEvent, 10)
+		batchCh     = make(chan *RatesSavedEvent, 10)
+		errCh       = make(chan error, 1)
+	)
+
+	defer o.OnProviderError(func(e *ProviderErrorEvent) { errorCh <- e })()
+	defer o.OnProviderRecovered(func(e *ProviderRecoveredEvent) { recoveredCh <- e })()
+	defer o.OnRateSaved(func(e *RateSavedEvent) { savedCh <- e })()
+	defer o.OnRatesSaved(func(e *RatesSavedEvent) { batchCh <- e })()
+
+	require.NoError(t, o.Register(provider))
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	go func() {
+		errCh <- o.Start(ctx)
+	}()
+
+	errorEvent := receive(t, errorCh)
+
+	assert.Equal(t, testProviderName, errorEvent.Provider)
+	assert.ErrorIs(t, errorEvent.Err, fetchErr)
+	assert.Equal(t, 1, errorEvent.Failures)
+	assert.True(t, errorEvent.Retrying)
+
+	recoveredEvent := receive(t, recoveredCh)
+
+	assert.Equal(t, testProviderName, recoveredEvent.Provider)
+	assert.Equal(t, 1, recoveredEvent.Failures)
+
+	savedEvent := receive(t, savedCh)
+
+	assert.Equal(t, testProviderName, savedEvent.Provider)
+	assert.Equal(t, rate, savedEvent.Rate)
+	assert.False(t, savedEvent.SavedAt.IsZero())
+
+	// The run's rates are also published as a single batch
+	batchEvent := receive(t, batchCh)
+
+	assert.Equal(t, testProviderName, batchEvent.Provider)
+	assert.Equal(t, []*types.ExchangeRate{rate}, batchEvent.Rates)
+	assert.Equal(t, savedEvent.SavedAt, batchEvent.SavedAt)
+
+	cancelFn()
+
+	select {
+	case err := <-errCh:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("orchestrator did not shut down in time")
+	}
+}
+
+func TestOrchestrator_StatusEvents(t *testing.T) {
+	t.Parallel()
+
+	var (
+		provider, fetchCh = newCountingProvider(time.Hour)
+
+		o = New(&mock.Storage{}, WithQueryInterval(time.Millisecond*10))
+
+		statusCh = make(chan *ProviderStatusChangedEvent, 10)
+	)
+
+	defer o.OnProviderStatusChanged(func(e *ProviderStatusChangedEvent) { statusCh <- e })()
+
+	require.NoError(t, o.Register(provider))
+
+	registered := receive(t, statusCh).Status
+
+	assert.Equal(t, testProviderName, registered.Name)
+	assert.NotNil(t, registered.NextRun)
+
+	startOrchestrator(t, o)
+	waitFetch(t, fetchCh)
+
+	// The run start and end are published, even if no rates are saved
+	started := receive(t, statusCh).Status
+
+	assert.NotNil(t, started.LastAttempt)
+	assert.Nil(t, started.NextRun)
+
+	finished := receive(t, statusCh).Status
+
+	assert.NotNil(t, finished.LastSuccess)
+	assert.NotNil(t, finished.NextRun)
+	assert.Zero(t, finished.RatesSaved)
+
+	// Manual control is published
+	require.NoError(t, o.Pause(testProviderName))
+
+	paused := receive(t, statusCh).Status
+
+	assert.True(t, paused.Paused)
+	assert.Nil(t, paused.NextRun)
+
+	require.NoError(t, o.Pause(testProviderName)) // no-op, not published
+
+	require.NoError(t, o.Resume(testProviderName))
+
+	resumed := receive(t, statusCh).Status
+
+	assert.False(t, resumed.Paused)
+	assert.NotNil(t, resumed.NextRun)
+
+	waitFetch(t, fetchCh)
+}
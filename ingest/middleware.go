@@ -0,0 +1,252 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+var errFetchTimeout = errors.New("fetch timed out")
+
+// FetchFunc is a single provider fetch
+type FetchFunc func(context.Context) ([]*types.ExchangeRate, error)
+
+// ProviderMiddleware wraps a provider, adding behavior around its fetches
+// (timeouts, caching, logging...). Middlewares are applied at registration
+type ProviderMiddleware func(Provider) Provider
+
+// Chain wraps the provider with the given middlewares.
+// The first middleware is the outermost one, and sees the fetch first
+func Chain(p Provider, mws ...ProviderMiddleware) Provider {
+	for i := len(mws) - 1; i >= 0; i-- {
+		p = mws[i](p)
+	}
+
+	return p
+}
+
+// wrappedProvider is a provider with a decorated fetch.
+// The name and interval are the wrapped provider's
+type wrappedProvider struct {
+	Provider
+
+	fetch FetchFunc
+}
+
+// WrapFetch returns a provider that fetches using the given fetch,
+// instead of the provider's own one. Used for building custom middlewares
+func WrapFetch(p Provider, fetch FetchFunc) Provider {
+	return &wrappedProvider{
+		Provider: p,
+		fetch:    fetch,
+	}
+}
+
+func (w *wrappedProvider) Fetch(ctx context.Context) ([]*types.ExchangeRate, error) {
+	return w.fetch(ctx)
+}
+
+// Unwrap returns the wrapped provider
+func (w *wrappedProvider) Unwrap() Provider {
+	return w.Provider
+}
+
+// unwrapProvider returns the innermost provider, stripped of any middlewares.
+// Optional provider capabilities are checked on it
+func unwrapProvider(p Provider) Provider {
+	for {
+		w, ok := p.(interface{ Unwrap() Provider })
+		if !ok {
+			return p
+		}
+
+		p = w.Unwrap()
+	}
+}
+
+// PanicError is the fetch error of a provider that panicked
+type PanicError struct {
+	Value any    // the recovered value
+	Stack []byte // the stack trace at the moment of the panic
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("provider panicked: %v", e.Value)
+}
+
+// Recover converts provider panics into fetch errors (PanicError).
+// The orchestrator always applies it, as the outermost middleware
+func Recover() ProviderMiddleware {
+	return func(p Provider) Provider {
+		return WrapFetch(p, func(ctx context.Context) (rates []*types.ExchangeRate, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					rates, err = nil, &PanicError{
+						Value: r,
+						Stack: debug.Stack(),
+					}
+				}
+			}()
+
+			return p.Fetch(ctx)
+		})
+	}
+}
+
+// Timeout limits the duration of a single fetch.
+// The provider needs to honor the fetch context
+func Timeout(d time.Duration) ProviderMiddleware {
+	return func(p Provider) Provider {
+		return WrapFetch(p, func(ctx context.Context) ([]*types.ExchangeRate, error) {
+			fetchCtx, cancelFn := context.WithTimeout(ctx, d)
+			defer cancelFn()
+
+			rates, err := p.Fetch(fetchCtx)
+			if err != nil && ctx.Err() == nil && errors.Is(fetchCtx.Err(), context.DeadlineExceeded) {
+				return nil, fmt.Errorf("%w after %s: %w", errFetchTimeout, d, err)
+			}
+
+			return rates, err
+		})
+	}
+}
+
+// Cache reuses the latest successfully fetched rates for the given duration,
+// instead of hitting the provider (i.e. on manual triggers)
+func Cache(ttl time.Duration) ProviderMiddleware {
+	return func(p Provider) Provider {
+		var (
+			cached    []*types.ExchangeRate
+			fetchedAt time.Time
+			mux       sync.Mutex
+		)
+
+		return WrapFetch(p, func(ctx context.Context) ([]*types.ExchangeRate, error) {
+			mux.Lock()
+			if !fetchedAt.IsZero() && time.Since(fetchedAt) < ttl {
+				rates := slices.Clone(cached)
+				mux.Unlock()
+
+				return rates, nil
+			}
+			mux.Unlock()
+
+			rates, err := p.Fetch(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			mux.Lock()
+			cached, fetchedAt = slices.Clone(rates), time.Now()
+			mux.Unlock()
+
+			return rates, nil
+		})
+	}
+}
+
+// Logging logs every fetch, along with its duration and outcome
+func Logging(logger *slog.Logger) ProviderMiddleware {
+	return func(p Provider) Provider {
+		return WrapFetch(p, func(ctx context.Context) ([]*types.ExchangeRate, error) {
+			start := time.Now()
+
+			rates, err := p.Fetch(ctx)
+			if err != nil {
+				logger.Warn(
+					"provider fetch failed",
+					"name", p.Name(),
+					"duration", time.Since(start).String(),
+					"err", err,
+				)
+
+				return rates, err
+			}
+
+			logger.Info(
+				"provider fetch completed",
+				"name", p.Name(),
+				"duration", time.Since(start).String(),
+				"rates", len(rates),
+			)
+
+			return rates, nil
+		})
+	}
+}
+
+// RateLimit spaces out the provider's fetches, so consecutive fetches
+// start at least the given interval apart. Early fetches wait their turn
+func RateLimit(minInterval time.Duration) ProviderMiddleware {
+	return func(p Provider) Provider {
+		var (
+			next time.Time // the earliest start of the next fetch
+			mux  sync.Mutex
+		)
+
+		return WrapFetch(p, func(ctx context.Context) ([]*types.ExchangeRate, error) {
+			// Reserve the fetch slot
+			mux.Lock()
+			now := time.Now()
+
+			start := next
+			if start.Before(now) {
+				start = now
+			}
+
+			next = start.Add(minInterval)
+			mux.Unlock()
+
+			if wait := start.Sub(now); wait > 0 {
+				timer := time.NewTimer(wait)
+				defer timer.Stop()
+
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-timer.C:
+				}
+			}
+
+			return p.Fetch(ctx)
+		})
+	}
+}
+
+// Backoff retries failed fetches in place, waiting between attempts as per the policy.
+// A policy without MaxAttempts retries until the fetch context is done,
+// so it should be paired with an outer Timeout
+func Backoff(policy RetryPolicy) ProviderMiddleware {
+	return func(p Provider) Provider {
+		return WrapFetch(p, func(ctx context.Context) ([]*types.ExchangeRate, error) {
+			for failures := 1; ; failures++ {
+				rates, err := p.Fetch(ctx)
+				if err == nil {
+					return rates, nil
+				}
+
+				delay, ok := policy.Delay(failures)
+				if !ok || ctx.Err() != nil {
+					return nil, err
+				}
+
+				timer := time.NewTimer(delay)
+
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+
+					return nil, err
+				case <-timer.C:
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,434 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/provider/currencies"
+	"github.com/sig-0/fxrates/storage/mock"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+// newFetchProvider creates a new hourly test provider with the given fetch
+func newFetchProvider(fetchFn fetchDelegate) *mockProvider {
+	return &mockProvider{
+		nameFn: func() string {
+			return testProviderName
+		},
+		intervalFn: func() time.Duration {
+			return time.Hour
+		},
+		fetchFn: fetchFn,
+	}
+}
+
+// countingProvider creates a new test provider that counts its fetches
+func countingProvider(calls *atomic.Int32, err error) *mockProvider {
+	return newFetchProvider(func(context.Context) ([]*types.ExchangeRate, error) {
+		calls.Add(1)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return []*types.ExchangeRate{newValidRate("341.7412")}, nil
+	})
+}
+
+func TestChain(t *testing.T) {
+	t.Parallel()
+
+	var (
+		order []string
+
+		tag = func(name string) ProviderMiddleware {
+			return func(p Provider) Provider {
+				return WrapFetch(p, func(ctx context.Context) ([]*types.ExchangeRate, error) {
+					order = append(order, name)
+
+					return p.Fetch(ctx)
+				})
+			}
+		}
+
+		p = Chain(
+			newFetchProvider(func(context.Context) ([]*types.ExchangeRate, error) {
+				order = append(order, "provider")
+
+				return nil, nil
+			}),
+			tag("outer"),
+			tag("inner"),
+		)
+	)
+
+	_, err := p.Fetch(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"outer", "inner", "provider"}, order)
+	assert.Equal(t, testProviderName, p.Name())
+	assert.Equal(t, time.Hour, p.Interval())
+}
+
+func TestRecover(t *testing.T) {
+	t.Parallel()
+
+	p := Chain(
+		newFetchProvider(func(context.Context) ([]*types.ExchangeRate, error) {
+			panic("unexpected markup")
+		}),
+		Recover(),
+	)
+
+	rates, err := p.Fetch(context.Background())
+	assert.Nil(t, rates)
+
+	var panicErr *PanicError
+	require.ErrorAs(t, err, &panicErr)
+
+	assert.Equal(t, "unexpected markup", panicErr.Value)
+	assert.NotEmpty(t, panicErr.Stack)
+}
+
+func TestTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fetch timed out", func(t *testing.T) {
+		t.Parallel()
+
+		p := Chain(
+			newFetchProvider(func(ctx context.Context) ([]*types.ExchangeRate, error) {
+				<-ctx.Done()
+
+				return nil, ctx.Err()
+			}),
+			Timeout(time.Millisecond*10),
+		)
+
+		_, err := p.Fetch(context.Background())
+
+		assert.ErrorIs(t, err, errFetchTimeout)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("fetch in time", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+
+		rates, err := Chain(countingProvider(&calls, nil), Timeout(time.Minute)).Fetch(context.Background())
+		require.NoError(t, err)
+
+		assert.Len(t, rates, 1)
+	})
+}
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rates reused", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			calls atomic.Int32
+			p     = Chain(countingProvider(&calls, nil), Cache(time.Hour))
+		)
+
+		first, err := p.Fetch(context.Background())
+		require.NoError(t, err)
+
+		second, err := p.Fetch(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, first, second)
+		assert.EqualValues(t, 1, calls.Load())
+	})
+
+	t.Run("cache expired", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			calls atomic.Int32
+			p     = Chain(countingProvider(&calls, nil), Cache(time.Millisecond))
+		)
+
+		_, err := p.Fetch(context.Background())
+		require.NoError(t, err)
+
+		time.Sleep(time.Millisecond * 5)
+
+		_, err = p.Fetch(context.Background())
+		require.NoError(t, err)
+
+		assert.EqualValues(t, 2, calls.Load())
+	})
+
+	t.Run("errors not cached", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			calls atomic.Int32
+			p     = Chain(countingProvider(&calls, errors.New("upstream error")), Cache(time.Hour))
+		)
+
+		_, err := p.Fetch(context.Background())
+		require.Error(t, err)
+
+		_, err = p.Fetch(context.Background())
+		require.Error(t, err)
+
+		assert.EqualValues(t, 2, calls.Load())
+	})
+}
+
+func TestLogging(t *testing.T) {
+	t.Parallel()
+
+	var (
+		buf    bytes.Buffer
+		logger = slog.New(slog.NewTextHandler(&buf, nil))
+		calls  atomic.Int32
+	)
+
+	_, err := Chain(countingProvider(&calls, nil), Logging(logger)).Fetch(context.Background())
+	require.NoError(t, err)
+
+	_, err = Chain(countingProvider(&calls, errors.New("upstream error")), Logging(logger)).
+		Fetch(context.Background())
+	require.Error(t, err)
+
+	output := buf.String()
+
+	assert.Contains(t, output, "provider fetch completed")
+	assert.Contains(t, output, "rates=1")
+	assert.Contains(t, output, "provider fetch failed")
+	assert.Contains(t, output, "upstream error")
+	assert.Contains(t, output, "duration=")
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fetches spaced out", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			calls       atomic.Int32
+			minInterval = time.Millisecond * 50
+			p           = Chain(countingProvider(&calls, nil), RateLimit(minInterval))
+		)
+
+		start := time.Now()
+
+		for range 3 {
+			_, err := p.Fetch(context.Background())
+			require.NoError(t, err)
+		}
+
+		assert.GreaterOrEqual(t, time.Since(start), 2*minInterval)
+		assert.EqualValues(t, 3, calls.Load())
+	})
+
+	t.Run("wait canceled", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			calls atomic.Int32
+			p     = Chain(countingProvider(&calls, nil), RateLimit(time.Hour))
+		)
+
+		_, err := p.Fetch(context.Background())
+		require.NoError(t, err)
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), time.Millisecond*10)
+		defer cancelFn()
+
+		_, err = p.Fetch(ctx)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.EqualValues(t, 1, calls.Load())
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{
+		InitialDelay: time.Millisecond,
+		Multiplier:   2,
+		MaxAttempts:  2,
+	}
+
+	t.Run("transient failure", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+
+		p := Chain(
+			newFetchProvider(func(context.Context) ([]*types.ExchangeRate, error) {
+				if calls.Add(1) < 3 {
+					return nil, errors.New("too many requests")
+				}
+
+				return []*types.ExchangeRate{newValidRate("341.7412")}, nil
+			}),
+			Backoff(policy),
+		)
+
+		rates, err := p.Fetch(context.Background())
+		require.NoError(t, err)
+
+		assert.Len(t, rates, 1)
+		assert.EqualValues(t, 3, calls.Load())
+	})
+
+	t.Run("attempts exhausted", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			calls    atomic.Int32
+			fetchErr = errors.New("upstream error")
+			p        = Chain(countingProvider(&calls, fetchErr), Backoff(policy))
+		)
+
+		_, err := p.Fetch(context.Background())
+
+		assert.ErrorIs(t, err, fetchErr)
+		assert.EqualValues(t, 3, calls.Load()) // initial fetch + 2 retries
+	})
+
+	t.Run("bounded by the context", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			calls    atomic.Int32
+			fetchErr = errors.New("upstream error")
+			p        = Chain(
+				countingProvider(&calls, fetchErr),
+				Timeout(time.Millisecond*20),
+				Backoff(RetryPolicy{InitialDelay: time.Millisecond}),
+			)
+		)
+
+		_, err := p.Fetch(context.Background())
+
+		assert.ErrorIs(t, err, fetchErr)
+	})
+}
+
+func TestOrchestrator_Middleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("capabilities of wrapped providers", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			o      = New(&mock.Storage{})
+			policy = RetryPolicy{InitialDelay: time.Minute, MaxAttempts: 1}
+
+			provider = &mockRetryProvider{
+				mockProvider: *newFetchProvider(nil),
+				retryPolicyFn: func() RetryPolicy {
+					return policy
+				},
+			}
+		)
+
+		require.NoError(t, o.Register(Chain(provider, Timeout(time.Minute))))
+
+		assert.Equal(t, policy, registeredProviderOf(t, o).retryPolicy)
+	})
+
+	t.Run("middlewares applied", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			order []string
+
+			tag = func(name string) ProviderMiddleware {
+				return func(p Provider) Provider {
+					return WrapFetch(p, func(ctx context.Context) ([]*types.ExchangeRate, error) {
+						order = append(order, name)
+
+						return p.Fetch(ctx)
+					})
+				}
+			}
+
+			o = New(&mock.Storage{}, WithDefaultMiddleware(tag("default")))
+		)
+
+		require.NoError(t, o.Register(newFetchProvider(nil), WithMiddleware(tag("registration"))))
+
+		_, err := registeredProviderOf(t, o).provider.Fetch(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"default", "registration"}, order)
+	})
+
+	t.Run("provider panic recovered", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			failed = make(chan struct{})
+			calls  atomic.Int32
+
+			provider = newFetchProvider(func(context.Context) ([]*types.ExchangeRate, error) {
+				if calls.Add(1) == 1 {
+					panic("nil map write")
+				}
+
+				close(failed)
+
+				return []*types.ExchangeRate{
+					{
+						Base:     currencies.USD,
+						Target:   currencies.VES,
+						Rate:     100,
+						RateType: types.RateTypeMID,
+						Source:   "test",
+					},
+				}, nil
+			})
+
+			o = New(
+				&mock.Storage{},
+				WithQueryInterval(time.Millisecond*10),
+				WithDefaultRetryPolicy(RetryPolicy{InitialDelay: time.Millisecond}),
+			)
+			errCh = make(chan error, 1)
+		)
+
+		require.NoError(t, o.Register(provider))
+
+		ctx, cancelFn := context.WithCancel(context.Background())
+
+		go func() {
+			errCh <- o.Start(ctx)
+		}()
+
+		// The panicked fetch is retried, as any failed fetch
+		select {
+		case <-failed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("provider fetch not retried in time")
+		}
+
+		cancelFn()
+
+		select {
+		case err := <-errCh:
+			assert.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("orchestrator did not shut down in time")
+		}
+	})
+}
@@ -42,3 +42,35 @@ func (m *mockProvider) Fetch(ctx context.Context) ([]*types.ExchangeRate, error)
 
 	return nil, nil
 }
+
+type retryPolicyDelegate func() RetryPolicy
+
+type mockRetryProvider struct {
+	mockProvider
+
+	retryPolicyFn retryPolicyDelegate
+}
+
+func (m *mockRetryProvider) RetryPolicy() RetryPolicy {
+	if m.retryPolicyFn != nil {
+		return m.retryPolicyFn()
+	}
+
+	return RetryPolicy{}
+}
+
+type nextRunDelegate func(time.Time) time.Time
+
+type mockSchedulerProvider struct {
+	mockProvider
+
+	nextRunFn nextRunDelegate
+}
+
+func (m *mockSchedulerProvider) NextRun(after time.Time) time.Time {
+	if m.nextRunFn != nil {
+		return m.nextRunFn(after)
+	}
+
+	return time.Time{}
+}
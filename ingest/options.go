@@ -23,3 +23,35 @@ func WithQueryInterval(q time.Duration) Option {
 		o.queryInterval = q
 	}
 }
+
+// WithDefaultRetryPolicy specifies the retry policy for failed fetches
+// of providers that don't specify their own. Defaults to DefaultRetryPolicy
+func WithDefaultRetryPolicy(policy RetryPolicy) Option {
+	return func(o *Orchestrator) {
+		o.retryPolicy = policy
+	}
+}
+
+// WithDefaultValidationPolicy enables the validation of fetched rates before they're saved,
+// for providers that don't specify their own policy. Rates are not validated by default
+func WithDefaultValidationPolicy(policy ValidationPolicy) Option {
+	return func(o *Orchestrator) {
+		o.validationPolicy = &policy
+	}
+}
+
+// WithDefaultMiddleware specifies the middlewares applied to every registered provider,
+// outside the provider's own registration middlewares
+func WithDefaultMiddleware(mws ...ProviderMiddleware) Option {
+	return func(o *Orchestrator) {
+		o.middlewares = append(o.middlewares, mws...)
+	}
+}
+
+// WithEventBufferSize specifies the number of undelivered events kept per event subscription,
+// after which events are dropped for the subscription. Defaults to 100
+func WithEventBufferSize(size int) Option {
+	return func(o *Orchestrator) {
+		o.eventBufferSize = size
+	}
+}
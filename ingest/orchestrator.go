@@ -3,20 +3,29 @@ package ingest
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/rs/xid"
 	"github.com/sig-0/iq"
 
+	"github.com/sig-0/fxrates/ingest/schedule"
+
 	"github.com/sig-0/fxrates/storage"
+	"github.com/sig-0/fxrates/storage/types"
 )
 
+// saveTimeout is the timeout for saving a single rate, or a batch of rates
+const saveTimeout = 10 * time.Second
+
 var (
 	errInvalidProvider = errors.New("invalid provider")
 	errInvalidInterval = errors.New("invalid interval")
+	errDuplicateName   = errors.New("provider name already registered")
 )
 
 // Orchestrator is the main job scheduler for registered providers
@@ -24,7 +33,18 @@ type Orchestrator struct {
 	storage storage.Storage
 	logger  *slog.Logger
 
-	registeredProviders sync.Map
+	registeredProviders sync.Map // xid.ID -> *registeredProvider
+	providerNames       sync.Map // name -> xid.ID
+	retryPolicy         RetryPolicy
+	validationPolicy    *ValidationPolicy // nil if fetched rates are not validated
+	middlewares         []ProviderMiddleware
+
+	rateSaved         *eventBus[*RateSavedEvent]
+	ratesSaved        *eventBus[*RatesSavedEvent]
+	providerError     *eventBus[*ProviderErrorEvent]
+	providerRecovered *eventBus[*ProviderRecoveredEvent]
+	providerStatus    *eventBus[*ProviderStatusChangedEvent]
+	eventBufferSize   int
 
 	q             iq.Queue[scheduledIngest]
 	queryInterval time.Duration
@@ -34,10 +54,12 @@ type Orchestrator struct {
 // New creates a new Orchestrator instance
 func New(storage storage.Storage, opts ...Option) *Orchestrator {
 	o := &Orchestrator{
-		logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
-		storage:       storage,
-		q:             iq.NewQueue[scheduledIngest](),
-		queryInterval: time.Second, // every second
+		logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
+		storage:         storage,
+		q:               iq.NewQueue[scheduledIngest](),
+		queryInterval:   time.Second, // every second
+		retryPolicy:     DefaultRetryPolicy(),
+		eventBufferSize: defaultEventBufferSize,
 	}
 
 	// Apply the options
@@ -45,12 +67,22 @@ func New(storage storage.Storage, opts ...Option) *Orchestrator {
 		opt(o)
 	}
 
+	o.rateSaved = newEventBus[*RateSavedEvent]("rate_saved", o.eventBufferSize, o.logger)
+	o.ratesSaved = newEventBus[*RatesSavedEvent]("rates_saved", o.eventBufferSize, o.logger)
+	o.providerError = newEventBus[*ProviderErrorEvent]("provider_error", o.eventBufferSize, o.logger)
+	o.providerRecovered = newEventBus[*ProviderRecoveredEvent]("provider_recovered", o.eventBufferSize, o.logger)
+	o.providerStatus = newEventBus[*ProviderStatusChangedEvent]("provider_status", o.eventBufferSize, o.logger)
+
 	return o
 }
 
 // Register registers a new provider with the orchestrator.
-// The provider is immediately queued up for execution
-func (o *Orchestrator) Register(p Provider) error {
+// Provider names must be unique.
+// The provider is immediately queued up for execution, and then
+// runs on its schedule (if any), or at its interval.
+// Optional provider capabilities are checked on the provider stripped of
+// any middlewares, and the registration middlewares are applied afterwards
+func (o *Orchestrator) Register(p Provider, opts ...RegisterOption) error {
 	if p == nil || p.Name() == "" {
 		return errInvalidProvider
 	}
@@ -59,9 +91,53 @@ func (o *Orchestrator) Register(p Provider) error {
 		return errInvalidInterval
 	}
 
+	rp := &registeredProvider{
+		provider:         p,
+		retryPolicy:      o.retryPolicy,
+		validationPolicy: o.validationPolicy,
+		status: ProviderStatus{
+			Name: p.Name(),
+		},
+	}
+
+	// Check if the provider has a custom retry policy
+	inner := unwrapProvider(p)
+	if rpp, ok := inner.(RetryPolicyProvider); ok {
+		rp.retryPolicy = rpp.RetryPolicy()
+	}
+
+	// Check if the provider has a custom schedule
+	if scheduler, ok := inner.(Scheduler); ok {
+		rp.schedule = schedule.Func(scheduler.NextRun)
+	}
+
+	// Check if the provider observes its saved rates
+	if observer, ok := inner.(SaveObserver); ok {
+		rp.observer = observer
+	}
+
+	// Apply the options
+	for _, opt := range opts {
+		opt(rp)
+	}
+
+	// Wrap the provider. Panics are recovered from regardless of the middlewares,
+	// so a faulty provider (or middleware) can't crash the process
+	mws := make([]ProviderMiddleware, 0, len(o.middlewares)+len(rp.middlewares)+1)
+	mws = append(mws, Recover())
+	mws = append(mws, o.middlewares...)
+	mws = append(mws, rp.middlewares...)
+
+	rp.provider = Chain(p, mws...)
+
 	// Register the provider
 	id := xid.New()
-	o.registeredProviders.Store(id, p)
+
+	if _, exists := o.providerNames.LoadOrStore(p.Name(), id); exists {
+		return errDuplicateName
+	}
+
+	o.registeredProviders.Store(id, rp)
 
 	o.logger.Info(
 		"registered new provider",
@@ -69,12 +145,17 @@ func (o *Orchestrator) Register(p Provider) error {
 	)
 
 	// Schedule the job
+	now := time.Now().UTC()
+
 	o.scheduleIngest(
-		time.Now().UTC(),
+		now,
 		id,
-		p,
+		rp,
+		0,
 	)
 
+	o.publishStatus(now, rp)
+
 	return nil
 }
 
@@ -98,6 +179,17 @@ func (o *Orchestrator) Start(ctx context.Context) error {
 					return // nothing to schedule anymore
 				}
 
+				// Drop ingests of deregistered providers,
+				// and ones invalidated by manual control
+				now := time.Now().UTC()
+
+				rp, ok := o.registeredProvider(nextSI.providerID)
+				if !ok || !rp.markAttempt(now, nextSI.generation) {
+					continue
+				}
+
+				o.publishStatus(now, rp)
+
 				o.logger.Info(
 					"scheduling ingest",
 					"name", nextSI.provider.Name(),
@@ -107,6 +199,7 @@ func (o *Orchestrator) Start(ctx context.Context) error {
 				info := &workerInfo{
 					provider:   nextSI.provider,
 					providerID: nextSI.providerID,
+					generation: nextSI.generation,
 					resCh:      collectorCh,
 				}
 
@@ -130,8 +223,7 @@ func (o *Orchestrator) Start(ctx context.Context) error {
 		case response := <-collectorCh:
 			now := time.Now().UTC()
 
-			rpRaw, ok := o.registeredProviders.Load(response.providerID)
-
+			rp, ok := o.registeredProvider(response.providerID)
 			if !ok {
 				o.logger.Error(
 					"unable to load registered provider",
@@ -141,69 +233,320 @@ func (o *Orchestrator) Start(ctx context.Context) error {
 				continue
 			}
 
-			rp, _ := rpRaw.(Provider)
+			// Validate and save the provider-fetched rates.
+			// A fetch, validation lookup or batch save error fails the whole run
+			var (
+				saved []*types.ExchangeRate
+				err   = response.error
+			)
+
+			if err == nil {
+				var valid []*types.ExchangeRate
+
+				valid, err = o.validateRates(ctx, rp, response.rates, now)
+				if err == nil {
+					saved, err = o.saveRates(ctx, valid)
+				}
+			}
+
+			if err != nil {
+				var panicErr *PanicError
+				if errors.As(err, &panicErr) {
+					o.logger.Error(
+						"provider panicked during fetch",
+						"name", rp.provider.Name(),
+						"panic", fmt.Sprint(panicErr.Value),
+						"stack", string(panicErr.Stack),
+					)
+				}
+
+				failures := rp.markFailure(err)
+
+				next, retrying := o.nextRetry(now, rp, failures)
 
-			// Save the observed exchange rate
-			if response.error != nil {
 				o.logger.Error(
-					"error encountered during rate fetch",
+					"error encountered during rate ingest",
 					"id", response.providerID.String(),
-					"err", response.error.Error(),
+					"name", rp.provider.Name(),
+					"failures", failures,
+					"retrying", retrying,
+					"next_ingest", next.String(),
+					"err", err.Error(),
 				)
 
-				// Retry ingest job soon
 				o.scheduleIngest(
-					now.Add(time.Second*10), // TODO retry exponentially?
+					next,
 					response.providerID,
 					rp,
+					response.generation,
 				)
 
+				// Publish the events once the provider status is final
+				o.publishStatus(now, rp)
+
+				o.providerError.publish(&ProviderErrorEvent{
+					At:       now,
+					NextRun:  next,
+					Err:      err,
+					Provider: rp.provider.Name(),
+					Failures: failures,
+					Retrying: retrying,
+				})
+
 				continue
 			}
 
-			// Save the provider-fetched rates
-			for _, rate := range response.rates {
-				// TODO overkill?
-				saveCtx, cancelFn := context.WithTimeout(ctx, time.Second*10)
-
-				if err := o.storage.SaveExchangeRate(saveCtx, rate); err != nil {
-					o.logger.Error(
-						"unable to save exchange rate",
-						"base", rate.Base,
-						"target", rate.Target,
-						"source", rate.Source,
-						"err", err,
-					)
-				}
+			failures := rp.markSuccess(now, len(saved))
 
-				o.logger.Info(
-					"saved exchange rate",
-					"base", rate.Base,
-					"target", rate.Target,
-					"source", rate.Source,
-					"rate", rate.Rate,
-					"rate_type", rate.RateType,
-					"effective_date", rate.AsOf.String(),
-				)
-
-				cancelFn()
+			if rp.observer != nil {
+				rp.observer.OnSaved(saved)
 			}
 
 			// Schedule a new ingest for this provider
 			o.scheduleIngest(
-				now.Add(rp.Interval()),
+				rp.nextRun(now),
 				response.providerID,
 				rp,
+				response.generation,
 			)
+
+			// Publish the events once the provider status is final
+			o.publishStatus(now, rp)
+
+			if failures > 0 {
+				o.providerRecovered.publish(&ProviderRecoveredEvent{
+					At:       now,
+					Provider: rp.provider.Name(),
+					Failures: failures,
+				})
+			}
+
+			for _, rate := range saved {
+				o.rateSaved.publish(&RateSavedEvent{
+					SavedAt:  now,
+					Rate:     rate,
+					Provider: rp.provider.Name(),
+				})
+			}
+
+			if len(saved) > 0 {
+				o.ratesSaved.publish(&RatesSavedEvent{
+					SavedAt:  now,
+					Rates:    saved,
+					Provider: rp.provider.Name(),
+				})
+			}
 		}
 	}
 }
 
-// scheduleIngest schedules a new provider ingest
+// saveRates saves the provider-fetched rates, and returns the accepted ones.
+// If the storage is a storage.BatchSaver, the rates are saved atomically,
+// and the batch error is returned. Otherwise, the rates are saved one by one,
+// and failed rates are skipped
+func (o *Orchestrator) saveRates(
+	ctx context.Context,
+	rates []*types.ExchangeRate,
+) ([]*types.ExchangeRate, error) {
+	if saver, ok := o.storage.(storage.BatchSaver); ok {
+		return o.saveBatch(ctx, saver, rates)
+	}
+
+	saved := make([]*types.ExchangeRate, 0, len(rates))
+
+	for _, rate := range rates {
+		saveCtx, cancelFn := context.WithTimeout(ctx, saveTimeout)
+		err := o.storage.SaveExchangeRate(saveCtx, rate)
+
+		cancelFn()
+
+		if err != nil {
+			o.logger.Error(
+				"unable to save exchange rate",
+				"base", rate.Base,
+				"target", rate.Target,
+				"source", rate.Source,
+				"err", err,
+			)
+
+			continue
+		}
+
+		saved = append(saved, rate)
+
+		logSavedRate(o.logger, rate)
+	}
+
+	return saved, nil
+}
+
+// saveBatch saves the rates as a single batch, and returns the ones stored as new revisions.
+// Unchanged rates are counted, and rates conflicting with an already stored revision are logged
+func (o *Orchestrator) saveBatch(
+	ctx context.Context,
+	saver storage.BatchSaver,
+	rates []*types.ExchangeRate,
+) ([]*types.ExchangeRate, error) {
+	if len(rates) == 0 {
+		return nil, nil
+	}
+
+	saveCtx, cancelFn := context.WithTimeout(ctx, saveTimeout)
+	defer cancelFn()
+
+	statuses, err := saver.SaveExchangeRates(saveCtx, rates)
+	if err != nil {
+		return nil, fmt.Errorf("unable to save exchange rates: %w", err)
+	}
+
+	var (
+		accepted  = make([]*types.ExchangeRate, 0, len(rates))
+		unchanged int
+	)
+
+	for i, rate := range rates {
+		status := types.SaveStatusSaved
+		if i < len(statuses) {
+			status = statuses[i]
+		}
+
+		switch status {
+		case types.SaveStatusSaved:
+			accepted = append(accepted, rate)
+
+			logSavedRate(o.logger, rate)
+		case types.SaveStatusUnchanged:
+			unchanged++
+		case types.SaveStatusConflict:
+			o.logger.Warn(
+				"exchange rate conflicts with a stored revision",
+				"base", rate.Base,
+				"target", rate.Target,
+				"source", rate.Source,
+				"rate", rate.Rate,
+				"rate_type", rate.RateType,
+				"fetched_at", rate.FetchedAt.String(),
+			)
+		}
+	}
+
+	if unchanged > 0 {
+		o.logger.Debug(
+			"exchange rates unchanged, not saved",
+			"count", unchanged,
+		)
+	}
+
+	return accepted, nil
+}
+
+// logSavedRate logs the saved exchange rate
+func logSavedRate(logger *slog.Logger, rate *types.ExchangeRate) {
+	logger.Info(
+		"saved exchange rate",
+		"base", rate.Base,
+		"target", rate.Target,
+		"source", rate.Source,
+		"rate", rate.Rate,
+		"rate_type", rate.RateType,
+		"effective_date", rate.AsOf.String(),
+	)
+}
+
+// Providers returns the run status of all registered providers, sorted by name
+func (o *Orchestrator) Providers() []*ProviderStatus {
+	statuses := make([]*ProviderStatus, 0)
+
+	o.registeredProviders.Range(func(_, value any) bool {
+		rp, _ := value.(*registeredProvider)
+		statuses = append(statuses, rp.snapshot())
+
+		return true
+	})
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	return statuses
+}
+
+// Provider returns the run status of the registered provider with the given name
+func (o *Orchestrator) Provider(name string) (*ProviderStatus, bool) {
+	_, rp, err := o.lookupProvider(name)
+	if err != nil {
+		return nil, false
+	}
+
+	return rp.snapshot(), true
+}
+
+// lookupProvider fetches the registered provider with the given name
+func (o *Orchestrator) lookupProvider(name string) (xid.ID, *registeredProvider, error) {
+	idRaw, ok := o.providerNames.Load(name)
+	if !ok {
+		return xid.NilID(), nil, ErrProviderNotFound
+	}
+
+	id, _ := idRaw.(xid.ID)
+
+	rp, ok := o.registeredProvider(id)
+	if !ok {
+		return xid.NilID(), nil, ErrProviderNotFound
+	}
+
+	return id, rp, nil
+}
+
+// registeredProvider fetches the registered provider with the given ID
+func (o *Orchestrator) registeredProvider(id xid.ID) (*registeredProvider, bool) {
+	rpRaw, ok := o.registeredProviders.Load(id)
+	if !ok {
+		return nil, false
+	}
+
+	rp, ok := rpRaw.(*registeredProvider)
+
+	return rp, ok
+}
+
+// nextRetry returns the next ingest time for a provider whose fetch failed,
+// and whether it's a retry (as opposed to the provider's regular run)
+func (o *Orchestrator) nextRetry(
+	now time.Time,
+	rp *registeredProvider,
+	failures int,
+) (time.Time, bool) {
+	delay, ok := rp.retryPolicy.Delay(failures)
+	if !ok {
+		// Retries are exhausted, fall back to the regular schedule
+		return rp.nextRun(now), false
+	}
+
+	return now.Add(delay), true
+}
+
+// scheduleIngest schedules a new provider ingest, if the given
+// schedule generation is still current and the provider is not paused
 func (o *Orchestrator) scheduleIngest(
 	at time.Time,
 	providerID xid.ID,
-	provider Provider,
+	rp *registeredProvider,
+	generation uint64,
+) {
+	if !rp.markScheduled(at, generation) {
+		return
+	}
+
+	o.pushIngest(at, providerID, rp, generation)
+}
+
+// pushIngest queues up a new provider ingest
+func (o *Orchestrator) pushIngest(
+	at time.Time,
+	providerID xid.ID,
+	rp *registeredProvider,
+	generation uint64,
 ) {
 	o.qMux.Lock()
 	defer o.qMux.Unlock()
@@ -211,7 +554,8 @@ func (o *Orchestrator) scheduleIngest(
 	futureSI := scheduledIngest{
 		at:         at,
 		providerID: providerID,
-		provider:   provider,
+		provider:   rp.provider,
+		generation: generation,
 	}
 
 	o.q.Push(futureSI)
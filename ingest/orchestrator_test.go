@@ -43,6 +43,38 @@ func TestOrchestrator_New(t *testing.T) {
 		require.NotNil(t, o)
 		assert.Equal(t, time.Minute, o.queryInterval)
 	})
+
+	t.Run("default retry policy", func(t *testing.T) {
+		t.Parallel()
+
+		policy := RetryPolicy{InitialDelay: time.Minute, Multiplier: 3}
+
+		assert.Equal(t, DefaultRetryPolicy(), New(&mock.Storage{}).retryPolicy)
+		assert.Equal(
+			t,
+			policy,
+			New(&mock.Storage{}, WithDefaultRetryPolicy(policy)).retryPolicy,
+		)
+	})
+}
+
+// registeredProviderOf returns the single provider registered with the orchestrator
+func registeredProviderOf(t *testing.T, o *Orchestrator) *registeredProvider {
+	t.Helper()
+
+	var rp *registeredProvider
+
+	o.registeredProviders.Range(
+		func(_, value any) bool {
+			rp, _ = value.(*registeredProvider)
+
+			return false
+		},
+	)
+
+	require.NotNil(t, rp)
+
+	return rp
 }
 
 func TestOrchestrator_Register(t *testing.T) {
@@ -168,6 +200,78 @@ func TestOrchestrator_Register(t *testing.T) {
 		scheduled := o.q.Index(0)
 		assert.True(t, scheduled.at.Before(time.Now().Add(time.Second)))
 	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			o = New(&mock.Storage{})
+
+			provider = &mockProvider{
+				nameFn: func() string {
+					return testProviderName
+				},
+				intervalFn: func() time.Duration {
+					return time.Hour
+				},
+			}
+		)
+
+		require.NoError(t, o.Register(provider))
+		assert.ErrorIs(t, o.Register(provider), errDuplicateName)
+		assert.Equal(t, 1, o.q.Len())
+	})
+
+	t.Run("retry policy precedence", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			defaultPolicy  = RetryPolicy{InitialDelay: time.Second}
+			providerPolicy = RetryPolicy{InitialDelay: time.Minute}
+			registerPolicy = RetryPolicy{InitialDelay: time.Hour}
+
+			newProvider = func() *mockRetryProvider {
+				return &mockRetryProvider{
+					mockProvider: mockProvider{
+						nameFn: func() string {
+							return testProviderName
+						},
+						intervalFn: func() time.Duration {
+							return time.Hour
+						},
+					},
+					retryPolicyFn: func() RetryPolicy {
+						return providerPolicy
+					},
+				}
+			}
+		)
+
+		// Orchestrator default
+		o := New(&mock.Storage{}, WithDefaultRetryPolicy(defaultPolicy))
+
+		require.NoError(t, o.Register(&mockProvider{
+			nameFn: func() string {
+				return testProviderName
+			},
+			intervalFn: func() time.Duration {
+				return time.Hour
+			},
+		}))
+		assert.Equal(t, defaultPolicy, registeredProviderOf(t, o).retryPolicy)
+
+		// Provider policy
+		o = New(&mock.Storage{}, WithDefaultRetryPolicy(defaultPolicy))
+
+		require.NoError(t, o.Register(newProvider()))
+		assert.Equal(t, providerPolicy, registeredProviderOf(t, o).retryPolicy)
+
+		// Registration policy
+		o = New(&mock.Storage{}, WithDefaultRetryPolicy(defaultPolicy))
+
+		require.NoError(t, o.Register(newProvider(), WithRetryPolicy(registerPolicy)))
+		assert.Equal(t, registerPolicy, registeredProviderOf(t, o).retryPolicy)
+	})
 }
 
 func TestOrchestrator_Start(t *testing.T) {
@@ -516,4 +620,505 @@ func TestOrchestrator_Start(t *testing.T) {
 		cancel()
 		require.NoError(t, <-errCh)
 	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			fetchCount atomic.Int32
+			retryDone  = make(chan struct{})
+			errCh      = make(chan error, 1)
+
+			provider = &mockProvider{
+				nameFn: func() string {
+					return testProviderName
+				},
+				intervalFn: func() time.Duration {
+					return time.Hour
+				},
+				fetchFn: func(_ context.Context) ([]*types.ExchangeRate, error) {
+					if fetchCount.Add(1) == 3 {
+						close(retryDone)
+					}
+
+					return nil, errors.New("fetch error")
+				},
+			}
+
+			o = New(&mock.Storage{}, WithQueryInterval(time.Millisecond*10))
+		)
+
+		require.NoError(t, o.Register(
+			provider,
+			WithRetryPolicy(RetryPolicy{
+				InitialDelay: time.Millisecond * 10,
+				Multiplier:   2,
+				MaxAttempts:  2,
+			}),
+		))
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		go func() {
+			errCh <- o.Start(ctx)
+		}()
+
+		select {
+		case <-retryDone:
+			// Success
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for retries")
+		}
+
+		// Make sure the provider falls back to its regular interval
+		time.Sleep(time.Millisecond * 200)
+
+		cancel()
+		require.NoError(t, <-errCh)
+
+		assert.Equal(t, int32(3), fetchCount.Load())
+		assert.Equal(t, 3, registeredProviderOf(t, o).status.ConsecutiveFailures)
+	})
+
+	t.Run("failures reset on success", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			fetchCount atomic.Int32
+			saveDone   = make(chan struct{})
+			errCh      = make(chan error, 1)
+
+			storage = &mock.Storage{
+				SaveExchangeRateFn: func(_ context.Context, _ *types.ExchangeRate) error {
+					close(saveDone)
+
+					return nil
+				},
+			}
+
+			provider = &mockProvider{
+				nameFn: func() string {
+					return testProviderName
+				},
+				intervalFn: func() time.Duration {
+					return time.Hour
+				},
+				fetchFn: func(_ context.Context) ([]*types.ExchangeRate, error) {
+					if fetchCount.Add(1) <= 2 {
+						return nil, errors.New("fetch error")
+					}
+
+					return []*types.ExchangeRate{{
+						Base:   currencies.USD,
+						Target: currencies.VES,
+						Rate:   100.0,
+					}}, nil
+				},
+			}
+
+			o = New(
+				storage,
+				WithQueryInterval(time.Millisecond*10),
+				WithDefaultRetryPolicy(RetryPolicy{
+					InitialDelay: time.Millisecond * 10,
+					Multiplier:   2,
+				}),
+			)
+		)
+
+		require.NoError(t, o.Register(provider))
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		go func() {
+			errCh <- o.Start(ctx)
+		}()
+
+		select {
+		case <-saveDone:
+			// Success
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for successful fetch")
+		}
+
+		cancel()
+		require.NoError(t, <-errCh)
+
+		assert.Equal(t, int32(3), fetchCount.Load())
+		assert.Zero(t, registeredProviderOf(t, o).status.ConsecutiveFailures)
+	})
+}
+
+func TestOrchestrator_SaveRates(t *testing.T) {
+	t.Parallel()
+
+	rates := []*types.ExchangeRate{
+		{Base: currencies.USD, Target: currencies.VES, Rate: 341.7412, Source: "BCV"},
+		{Base: currencies.EUR, Target: currencies.VES, Rate: 398.12, Source: "BCV"},
+		{Base: currencies.CNY, Target: currencies.VES, Rate: 47.1, Source: "BCV"},
+	}
+
+	t.Run("batch saver used when available", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			batch []*types.ExchangeRate
+
+			storage = &mock.BatchStorage{
+				Storage: mock.Storage{
+					SaveExchangeRateFn: func(_ context.Context, _ *types.ExchangeRate) error {
+						t.Error("single save called")
+
+						return nil
+					},
+				},
+				SaveExchangeRatesFn: func(
+					_ context.Context,
+					rates []*types.ExchangeRate,
+				) ([]types.SaveStatus, error) {
+					batch = rates
+
+					return []types.SaveStatus{
+						types.SaveStatusSaved,
+						types.SaveStatusUnchanged,
+						types.SaveStatusConflict,
+					}, nil
+				},
+			}
+		)
+
+		saved, err := New(storage).saveRates(context.Background(), rates)
+		require.NoError(t, err)
+
+		assert.Equal(t, rates, batch)
+		assert.Equal(t, rates[:1], saved) // the unchanged and conflicting rates are not accepted
+	})
+
+	t.Run("unchanged rates not published", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			batchDone = make(chan struct{})
+			errCh     = make(chan error, 1)
+			savedCh   = make(chan *RateSavedEvent, 10)
+
+			storage = &mock.BatchStorage{
+				SaveExchangeRatesFn: func(
+					_ context.Context,
+					rates []*types.ExchangeRate,
+				) ([]types.SaveStatus, error) {
+					defer close(batchDone)
+
+					statuses := make([]types.SaveStatus, len(rates))
+					for i := range statuses {
+						statuses[i] = types.SaveStatusUnchanged
+					}
+
+					return statuses, nil
+				},
+			}
+
+			provider = &mockProvider{
+				nameFn: func() string {
+					return testProviderName
+				},
+				intervalFn: func() time.Duration {
+					return time.Hour
+				},
+				fetchFn: func(_ context.Context) ([]*types.ExchangeRate, error) {
+					return rates, nil
+				},
+			}
+
+			o = New(storage, WithQueryInterval(time.Millisecond*10))
+		)
+
+		defer o.OnRateSaved(func(e *RateSavedEvent) { savedCh <- e })()
+
+		require.NoError(t, o.Register(provider))
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		go func() {
+			errCh <- o.Start(ctx)
+		}()
+
+		select {
+		case <-batchDone:
+			// Success
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for the batch save")
+		}
+
+		// The run succeeds, without saving anything
+		require.Eventually(t, func() bool {
+			status, found := o.Provider(testProviderName)
+
+			return found && status.LastSuccess != nil
+		}, 5*time.Second, 10*time.Millisecond)
+
+		cancel()
+		require.NoError(t, <-errCh)
+
+		status, _ := o.Provider(testProviderName)
+		assert.Zero(t, status.RatesSaved)
+
+		select {
+		case e := <-savedCh:
+			t.Fatalf("unchanged rate published: %v", e.Rate)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("batch error", func(t *testing.T) {
+		t.Parallel()
+
+		storage := &mock.BatchStorage{
+			SaveExchangeRatesFn: func(
+				_ context.Context,
+				_ []*types.ExchangeRate,
+			) ([]types.SaveStatus, error) {
+				return nil, errors.New("tx aborted")
+			},
+		}
+
+		saved, err := New(storage).saveRates(context.Background(), rates)
+		require.Error(t, err)
+
+		assert.Empty(t, saved)
+	})
+
+	t.Run("single saves without batch support", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			saveCount int
+
+			storage = &mock.Storage{
+				SaveExchangeRateFn: func(_ context.Context, rate *types.ExchangeRate) error {
+					saveCount++
+
+					if rate.Target == currencies.VES && rate.Base == currencies.EUR {
+						return errors.New("storage error")
+					}
+
+					return nil
+				},
+			}
+		)
+
+		saved, err := New(storage).saveRates(context.Background(), rates)
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, saveCount)
+		assert.Equal(t, []*types.ExchangeRate{rates[0], rates[2]}, saved) // failed rates are skipped
+	})
+
+	t.Run("batch error fails the run", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			batchCount atomic.Int32
+			batchDone  = make(chan struct{})
+			errCh      = make(chan error, 1)
+
+			storage = &mock.BatchStorage{
+				SaveExchangeRatesFn: func(
+					_ context.Context,
+					_ []*types.ExchangeRate,
+				) ([]types.SaveStatus, error) {
+					if batchCount.Add(1) == 2 {
+						close(batchDone)
+					}
+
+					return nil, errors.New("tx aborted")
+				},
+			}
+
+			provider = &mockProvider{
+				nameFn: func() string {
+					return testProviderName
+				},
+				intervalFn: func() time.Duration {
+					return time.Hour
+				},
+				fetchFn: func(_ context.Context) ([]*types.ExchangeRate, error) {
+					return rates, nil
+				},
+			}
+
+			o = New(
+				storage,
+				WithQueryInterval(time.Millisecond*10),
+				WithDefaultRetryPolicy(RetryPolicy{
+					InitialDelay: time.Millisecond * 10,
+					Multiplier:   2,
+				}),
+			)
+		)
+
+		require.NoError(t, o.Register(provider))
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		go func() {
+			errCh <- o.Start(ctx)
+		}()
+
+		// The run is retried, instead of waiting for the regular interval
+		select {
+		case <-batchDone:
+			// Success
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for the batch retry")
+		}
+
+		cancel()
+		require.NoError(t, <-errCh)
+
+		status, found := o.Provider(testProviderName)
+		require.True(t, found)
+
+		assert.Contains(t, status.LastError, "tx aborted")
+		assert.Nil(t, status.LastSuccess)
+	})
+}
+
+func TestOrchestrator_Providers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no providers", func(t *testing.T) {
+		t.Parallel()
+
+		o := New(&mock.Storage{})
+
+		assert.Empty(t, o.Providers())
+
+		_, found := o.Provider(testProviderName)
+		assert.False(t, found)
+	})
+
+	t.Run("registered providers", func(t *testing.T) {
+		t.Parallel()
+
+		o := New(&mock.Storage{})
+
+		for _, name := range []string{"provider-2", "provider-1"} {
+			require.NoError(t, o.Register(&mockProvider{
+				nameFn: func() string {
+					return name
+				},
+				intervalFn: func() time.Duration {
+					return time.Hour
+				},
+			}))
+		}
+
+		statuses := o.Providers()
+		require.Len(t, statuses, 2)
+
+		// Sorted by name, and scheduled right away
+		assert.Equal(t, "provider-1", statuses[0].Name)
+		assert.Equal(t, "provider-2", statuses[1].Name)
+
+		for _, status := range statuses {
+			assert.NotNil(t, status.NextRun)
+			assert.Nil(t, status.LastAttempt)
+			assert.Nil(t, status.LastSuccess)
+			assert.Zero(t, status.ConsecutiveFailures)
+		}
+	})
+
+	t.Run("run status", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			fetchCount atomic.Int32
+			saveCount  atomic.Int32
+			savesDone  = make(chan struct{})
+			errCh      = make(chan error, 1)
+
+			storage = &mock.Storage{
+				SaveExchangeRateFn: func(_ context.Context, rate *types.ExchangeRate) error {
+					if saveCount.Add(1) == 2 {
+						close(savesDone)
+					}
+
+					if rate.Target == currencies.EUR {
+						return errors.New("storage error")
+					}
+
+					return nil
+				},
+			}
+
+			provider = &mockProvider{
+				nameFn: func() string {
+					return testProviderName
+				},
+				intervalFn: func() time.Duration {
+					return time.Hour
+				},
+				fetchFn: func(_ context.Context) ([]*types.ExchangeRate, error) {
+					if fetchCount.Add(1) == 1 {
+						return nil, errors.New("fetch error")
+					}
+
+					return []*types.ExchangeRate{
+						{
+							Base:   currencies.USD,
+							Target: currencies.VES,
+							Rate:   100.0,
+						},
+						{
+							Base:   currencies.USD,
+							Target: currencies.EUR,
+							Rate:   0.9,
+						},
+					}, nil
+				},
+			}
+
+			o = New(
+				storage,
+				WithQueryInterval(time.Millisecond*10),
+				WithDefaultRetryPolicy(RetryPolicy{
+					InitialDelay: time.Millisecond * 10,
+				}),
+			)
+		)
+
+		require.NoError(t, o.Register(provider))
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		go func() {
+			errCh <- o.Start(ctx)
+		}()
+
+		select {
+		case <-savesDone:
+			// Success
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for save attempts")
+		}
+
+		cancel()
+		require.NoError(t, <-errCh)
+
+		status, found := o.Provider(testProviderName)
+		require.True(t, found)
+
+		assert.Equal(t, testProviderName, status.Name)
+		assert.Equal(t, "fetch error", status.LastError)
+		assert.Zero(t, status.ConsecutiveFailures)
+		assert.Equal(t, 1, status.RatesSaved)
+
+		require.NotNil(t, status.LastAttempt)
+		require.NotNil(t, status.LastSuccess)
+		require.NotNil(t, status.NextRun)
+
+		assert.False(t, status.LastSuccess.Before(*status.LastAttempt))
+		assert.True(t, status.NextRun.After(*status.LastSuccess))
+	})
 }
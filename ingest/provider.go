@@ -18,3 +18,18 @@ type Provider interface {
 	// Fetch is the provider's main fetch job, yielding exchange rate data points
 	Fetch(context.Context) ([]*types.ExchangeRate, error)
 }
+
+// Scheduler is an optional Provider capability for calendar-aware schedules
+// (cron expressions, business days...). If implemented, it takes precedence over Interval
+type Scheduler interface {
+	// NextRun returns the provider's next regular run strictly after the given time.
+	// A zero time falls back to the provider's Interval
+	NextRun(after time.Time) time.Time
+}
+
+// SaveObserver is an optional Provider capability for providers that depend on their rates
+// being persisted (i.e. a one-off backfill, only done once it's saved)
+type SaveObserver interface {
+	// OnSaved is called after every successful run, with the rates accepted by the storage
+	OnSaved(saved []*types.ExchangeRate)
+}
@@ -0,0 +1,130 @@
+package ingest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sig-0/fxrates/ingest/schedule"
+)
+
+// RegisterOption is a single provider registration option
+type RegisterOption func(r *registeredProvider)
+
+// WithRetryPolicy specifies the retry policy for the registered provider's failed fetches.
+// Takes precedence over the provider's own RetryPolicy and the orchestrator default
+func WithRetryPolicy(policy RetryPolicy) RegisterOption {
+	return func(r *registeredProvider) {
+		r.retryPolicy = policy
+	}
+}
+
+// WithValidationPolicy specifies the validation policy for the registered provider's fetched rates.
+// Takes precedence over the orchestrator default
+func WithValidationPolicy(policy ValidationPolicy) RegisterOption {
+	return func(r *registeredProvider) {
+		r.validationPolicy = &policy
+	}
+}
+
+// WithSchedule specifies the regular run schedule for the registered provider.
+// Takes precedence over the provider's own NextRun and Interval
+func WithSchedule(s schedule.Schedule) RegisterOption {
+	return func(r *registeredProvider) {
+		r.schedule = s
+	}
+}
+
+// WithMiddleware wraps the registered provider with the given middlewares,
+// inside the orchestrator's default ones. The first middleware is the outermost one
+func WithMiddleware(mws ...ProviderMiddleware) RegisterOption {
+	return func(r *registeredProvider) {
+		r.middlewares = append(r.middlewares, mws...)
+	}
+}
+
+// registeredProvider is a single provider registered with the orchestrator
+type registeredProvider struct {
+	provider         Provider // the provider, wrapped with its middlewares
+	middlewares      []ProviderMiddleware
+	retryPolicy      RetryPolicy
+	validationPolicy *ValidationPolicy // nil if fetched rates are not validated
+	schedule         schedule.Schedule // nil if the provider runs at a fixed interval
+	observer         SaveObserver      // nil if the provider doesn't observe saves
+
+	status       ProviderStatus
+	pendingMoves map[seriesKey]*pendingMove // unconfirmed moves beyond the max deviation
+	generation   uint64                     // bumped on manual control, invalidating queued ingests
+	mux          sync.RWMutex
+}
+
+// nextRun returns the provider's next regular run after the given time
+func (r *registeredProvider) nextRun(after time.Time) time.Time {
+	if r.schedule != nil {
+		if next := r.schedule.Next(after); !next.IsZero() {
+			return next.UTC()
+		}
+	}
+
+	return after.Add(r.provider.Interval())
+}
+
+// markScheduled records the provider's next scheduled fetch, if the
+// schedule generation is still current and the provider is not paused
+func (r *registeredProvider) markScheduled(at time.Time, generation uint64) bool {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if r.status.Paused || generation != r.generation {
+		return false
+	}
+
+	r.status.NextRun = &at
+
+	return true
+}
+
+// trigger invalidates the provider's queued ingests, and records
+// an immediate fetch. Returns the new schedule generation
+func (r *registeredProvider) trigger(at time.Time) uint64 {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.generation++
+	r.status.NextRun = &at
+
+	return r.generation
+}
+
+// pause invalidates the provider's queued ingests, and marks it as paused.
+// Returns false if the provider was already paused
+func (r *registeredProvider) pause() bool {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if r.status.Paused {
+		return false
+	}
+
+	r.generation++
+	r.status.Paused = true
+	r.status.NextRun = nil
+
+	return true
+}
+
+// resume unpauses the provider, and records an immediate fetch.
+// Returns the new schedule generation, and false if the provider was not paused
+func (r *registeredProvider) resume(at time.Time) (uint64, bool) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if !r.status.Paused {
+		return r.generation, false
+	}
+
+	r.generation++
+	r.status.Paused = false
+	r.status.NextRun = &at
+
+	return r.generation, true
+}
@@ -0,0 +1,143 @@
+package ingest
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/ingest/schedule"
+	"github.com/sig-0/fxrates/storage/mock"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+func TestRegisteredProvider_NextRun(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now = time.Date(2026, time.January, 7, 10, 30, 0, 0, time.UTC)
+
+		intervalProvider = mockProvider{
+			nameFn: func() string {
+				return testProviderName
+			},
+			intervalFn: func() time.Duration {
+				return time.Hour
+			},
+		}
+
+		newSchedulerProvider = func(next time.Time) *mockSchedulerProvider {
+			return &mockSchedulerProvider{
+				mockProvider: intervalProvider,
+				nextRunFn: func(time.Time) time.Time {
+					return next
+				},
+			}
+		}
+	)
+
+	t.Run("interval", func(t *testing.T) {
+		t.Parallel()
+
+		o := New(&mock.Storage{})
+
+		require.NoError(t, o.Register(&intervalProvider))
+
+		assert.Equal(t, now.Add(time.Hour), registeredProviderOf(t, o).nextRun(now))
+	})
+
+	t.Run("provider schedule", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			o    = New(&mock.Storage{})
+			next = now.Add(time.Minute * 5)
+		)
+
+		require.NoError(t, o.Register(newSchedulerProvider(next)))
+
+		assert.Equal(t, next, registeredProviderOf(t, o).nextRun(now))
+	})
+
+	t.Run("provider schedule without runs", func(t *testing.T) {
+		t.Parallel()
+
+		o := New(&mock.Storage{})
+
+		require.NoError(t, o.Register(newSchedulerProvider(time.Time{})))
+
+		assert.Equal(t, now.Add(time.Hour), registeredProviderOf(t, o).nextRun(now))
+	})
+
+	t.Run("registration schedule", func(t *testing.T) {
+		t.Parallel()
+
+		o := New(&mock.Storage{})
+
+		require.NoError(t, o.Register(
+			newSchedulerProvider(now.Add(time.Minute*5)),
+			WithSchedule(schedule.MustParseCron("0 12 * * *", time.UTC)),
+		))
+
+		assert.Equal(
+			t,
+			time.Date(2026, time.January, 7, 12, 0, 0, 0, time.UTC),
+			registeredProviderOf(t, o).nextRun(now),
+		)
+	})
+}
+
+func TestOrchestrator_Schedule(t *testing.T) {
+	t.Parallel()
+
+	var (
+		fetchCount atomic.Int32
+		fetchDone  = make(chan struct{})
+		errCh      = make(chan error, 1)
+
+		provider = &mockSchedulerProvider{
+			mockProvider: mockProvider{
+				nameFn: func() string {
+					return testProviderName
+				},
+				intervalFn: func() time.Duration {
+					return time.Hour
+				},
+				fetchFn: func(_ context.Context) ([]*types.ExchangeRate, error) {
+					if fetchCount.Add(1) == 2 {
+						close(fetchDone)
+					}
+
+					return nil, nil
+				},
+			},
+			nextRunFn: func(after time.Time) time.Time {
+				return after.Add(time.Millisecond * 50)
+			},
+		}
+
+		o = New(&mock.Storage{}, WithQueryInterval(time.Millisecond*10))
+	)
+
+	require.NoError(t, o.Register(provider))
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		errCh <- o.Start(ctx)
+	}()
+
+	// The provider runs on its schedule, and not on its hourly interval
+	select {
+	case <-fetchDone:
+		// Success
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for the scheduled run")
+	}
+
+	cancel()
+	require.NoError(t, <-errCh)
+}
@@ -0,0 +1,75 @@
+package ingest
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy defines how failed provider fetches are retried
+type RetryPolicy struct {
+	// InitialDelay is the delay before the first retry
+	InitialDelay time.Duration
+
+	// MaxDelay is the upper bound for a single retry delay
+	MaxDelay time.Duration
+
+	// Multiplier is the growth factor between consecutive retry delays
+	Multiplier float64
+
+	// Jitter is the randomization factor [0, 1] applied to each delay,
+	// so a 0.2 jitter yields a delay within ±20% of the computed one
+	Jitter float64
+
+	// MaxAttempts is the number of consecutive retries before the provider
+	// falls back to its regular schedule. 0 retries indefinitely
+	MaxAttempts int
+}
+
+// RetryPolicyProvider is an optional Provider capability
+// for specifying a custom retry policy
+type RetryPolicyProvider interface {
+	// RetryPolicy returns the retry policy for the provider's failed fetches
+	RetryPolicy() RetryPolicy
+}
+
+// DefaultRetryPolicy returns the default retry policy:
+// 10s, 20s, 40s... capped at 30min, with a ±20% jitter
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		InitialDelay: time.Second * 10,
+		MaxDelay:     time.Minute * 30,
+		Multiplier:   2,
+		Jitter:       0.2,
+		MaxAttempts:  0,
+	}
+}
+
+// Delay returns the delay before the next retry, given the number of
+// consecutive failures so far (>= 1). If the retries are exhausted, false is returned
+func (p RetryPolicy) Delay(failures int) (time.Duration, bool) {
+	if failures < 1 {
+		failures = 1
+	}
+
+	if p.MaxAttempts > 0 && failures > p.MaxAttempts {
+		return 0, false
+	}
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(p.InitialDelay) * math.Pow(multiplier, float64(failures-1))
+
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+
+	if jitter := math.Min(math.Max(p.Jitter, 0), 1); jitter > 0 {
+		delay *= 1 + jitter*(2*rand.Float64()-1) //nolint:gosec // no need for crypto randomness
+	}
+
+	return time.Duration(delay), true
+}
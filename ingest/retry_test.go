@@ -0,0 +1,99 @@
+package ingest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{
+		InitialDelay: time.Second,
+		MaxDelay:     time.Second * 10,
+		Multiplier:   2,
+	}
+
+	testTable := []struct {
+		name     string
+		policy   RetryPolicy
+		failures int
+		expected time.Duration
+		retrying bool
+	}{
+		{"first failure", policy, 1, time.Second, true},
+		{"exponential growth", policy, 3, time.Second * 4, true},
+		{"capped at max delay", policy, 10, time.Second * 10, true},
+		{"non-positive failures", policy, 0, time.Second, true},
+		{
+			"constant delay",
+			RetryPolicy{InitialDelay: time.Second, Multiplier: 1},
+			5,
+			time.Second,
+			true,
+		},
+		{
+			"invalid multiplier",
+			RetryPolicy{InitialDelay: time.Second, Multiplier: 0.5},
+			3,
+			time.Second,
+			true,
+		},
+		{
+			"within max attempts",
+			RetryPolicy{InitialDelay: time.Second, Multiplier: 2, MaxAttempts: 2},
+			2,
+			time.Second * 2,
+			true,
+		},
+		{
+			"max attempts exhausted",
+			RetryPolicy{InitialDelay: time.Second, Multiplier: 2, MaxAttempts: 2},
+			3,
+			0,
+			false,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			delay, retrying := testCase.policy.Delay(testCase.failures)
+
+			assert.Equal(t, testCase.retrying, retrying)
+			assert.Equal(t, testCase.expected, delay)
+		})
+	}
+}
+
+func TestRetryPolicy_Jitter(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{
+		InitialDelay: time.Second * 10,
+		Multiplier:   2,
+		Jitter:       0.5,
+	}
+
+	for range 100 {
+		delay, retrying := policy.Delay(1)
+
+		assert.True(t, retrying)
+		assert.GreaterOrEqual(t, delay, time.Second*5)
+		assert.LessOrEqual(t, delay, time.Second*15)
+	}
+}
+
+func TestDefaultRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy := DefaultRetryPolicy()
+
+	assert.Equal(t, time.Second*10, policy.InitialDelay)
+	assert.Equal(t, time.Minute*30, policy.MaxDelay)
+	assert.Equal(t, 2.0, policy.Multiplier)
+	assert.Zero(t, policy.MaxAttempts)
+}
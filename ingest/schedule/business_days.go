@@ -0,0 +1,81 @@
+package schedule
+
+import "time"
+
+// maxSkippedRuns is the number of consecutive non-business-day runs
+// after which the business-day schedule gives up
+const maxSkippedRuns = 10_000
+
+// BusinessDays is a schedule that skips the runs of the wrapped schedule
+// falling on weekends or holidays, in the given time zone
+type BusinessDays struct {
+	schedule Schedule
+	location *time.Location
+	holidays map[date]struct{}
+}
+
+// date is a calendar date
+type date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+// NewBusinessDays creates a new business-day schedule, wrapping the given schedule.
+// Days are evaluated in the given time zone (UTC if nil). Only the calendar date of holidays is considered
+func NewBusinessDays(schedule Schedule, loc *time.Location, holidays ...time.Time) *BusinessDays {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	b := &BusinessDays{
+		schedule: schedule,
+		location: loc,
+		holidays: make(map[date]struct{}, len(holidays)),
+	}
+
+	for _, holiday := range holidays {
+		b.holidays[dateOf(holiday)] = struct{}{}
+	}
+
+	return b
+}
+
+// Next returns the next business-day run of the wrapped schedule strictly after the given time
+func (b *BusinessDays) Next(after time.Time) time.Time {
+	t := after
+
+	for range maxSkippedRuns {
+		t = b.schedule.Next(t)
+		if t.IsZero() {
+			return t
+		}
+
+		if b.IsBusinessDay(t) {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// IsBusinessDay checks if the given time falls on a business day
+func (b *BusinessDays) IsBusinessDay(t time.Time) bool {
+	local := t.In(b.location)
+
+	switch local.Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	default:
+		_, holiday := b.holidays[dateOf(local)]
+
+		return !holiday
+	}
+}
+
+// dateOf returns the calendar date of the given time
+func dateOf(t time.Time) date {
+	y, m, d := t.Date()
+
+	return date{year: y, month: m, day: d}
+}
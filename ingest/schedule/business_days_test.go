@@ -0,0 +1,78 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBusinessDays_Next(t *testing.T) {
+	t.Parallel()
+
+	var (
+		daily = MustParseCron("0 17 * * *", caracas)
+
+		// Friday, after the daily run
+		friday = time.Date(2026, time.January, 9, 22, 0, 0, 0, time.UTC)
+	)
+
+	t.Run("skips weekends", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewBusinessDays(daily, caracas)
+
+		assert.True(
+			t,
+			time.Date(2026, time.January, 12, 21, 0, 0, 0, time.UTC).Equal(b.Next(friday)),
+		)
+	})
+
+	t.Run("skips holidays", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewBusinessDays(
+			daily,
+			caracas,
+			time.Date(2026, time.January, 12, 0, 0, 0, 0, time.UTC),
+		)
+
+		assert.True(
+			t,
+			time.Date(2026, time.January, 13, 21, 0, 0, 0, time.UTC).Equal(b.Next(friday)),
+		)
+	})
+
+	t.Run("time zone day boundary", func(t *testing.T) {
+		t.Parallel()
+
+		// Friday 22:00 in Caracas is already Saturday in UTC
+		b := NewBusinessDays(MustParseCron("0 22 * * *", caracas), caracas)
+
+		assert.True(
+			t,
+			time.Date(2026, time.January, 10, 2, 0, 0, 0, time.UTC).Equal(b.Next(friday)),
+		)
+	})
+
+	t.Run("no run", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewBusinessDays(
+			Func(func(time.Time) time.Time {
+				return time.Time{}
+			}),
+			nil,
+		)
+
+		assert.True(t, b.Next(friday).IsZero())
+	})
+
+	t.Run("only weekend runs", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewBusinessDays(MustParseCron("0 0 * * SAT,SUN", time.UTC), time.UTC)
+
+		assert.True(t, b.Next(friday).IsZero())
+	})
+}
@@ -0,0 +1,262 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxCronYears is the search horizon for the next cron run
+const maxCronYears = 5
+
+var ErrInvalidCron = errors.New("invalid cron expression")
+
+var (
+	monthNames = map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+
+	dayNames = map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}
+)
+
+// cronField is the parsing spec of a single cron field
+type cronField struct {
+	names    map[string]int
+	name     string
+	min, max int
+}
+
+var (
+	minuteField = cronField{name: "minute", min: 0, max: 59}
+	hourField   = cronField{name: "hour", min: 0, max: 23}
+	domField    = cronField{name: "day of month", min: 1, max: 31}
+	monthField  = cronField{name: "month", min: 1, max: 12, names: monthNames}
+	dowField    = cronField{name: "day of week", min: 0, max: 7, names: dayNames}
+)
+
+// Cron is a standard 5-field cron expression schedule, evaluated in a time zone
+type Cron struct {
+	location *time.Location
+
+	minute, hour, dom, month, dow uint64 // allowed value bitsets
+
+	domAny, dowAny bool // set if the day field is a wildcard
+}
+
+// ParseCron parses a standard 5-field cron expression
+// (minute, hour, day of month, month, day of week), evaluated in the given time zone (UTC if nil).
+// Fields support wildcards, lists, ranges, steps and (for months and days of week) 3-letter names.
+// As in standard cron, if both day fields are restricted, a day matching either one is a match
+func ParseCron(expr string, loc *time.Location) (*Cron, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: expected 5 fields, got %d", ErrInvalidCron, len(fields))
+	}
+
+	c := &Cron{
+		location: loc,
+		domAny:   fields[2] == "*",
+		dowAny:   fields[4] == "*",
+	}
+
+	specs := []struct {
+		out   *uint64
+		field cronField
+	}{
+		{&c.minute, minuteField},
+		{&c.hour, hourField},
+		{&c.dom, domField},
+		{&c.month, monthField},
+		{&c.dow, dowField},
+	}
+
+	for i, spec := range specs {
+		set, err := parseCronField(fields[i], spec.field)
+		if err != nil {
+			return nil, err
+		}
+
+		*spec.out = set
+	}
+
+	// Sunday can be either 0 or 7
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+
+	return c, nil
+}
+
+// MustParseCron parses the cron expression, and panics if it's invalid
+func MustParseCron(expr string, loc *time.Location) *Cron {
+	c, err := ParseCron(expr, loc)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// Next returns the next run strictly after the given time.
+// A zero time is returned if there is no run within the next 5 years
+func (c *Cron) Next(after time.Time) time.Time {
+	// Cron runs at whole minutes
+	t := after.In(c.location).Truncate(time.Minute).Add(time.Minute)
+
+	limit := t.Year() + maxCronYears
+
+	for t.Year() <= limit {
+		if !has(c.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, c.location)
+
+			continue
+		}
+
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, c.location)
+
+			continue
+		}
+
+		if !has(c.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, c.location)
+
+			continue
+		}
+
+		if !has(c.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// dayMatches checks if the day of the given time matches the day fields
+func (c *Cron) dayMatches(t time.Time) bool {
+	var (
+		domMatch = has(c.dom, t.Day())
+		dowMatch = has(c.dow, int(t.Weekday()))
+	)
+
+	if c.domAny || c.dowAny {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
+
+// has checks if the value is set in the bitset
+func has(set uint64, value int) bool {
+	return set&(1<<uint(value)) != 0 //nolint:gosec // values are bounded by the field ranges
+}
+
+// parseCronField parses a single comma-separated cron field into a bitset
+func parseCronField(raw string, field cronField) (uint64, error) {
+	var set uint64
+
+	for _, part := range strings.Split(raw, ",") {
+		partSet, err := parseCronRange(part, field)
+		if err != nil {
+			return 0, err
+		}
+
+		set |= partSet
+	}
+
+	return set, nil
+}
+
+// parseCronRange parses a single cron range, i.e. `*`, `5`, `1-5`, `*/15` or `MON-FRI/2`
+func parseCronRange(raw string, field cronField) (uint64, error) {
+	invalidErr := fmt.Errorf("%w: invalid %s %q", ErrInvalidCron, field.name, raw)
+
+	rangePart, stepPart, hasStep := strings.Cut(raw, "/")
+
+	step := 1
+
+	if hasStep {
+		s, err := strconv.Atoi(stepPart)
+		if err != nil || s < 1 {
+			return 0, invalidErr
+		}
+
+		step = s
+	}
+
+	var start, end int
+
+	switch {
+	case rangePart == "*":
+		start, end = field.min, field.max
+	case strings.Contains(rangePart, "-"):
+		startRaw, endRaw, _ := strings.Cut(rangePart, "-")
+
+		s, err := parseCronValue(startRaw, field)
+		if err != nil {
+			return 0, invalidErr
+		}
+
+		e, err := parseCronValue(endRaw, field)
+		if err != nil {
+			return 0, invalidErr
+		}
+
+		start, end = s, e
+	default:
+		v, err := parseCronValue(rangePart, field)
+		if err != nil {
+			return 0, invalidErr
+		}
+
+		start, end = v, v
+
+		// A single value with a step runs until the end of the range
+		if hasStep {
+			end = field.max
+		}
+	}
+
+	if start > end {
+		return 0, invalidErr
+	}
+
+	var set uint64
+
+	for v := start; v <= end; v += step {
+		set |= 1 << uint(v) //nolint:gosec // values are bounded by the field ranges
+	}
+
+	return set, nil
+}
+
+// parseCronValue parses a single numeric or named cron value
+func parseCronValue(raw string, field cronField) (int, error) {
+	if v, ok := field.names[strings.ToUpper(raw)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, err
+	}
+
+	if v < field.min || v > field.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", v, field.min, field.max)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,165 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var caracas = time.FixedZone("VET", -4*60*60)
+
+func TestCron_Parse(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name string
+		expr string
+	}{
+		{"too few fields", "0 12 * *"},
+		{"too many fields", "0 0 12 * * *"},
+		{"minute out of range", "60 * * * *"},
+		{"hour out of range", "0 24 * * *"},
+		{"day of month out of range", "0 0 0 * *"},
+		{"invalid month name", "0 0 * FOO *"},
+		{"invalid range", "0 17-9 * * *"},
+		{"invalid step", "*/0 * * * *"},
+		{"non-numeric value", "a * * * *"},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseCron(testCase.expr, time.UTC)
+
+			assert.ErrorIs(t, err, ErrInvalidCron)
+		})
+	}
+
+	t.Run("nil location", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := ParseCron("* * * * *", nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, time.UTC, c.location)
+	})
+
+	t.Run("must parse panics", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Panics(t, func() {
+			MustParseCron("invalid", time.UTC)
+		})
+	})
+}
+
+func TestCron_Next(t *testing.T) {
+	t.Parallel()
+
+	// Wednesday
+	after := time.Date(2026, time.January, 7, 10, 30, 15, 0, time.UTC)
+
+	testTable := []struct {
+		after    time.Time
+		expected time.Time
+		loc      *time.Location
+		name     string
+		expr     string
+	}{
+		{
+			name:     "every minute",
+			expr:     "* * * * *",
+			after:    after,
+			expected: time.Date(2026, time.January, 7, 10, 31, 0, 0, time.UTC),
+		},
+		{
+			name:     "strictly after",
+			expr:     "31 10 * * *",
+			after:    time.Date(2026, time.January, 7, 10, 31, 0, 0, time.UTC),
+			expected: time.Date(2026, time.January, 8, 10, 31, 0, 0, time.UTC),
+		},
+		{
+			name:     "step",
+			expr:     "*/15 * * * *",
+			after:    after,
+			expected: time.Date(2026, time.January, 7, 10, 45, 0, 0, time.UTC),
+		},
+		{
+			name:     "hour list",
+			expr:     "0 9,17 * * *",
+			after:    after,
+			expected: time.Date(2026, time.January, 7, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "time zone",
+			expr:     "0 17 * * *",
+			loc:      caracas,
+			after:    after,
+			expected: time.Date(2026, time.January, 7, 21, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekdays",
+			expr:     "0 9 * * MON-FRI",
+			after:    time.Date(2026, time.January, 9, 12, 0, 0, 0, time.UTC), // Friday
+			expected: time.Date(2026, time.January, 12, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "sunday as 7",
+			expr:     "0 0 * * 7",
+			after:    after,
+			expected: time.Date(2026, time.January, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "month rollover",
+			expr:     "0 0 1 * *",
+			after:    after,
+			expected: time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "named month",
+			expr:     "0 0 15 mar *",
+			after:    after,
+			expected: time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "either day field",
+			expr:     "0 0 20 * MON",
+			after:    after,
+			expected: time.Date(2026, time.January, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "leap day",
+			expr:     "0 0 29 2 *",
+			after:    after,
+			expected: time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "no run",
+			expr:     "0 0 31 2 *",
+			after:    after,
+			expected: time.Time{},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			c, err := ParseCron(testCase.expr, testCase.loc)
+			require.NoError(t, err)
+
+			next := c.Next(testCase.after)
+
+			if testCase.expected.IsZero() {
+				assert.True(t, next.IsZero())
+
+				return
+			}
+
+			assert.True(t, testCase.expected.Equal(next), "expected %s, got %s", testCase.expected, next)
+		})
+	}
+}
@@ -0,0 +1,20 @@
+// Package schedule provides calendar-aware provider schedules,
+// such as cron expressions and business-day-only runs
+package schedule
+
+import "time"
+
+// Schedule computes the next run of a recurring job
+type Schedule interface {
+	// Next returns the next run strictly after the given time.
+	// A zero time is returned if there are no more runs
+	Next(after time.Time) time.Time
+}
+
+// Func is a function adapter for the Schedule interface
+type Func func(after time.Time) time.Time
+
+// Next returns the next run strictly after the given time
+func (f Func) Next(after time.Time) time.Time {
+	return f(after)
+}
@@ -0,0 +1,103 @@
+package ingest
+
+import (
+	"slices"
+	"time"
+)
+
+// StatusReader provides read access to the run status of registered providers
+type StatusReader interface {
+	// Providers returns the run status of all registered providers, sorted by name
+	Providers() []*ProviderStatus
+}
+
+// ProviderStatus is the run status of a single registered provider
+type ProviderStatus struct {
+	LastAttempt         *time.Time         `json:"last_attempt,omitempty"` // start of the latest fetch
+	LastSuccess         *time.Time         `json:"last_success,omitempty"` // end of the latest successful fetch
+	NextRun             *time.Time         `json:"next_run,omitempty"`     // next scheduled fetch, if not running
+	Quarantined         []*QuarantinedRate `json:"quarantined,omitempty"`  // latest rejected rates, newest first
+	Name                string             `json:"name"`
+	LastError           string             `json:"last_error,omitempty"` // latest fetch error, if any
+	ConsecutiveFailures int                `json:"consecutive_failures"`
+	RatesSaved          int                `json:"rates_saved"`    // rates saved on the latest successful fetch
+	RatesRejected       int                `json:"rates_rejected"` // rates rejected by validation on the latest fetch
+	Paused              bool               `json:"paused"`
+}
+
+// snapshot returns a copy of the provider's current status
+func (r *registeredProvider) snapshot() *ProviderStatus {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	status := r.status
+	status.Quarantined = slices.Clone(r.status.Quarantined)
+
+	return &status
+}
+
+// markAttempt records the start of a provider fetch, if the schedule
+// generation is still current. Returns false if the fetch is stale
+func (r *registeredProvider) markAttempt(at time.Time, generation uint64) bool {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if generation != r.generation {
+		return false
+	}
+
+	r.status.LastAttempt = &at
+	r.status.NextRun = nil
+
+	return true
+}
+
+// markFailure records a failed provider fetch,
+// and returns the number of consecutive failures
+func (r *registeredProvider) markFailure(err error) int {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.status.LastError = err.Error()
+	r.status.ConsecutiveFailures++
+
+	return r.status.ConsecutiveFailures
+}
+
+// markSuccess records a successful provider fetch,
+// and returns the number of consecutive failures before it
+func (r *registeredProvider) markSuccess(at time.Time, saved int) int {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	failures := r.status.ConsecutiveFailures
+
+	r.status.LastSuccess = &at
+	r.status.ConsecutiveFailures = 0
+	r.status.RatesSaved = saved
+
+	return failures
+}
+
+// markRejected records the rates rejected by validation on the latest fetch,
+// keeping the latest ones quarantined
+func (r *registeredProvider) markRejected(rejected []*QuarantinedRate) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.status.RatesRejected = len(rejected)
+
+	if len(rejected) == 0 {
+		return
+	}
+
+	quarantined := make([]*QuarantinedRate, 0, len(rejected)+len(r.status.Quarantined))
+
+	for i := len(rejected) - 1; i >= 0; i-- {
+		quarantined = append(quarantined, rejected[i])
+	}
+
+	quarantined = append(quarantined, r.status.Quarantined...)
+
+	r.status.Quarantined = quarantined[:min(len(quarantined), maxQuarantined)]
+}
@@ -0,0 +1,306 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+const (
+	// maxFetchedAtSkew is how far in the future a fetch time can be, allowing for clock skew
+	maxFetchedAtSkew = time.Minute
+
+	// maxQuarantined is the number of latest rejected rates kept per provider
+	maxQuarantined = 50
+)
+
+var (
+	errNonPositiveRate    = errors.New("rate is not positive")
+	errMissingCurrency    = errors.New("missing base or target currency")
+	errSameCurrency       = errors.New("base and target currencies are the same")
+	errMissingSource      = errors.New("missing source")
+	errInvalidAsOf        = errors.New("invalid as-of time")
+	errInvalidFetchedAt   = errors.New("invalid fetched-at time")
+	errExcessiveDeviation = errors.New("rate deviates too much from the previous rate")
+)
+
+// ValidationPolicy defines how fetched rates are validated before they're saved.
+// Rates that fail validation are quarantined instead of saved
+type ValidationPolicy struct {
+	// MaxDeviation is the maximum relative deviation of a rate from the previous stored rate
+	// of the same series (pair, source and rate type), so 0.5 allows ±50%.
+	// 0 disables the deviation check
+	MaxDeviation float64
+
+	// MaxAsOfLead is how far ahead of its fetch time a rate can be effective
+	// (i.e. BCV publishes rates for the next business day). 0 disables the check
+	MaxAsOfLead time.Duration
+
+	// DeviationConfirmations is the number of consecutive fetches of a series, within
+	// MaxDeviation of each other, that confirm a move beyond MaxDeviation (i.e. a devaluation).
+	// The confirming rate is accepted, the earlier ones stay quarantined.
+	// 0 keeps such moves quarantined
+	DeviationConfirmations int
+}
+
+// DefaultValidationPolicy returns the default validation policy:
+// at most ±50% deviation (unless confirmed by 3 consistent fetches),
+// and effective within a week of the fetch
+func DefaultValidationPolicy() ValidationPolicy {
+	return ValidationPolicy{
+		MaxDeviation:           0.5,
+		MaxAsOfLead:            time.Hour * 24 * 7,
+		DeviationConfirmations: 3,
+	}
+}
+
+// QuarantinedRate is a fetched rate rejected by validation
+type QuarantinedRate struct {
+	RejectedAt time.Time           `json:"rejected_at"`
+	Rate       *types.ExchangeRate `json:"rate"`
+	Reason     string              `json:"reason"`
+}
+
+// Check runs the structural checks on the rate: positive rate, distinct currencies,
+// a set source, and sane timestamps (relative to now)
+func (p ValidationPolicy) Check(rate *types.ExchangeRate, now time.Time) error {
+	if rate.Exact().Sign() <= 0 {
+		return errNonPositiveRate
+	}
+
+	if rate.Base == "" || rate.Target == "" {
+		return errMissingCurrency
+	}
+
+	if rate.Base == rate.Target {
+		return errSameCurrency
+	}
+
+	if rate.Source == "" {
+		return errMissingSource
+	}
+
+	if rate.FetchedAt.IsZero() || rate.FetchedAt.After(now.Add(maxFetchedAtSkew)) {
+		return fmt.Errorf("%w: %s", errInvalidFetchedAt, rate.FetchedAt)
+	}
+
+	if rate.AsOf.IsZero() {
+		return errInvalidAsOf
+	}
+
+	if p.MaxAsOfLead > 0 && rate.AsOf.Sub(rate.FetchedAt) > p.MaxAsOfLead {
+		return fmt.Errorf(
+			"%w: %s is more than %s after the fetch",
+			errInvalidAsOf,
+			rate.AsOf,
+			p.MaxAsOfLead,
+		)
+	}
+
+	return nil
+}
+
+// CheckDeviation checks the rate's relative deviation from the previous rate of the same series
+func (p ValidationPolicy) CheckDeviation(rate, previous *types.ExchangeRate) error {
+	if p.MaxDeviation <= 0 || previous == nil {
+		return nil
+	}
+
+	prev := previous.Exact().Float64()
+	if prev <= 0 {
+		return nil
+	}
+
+	deviation := math.Abs(rate.Exact().Float64()-prev) / prev
+	if deviation > p.MaxDeviation {
+		return fmt.Errorf(
+			"%w: %s vs %s (%.2f%%, max %.2f%%)",
+			errExcessiveDeviation,
+			rate.Exact(),
+			previous.Exact(),
+			deviation*100,
+			p.MaxDeviation*100,
+		)
+	}
+
+	return nil
+}
+
+// validateRates validates the provider-fetched rates, and returns the valid ones.
+// Rejected rates are quarantined on the provider, and logged
+func (o *Orchestrator) validateRates(
+	ctx context.Context,
+	rp *registeredProvider,
+	rates []*types.ExchangeRate,
+	now time.Time,
+) ([]*types.ExchangeRate, error) {
+	policy := rp.validationPolicy
+	if policy == nil {
+		return rates, nil
+	}
+
+	var (
+		valid    = make([]*types.ExchangeRate, 0, len(rates))
+		rejected = make([]*QuarantinedRate, 0)
+	)
+
+	for _, rate := range rates {
+		err := policy.Check(rate, now)
+		if err == nil {
+			previous, lookupErr := o.previousRate(ctx, rate)
+			if lookupErr != nil {
+				return nil, fmt.Errorf("unable to fetch previous rate: %w", lookupErr)
+			}
+
+			err = policy.CheckDeviation(rate, previous)
+
+			switch {
+			case err == nil:
+				rp.clearMove(rate)
+			case errors.Is(err, errExcessiveDeviation) && rp.confirmMove(rate, *policy):
+				o.logger.Info(
+					"exchange rate move confirmed",
+					"provider", rp.provider.Name(),
+					"base", rate.Base,
+					"target", rate.Target,
+					"source", rate.Source,
+					"rate", rate.Rate,
+					"rate_type", rate.RateType,
+					"previous", previous.Rate,
+				)
+
+				err = nil
+			}
+		}
+
+		if err == nil {
+			valid = append(valid, rate)
+
+			continue
+		}
+
+		o.logger.Warn(
+			"exchange rate rejected",
+			"provider", rp.provider.Name(),
+			"base", rate.Base,
+			"target", rate.Target,
+			"source", rate.Source,
+			"rate", rate.Rate,
+			"rate_type", rate.RateType,
+			"reason", err.Error(),
+		)
+
+		rejected = append(rejected, &QuarantinedRate{
+			RejectedAt: now,
+			Rate:       rate,
+			Reason:     err.Error(),
+		})
+	}
+
+	rp.markRejected(rejected)
+
+	return valid, nil
+}
+
+// seriesKey identifies the series of a rate
+type seriesKey struct {
+	base, target types.Currency
+	source       types.Source
+	rateType     types.RateType
+}
+
+// seriesKeyOf returns the series key of the rate
+func seriesKeyOf(rate *types.ExchangeRate) seriesKey {
+	return seriesKey{
+		base:     rate.Base,
+		target:   rate.Target,
+		source:   rate.Source,
+		rateType: rate.RateType,
+	}
+}
+
+// pendingMove is an unconfirmed move of a series beyond the max deviation
+type pendingMove struct {
+	latest *types.ExchangeRate // the latest rejected rate of the move
+	seen   int                 // consecutive consistent rejected rates
+}
+
+// confirmMove records the rate rejected for deviating too much, and returns true
+// if it confirms the series move (the policy's number of consecutive fetches,
+// within the max deviation of each other)
+func (r *registeredProvider) confirmMove(rate *types.ExchangeRate, policy ValidationPolicy) bool {
+	if policy.DeviationConfirmations <= 0 {
+		return false
+	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if r.pendingMoves == nil {
+		r.pendingMoves = make(map[seriesKey]*pendingMove)
+	}
+
+	key := seriesKeyOf(rate)
+
+	// An inconsistent rate starts over
+	move, ok := r.pendingMoves[key]
+	if !ok || policy.CheckDeviation(rate, move.latest) != nil {
+		move = &pendingMove{}
+		r.pendingMoves[key] = move
+	}
+
+	move.latest = rate
+	move.seen++
+
+	if move.seen < policy.DeviationConfirmations {
+		return false
+	}
+
+	delete(r.pendingMoves, key)
+
+	return true
+}
+
+// clearMove drops the pending move of the rate's series, if any,
+// as the rate is consistent with the stored series
+func (r *registeredProvider) clearMove(rate *types.ExchangeRate) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	delete(r.pendingMoves, seriesKeyOf(rate))
+}
+
+// previousRate fetches the latest stored rate of the rate's series,
+// effective at or before the rate. Returns nil if there is none
+func (o *Orchestrator) previousRate(
+	ctx context.Context,
+	rate *types.ExchangeRate,
+) (*types.ExchangeRate, error) {
+	lookupCtx, cancelFn := context.WithTimeout(ctx, saveTimeout)
+	defer cancelFn()
+
+	page, err := o.storage.RateAsOf(
+		lookupCtx,
+		&types.RateQuery{
+			Base:     rate.Base,
+			Target:   &rate.Target,
+			Source:   &rate.Source,
+			RateType: &rate.RateType,
+			Limit:    1,
+		},
+		rate.AsOf,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if page == nil || len(page.Results) == 0 {
+		return nil, nil
+	}
+
+	return page.Results[0], nil
+}
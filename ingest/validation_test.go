@@ -0,0 +1,388 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/provider/currencies"
+	"github.com/sig-0/fxrates/storage/mock"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+var validationNow = time.Date(2026, time.January, 7, 12, 0, 0, 0, time.UTC)
+
+// newValidRate creates a new rate that passes the structural checks
+func newValidRate(rate string) *types.ExchangeRate {
+	exact := types.MustParseDecimal(rate)
+
+	return &types.ExchangeRate{
+		AsOf:      time.Date(2026, time.January, 8, 0, 0, 0, 0, time.UTC),
+		FetchedAt: validationNow,
+		Base:      currencies.USD,
+		Target:    currencies.VES,
+		RateType:  types.RateTypeMID,
+		Source:    "BCV",
+		Rate:      exact.Float64(),
+		RateExact: exact,
+	}
+}
+
+func TestValidationPolicy_Check(t *testing.T) {
+	t.Parallel()
+
+	policy := DefaultValidationPolicy()
+
+	testTable := []struct {
+		name     string
+		modifyFn func(rate *types.ExchangeRate)
+		expected error
+	}{
+		{"valid rate", func(_ *types.ExchangeRate) {}, nil},
+		{
+			"zero rate",
+			func(rate *types.ExchangeRate) {
+				rate.Rate, rate.RateExact = 0, types.Decimal{}
+			},
+			errNonPositiveRate,
+		},
+		{
+			"negative rate",
+			func(rate *types.ExchangeRate) {
+				rate.RateExact = types.MustParseDecimal("-341.74")
+			},
+			errNonPositiveRate,
+		},
+		{
+			"missing target",
+			func(rate *types.ExchangeRate) {
+				rate.Target = ""
+			},
+			errMissingCurrency,
+		},
+		{
+			"same currencies",
+			func(rate *types.ExchangeRate) {
+				rate.Target = rate.Base
+			},
+			errSameCurrency,
+		},
+		{
+			"missing source",
+			func(rate *types.ExchangeRate) {
+				rate.Source = ""
+			},
+			errMissingSource,
+		},
+		{
+			"missing fetched-at",
+			func(rate *types.ExchangeRate) {
+				rate.FetchedAt = time.Time{}
+			},
+			errInvalidFetchedAt,
+		},
+		{
+			"fetched in the future",
+			func(rate *types.ExchangeRate) {
+				rate.FetchedAt = validationNow.Add(time.Hour)
+			},
+			errInvalidFetchedAt,
+		},
+		{
+			"missing as-of",
+			func(rate *types.ExchangeRate) {
+				rate.AsOf = time.Time{}
+			},
+			errInvalidAsOf,
+		},
+		{
+			"effective too far ahead",
+			func(rate *types.ExchangeRate) {
+				rate.AsOf = validationNow.Add(policy.MaxAsOfLead + time.Hour)
+			},
+			errInvalidAsOf,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			rate := newValidRate("341.7412")
+			testCase.modifyFn(rate)
+
+			err := policy.Check(rate, validationNow)
+			if testCase.expected == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.ErrorIs(t, err, testCase.expected)
+		})
+	}
+}
+
+func TestValidationPolicy_CheckDeviation(t *testing.T) {
+	t.Parallel()
+
+	var (
+		policy   = ValidationPolicy{MaxDeviation: 0.2}
+		previous = newValidRate("341.7412")
+	)
+
+	assert.NoError(t, policy.CheckDeviation(newValidRate("345"), previous))
+	assert.NoError(t, policy.CheckDeviation(newValidRate("400"), previous)) // +17%
+	assert.NoError(t, policy.CheckDeviation(newValidRate("341.74"), nil))   // no previous rate
+	assert.NoError(t, ValidationPolicy{}.CheckDeviation(newValidRate("341741.2"), previous))
+
+	// Thousands separator misread
+	assert.ErrorIs(t, policy.CheckDeviation(newValidRate("341741.2"), previous), errExcessiveDeviation)
+	assert.ErrorIs(t, policy.CheckDeviation(newValidRate("0.3417412"), previous), errExcessiveDeviation)
+}
+
+func TestOrchestrator_ValidateRates(t *testing.T) {
+	t.Parallel()
+
+	// newValidatedProvider registers a provider with the validation policy
+	newValidatedProvider := func(t *testing.T, o *Orchestrator, opts ...RegisterOption) *registeredProvider {
+		t.Helper()
+
+		require.NoError(t, o.Register(&mockProvider{
+			nameFn: func() string {
+				return testProviderName
+			},
+			intervalFn: func() time.Duration {
+				return time.Hour
+			},
+		}, opts...))
+
+		return registeredProviderOf(t, o)
+	}
+
+	// previousRateStorage is a storage holding a single previous rate
+	previousRateStorage := func(previous *types.ExchangeRate) *mock.Storage {
+		return &mock.Storage{
+			RateAsOfFn: func(
+				_ context.Context,
+				query *types.RateQuery,
+				_ time.Time,
+			) (*types.Page[*types.ExchangeRate], error) {
+				if previous == nil || *query.Target != previous.Target {
+					return &types.Page[*types.ExchangeRate]{}, nil
+				}
+
+				return &types.Page[*types.ExchangeRate]{
+					Results: []*types.ExchangeRate{previous},
+					Total:   1,
+				}, nil
+			},
+		}
+	}
+
+	t.Run("validation disabled", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			o  = New(&mock.Storage{})
+			rp = newValidatedProvider(t, o)
+
+			rates = []*types.ExchangeRate{{Base: currencies.USD, Target: currencies.VES}}
+		)
+
+		valid, err := o.validateRates(context.Background(), rp, rates, validationNow)
+		require.NoError(t, err)
+
+		assert.Equal(t, rates, valid)
+	})
+
+	t.Run("invalid rates quarantined", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			o = New(
+				previousRateStorage(newValidRate("341.7412")),
+				WithDefaultValidationPolicy(DefaultValidationPolicy()),
+			)
+			rp = newValidatedProvider(t, o)
+
+			valid     = newValidRate("341.8")
+			misread   = newValidRate("341800")
+			zero      = newValidRate("0")
+			firstSeen = newValidRate("398.12")
+		)
+
+		firstSeen.Base = currencies.EUR
+		firstSeen.Target = currencies.USD // no previous rate
+
+		result, err := o.validateRates(
+			context.Background(),
+			rp,
+			[]*types.ExchangeRate{valid, misread, zero, firstSeen},
+			validationNow,
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, []*types.ExchangeRate{valid, firstSeen}, result)
+
+		status := rp.snapshot()
+
+		assert.Equal(t, 2, status.RatesRejected)
+		require.Len(t, status.Quarantined, 2)
+
+		// Newest first
+		assert.Equal(t, zero, status.Quarantined[0].Rate)
+		assert.Contains(t, status.Quarantined[0].Reason, errNonPositiveRate.Error())
+		assert.Equal(t, misread, status.Quarantined[1].Rate)
+		assert.Contains(t, status.Quarantined[1].Reason, errExcessiveDeviation.Error())
+		assert.Equal(t, validationNow, status.Quarantined[1].RejectedAt)
+	})
+
+	t.Run("provider policy takes precedence", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			o = New(
+				previousRateStorage(newValidRate("341.7412")),
+				WithDefaultValidationPolicy(ValidationPolicy{MaxDeviation: 0.1}),
+			)
+			rp = newValidatedProvider(t, o, WithValidationPolicy(ValidationPolicy{MaxDeviation: 2}))
+
+			rates = []*types.ExchangeRate{newValidRate("683.4824")} // +100%
+		)
+
+		valid, err := o.validateRates(context.Background(), rp, rates, validationNow)
+		require.NoError(t, err)
+
+		assert.Equal(t, rates, valid)
+		assert.Zero(t, rp.snapshot().RatesRejected)
+	})
+
+	t.Run("confirmed move accepted", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			o = New(
+				previousRateStorage(newValidRate("341.7412")),
+				WithDefaultValidationPolicy(ValidationPolicy{MaxDeviation: 0.5, DeviationConfirmations: 3}),
+			)
+			rp = newValidatedProvider(t, o)
+		)
+
+		// validate validates a single fetched rate
+		validate := func(rate string) []*types.ExchangeRate {
+			t.Helper()
+
+			valid, err := o.validateRates(
+				context.Background(),
+				rp,
+				[]*types.ExchangeRate{newValidRate(rate)},
+				validationNow,
+			)
+			require.NoError(t, err)
+
+			return valid
+		}
+
+		// A devaluation is quarantined until consistently confirmed
+		assert.Empty(t, validate("600"))
+		assert.Empty(t, validate("610"))
+		assert.Len(t, validate("605"), 1)
+
+		// Glitches inconsistent with each other start over
+		assert.Empty(t, validate("3417412"))
+		assert.Empty(t, validate("0.03417412"))
+		assert.Empty(t, validate("3417412"))
+
+		// A rate consistent with the stored series drops the pending move
+		assert.Len(t, validate("341.8"), 1)
+		assert.Empty(t, validate("3417412"))
+		assert.Empty(t, validate("3417412"))
+		assert.Len(t, validate("3417412"), 1)
+	})
+
+	t.Run("unconfirmed moves stay quarantined", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			o = New(
+				previousRateStorage(newValidRate("341.7412")),
+				WithDefaultValidationPolicy(ValidationPolicy{MaxDeviation: 0.5}),
+			)
+			rp = newValidatedProvider(t, o)
+		)
+
+		for range 10 {
+			valid, err := o.validateRates(
+				context.Background(),
+				rp,
+				[]*types.ExchangeRate{newValidRate("600")},
+				validationNow,
+			)
+			require.NoError(t, err)
+
+			assert.Empty(t, valid)
+		}
+
+		assert.Len(t, rp.snapshot().Quarantined, 10)
+	})
+
+	t.Run("previous rate lookup error", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			o = New(
+				&mock.Storage{
+					RateAsOfFn: func(
+						_ context.Context,
+						_ *types.RateQuery,
+						_ time.Time,
+					) (*types.Page[*types.ExchangeRate], error) {
+						return nil, errors.New("storage error")
+					},
+				},
+				WithDefaultValidationPolicy(DefaultValidationPolicy()),
+			)
+			rp = newValidatedProvider(t, o)
+		)
+
+		_, err := o.validateRates(
+			context.Background(),
+			rp,
+			[]*types.ExchangeRate{newValidRate("341.7412")},
+			validationNow,
+		)
+		assert.Error(t, err)
+	})
+
+	t.Run("quarantine is capped", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			o = New(
+				&mock.Storage{},
+				WithDefaultValidationPolicy(DefaultValidationPolicy()),
+			)
+			rp = newValidatedProvider(t, o)
+		)
+
+		for i := range maxQuarantined + 5 {
+			rate := newValidRate("0")
+			rate.Source = types.Source(fmt.Sprintf("Source %d", i))
+
+			_, err := o.validateRates(context.Background(), rp, []*types.ExchangeRate{rate}, validationNow)
+			require.NoError(t, err)
+		}
+
+		status := rp.snapshot()
+
+		require.Len(t, status.Quarantined, maxQuarantined)
+		assert.Equal(t, types.Source(fmt.Sprintf("Source %d", maxQuarantined+4)), status.Quarantined[0].Rate.Source)
+		assert.Equal(t, 1, status.RatesRejected)
+	})
+}
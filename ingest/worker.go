@@ -14,6 +14,7 @@ type scheduledIngest struct {
 	at         time.Time
 	provider   Provider
 	providerID xid.ID
+	generation uint64 // the provider's schedule generation
 }
 
 // Less is utilized to sort scheduled ingests by their due-time (latest == first)
@@ -26,6 +27,7 @@ type workerInfo struct {
 	provider   Provider
 	resCh      chan<- *workerResponse
 	providerID xid.ID
+	generation uint64
 }
 
 // workerResponse is the provider routine response
@@ -33,6 +35,7 @@ type workerResponse struct {
 	error      error                 // encountered error, if any
 	rates      []*types.ExchangeRate // the fetched exchange rates
 	providerID xid.ID                // the provider ID
+	generation uint64                // the provider's schedule generation
 }
 
 // handleJob fetches using the provider
@@ -46,6 +49,7 @@ func handleJob(
 		error:      err,
 		rates:      rates,
 		providerID: info.providerID,
+		generation: info.generation,
 	}
 
 	select {
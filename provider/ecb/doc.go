@@ -0,0 +1,21 @@
+// Package ecb provides the European Central Bank euro foreign exchange reference rates.
+//
+// # Providers
+//
+// ## ECB (Euro Reference Rates)
+//
+// Source: "ECB"
+// URL: https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml
+// Schedule: business days at 16:15 and 17:15 (Frankfurt time)
+//
+// Fetches the daily euro reference rates, published by the ECB around 16:00 CET
+// on TARGET business days. Returns MID rates for around 30 currencies:
+//
+//	EUR/USD, EUR/JPY, EUR/GBP, EUR/CHF, EUR/CNY, EUR/TRY...
+//
+// The effective date (AsOf) is the UTC midnight of the reference date.
+//
+// With backfill enabled, fetches return the rates of the last 90 days instead
+// (https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist-90d.xml), until a run
+// is saved (see ingest.SaveObserver), so a failed save doesn't lose the backfill.
+package ecb
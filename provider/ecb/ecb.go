@@ -0,0 +1,206 @@
+package ecb
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/sig-0/fxrates/ingest/schedule"
+	"github.com/sig-0/fxrates/provider/currencies"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+const (
+	// DailyURL is the URL of the latest ECB reference rates
+	DailyURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+
+	// HistoryURL is the URL of the ECB reference rates of the last 90 days
+	HistoryURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist-90d.xml"
+)
+
+// ecbCron checks the ECB rates on business day afternoons (Frankfurt time),
+// after the daily concertation (around 16:00 CET)
+const ecbCron = "15 16,17 * * *"
+
+// referenceDateLayout is the layout of the reference rate dates
+const referenceDateLayout = "2006-01-02"
+
+var Source types.Source = "ECB"
+
+var errNoRates = errors.New("no reference rates found")
+
+// Option is a single ECB provider option
+type Option func(p *Provider)
+
+// WithBackfill makes the provider backfill the reference rates from the given
+// history file (i.e. HistoryURL), until its first run is saved
+func WithBackfill(historyURL string) Option {
+	return func(p *Provider) {
+		p.historyURL = historyURL
+	}
+}
+
+// Provider is the ECB euro foreign exchange reference rates provider.
+// The rates are published once per business day, as EUR/XXX MID rates
+type Provider struct {
+	client     *http.Client
+	schedule   schedule.Schedule
+	url        string
+	historyURL string // empty if the rates are not backfilled
+
+	backfilled atomic.Bool
+}
+
+// NewProvider creates a new instance of the ECB reference rates provider
+func NewProvider(url string, timeout time.Duration, opts ...Option) *Provider {
+	loc := frankfurtLocation()
+
+	p := &Provider{
+		client: &http.Client{
+			Timeout: timeout,
+		},
+		schedule: schedule.NewBusinessDays(
+			schedule.MustParseCron(ecbCron, loc),
+			loc,
+		),
+		url: url,
+	}
+
+	// Apply the options
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+func (p *Provider) Name() string {
+	return "ECB"
+}
+
+func (p *Provider) Interval() time.Duration {
+	return time.Hour * 24 // the rates are updated daily
+}
+
+func (p *Provider) NextRun(after time.Time) time.Time {
+	return p.schedule.Next(after)
+}
+
+// Fetch fetches the latest reference rates. If backfill is enabled,
+// the whole history file is fetched instead, until its rates are saved
+func (p *Provider) Fetch(ctx context.Context) ([]*types.ExchangeRate, error) {
+	if p.historyURL == "" || p.backfilled.Load() {
+		return p.fetchRates(ctx, p.url)
+	}
+
+	rates, err := p.fetchRates(ctx, p.historyURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to backfill rates: %w", err)
+	}
+
+	return rates, nil
+}
+
+// OnSaved marks the history as backfilled, once the rates of a run are saved.
+// Until then, every fetch is a backfill
+func (p *Provider) OnSaved(saved []*types.ExchangeRate) {
+	if p.historyURL != "" && len(saved) > 0 {
+		p.backfilled.Store(true)
+	}
+}
+
+// fetchRates fetches and parses the reference rates file at the given URL
+func (p *Provider) fetchRates(ctx context.Context, url string) ([]*types.ExchangeRate, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create GET request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute GET request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("invalid status code received: %d", resp.StatusCode)
+	}
+
+	var env envelope
+	if err := xml.NewDecoder(resp.Body).Decode(&env); err != nil {
+		return nil, fmt.Errorf("unable to decode reference rates: %w", err)
+	}
+
+	return env.rates(time.Now().UTC())
+}
+
+// envelope is the ECB reference rates file. The rates are nested in three levels of cubes:
+// the root one, one per reference date, and one per currency
+type envelope struct {
+	Cube struct {
+		Days []struct {
+			Time  string `xml:"time,attr"`
+			Rates []struct {
+				Currency string `xml:"currency,attr"`
+				Rate     string `xml:"rate,attr"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	} `xml:"Cube"`
+}
+
+// rates converts the reference rates into EUR/XXX MID rates, effective
+// from the UTC midnight of their reference date
+func (e *envelope) rates(fetchTime time.Time) ([]*types.ExchangeRate, error) {
+	out := make([]*types.ExchangeRate, 0)
+
+	for _, day := range e.Cube.Days {
+		asOf, err := time.Parse(referenceDateLayout, strings.TrimSpace(day.Time))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse reference date %q: %w", day.Time, err)
+		}
+
+		for _, it := range day.Rates {
+			currency := strings.ToUpper(strings.TrimSpace(it.Currency))
+			if currency == "" {
+				return nil, fmt.Errorf("missing currency on %s", day.Time)
+			}
+
+			rate, err := types.ParseDecimal(strings.TrimSpace(it.Rate))
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse %s rate %q: %w", currency, it.Rate, err)
+			}
+
+			out = append(out, &types.ExchangeRate{
+				AsOf:      asOf,
+				FetchedAt: fetchTime,
+				Base:      currencies.EUR,
+				Target:    types.Currency(currency),
+				RateType:  types.RateTypeMID,
+				Source:    Source,
+				Rate:      rate.Float64(),
+				RateExact: rate,
+			})
+		}
+	}
+
+	if len(out) == 0 {
+		return nil, errNoRates
+	}
+
+	return out, nil
+}
+
+// frankfurtLocation returns the ECB time zone
+func frankfurtLocation() *time.Location {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err == nil {
+		return loc
+	}
+
+	return time.FixedZone("CET", 60*60)
+}
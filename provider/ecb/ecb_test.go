@@ -0,0 +1,279 @@
+package ecb
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/ingest"
+	"github.com/sig-0/fxrates/provider/currencies"
+	"github.com/sig-0/fxrates/storage/mock"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+// newFixtureServer creates a new test server serving the testdata files
+func newFixtureServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		data, err := os.ReadFile(filepath.Join("testdata", filepath.Base(r.URL.Path)))
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write(data)
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return srv, &requests
+}
+
+// date returns the UTC midnight of the given date
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestProvider_Fetch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("daily rates", func(t *testing.T) {
+		t.Parallel()
+
+		srv, _ := newFixtureServer(t)
+
+		p := NewProvider(srv.URL+"/eurofxref-daily.xml", time.Second*5)
+
+		rates, err := p.Fetch(context.Background())
+		require.NoError(t, err)
+
+		require.Len(t, rates, 6)
+
+		usd := rates[0]
+
+		assert.Equal(t, date(2026, time.January, 7), usd.AsOf)
+		assert.WithinDuration(t, time.Now(), usd.FetchedAt, time.Minute)
+		assert.Equal(t, currencies.EUR, usd.Base)
+		assert.Equal(t, currencies.USD, usd.Target)
+		assert.Equal(t, types.RateTypeMID, usd.RateType)
+		assert.Equal(t, Source, usd.Source)
+		assert.Equal(t, "1.1691", usd.RateExact.String())
+		assert.InDelta(t, 1.1691, usd.Rate, 1e-9)
+
+		targets := make([]types.Currency, 0, len(rates))
+		for _, rate := range rates {
+			targets = append(targets, rate.Target)
+		}
+
+		assert.Equal(
+			t,
+			[]types.Currency{"USD", "JPY", "GBP", "CHF", "CNY", "TRY"},
+			targets,
+		)
+	})
+
+	t.Run("history backfilled once", func(t *testing.T) {
+		t.Parallel()
+
+		srv, requests := newFixtureServer(t)
+
+		p := NewProvider(
+			srv.URL+"/eurofxref-daily.xml",
+			time.Second*5,
+			WithBackfill(srv.URL+"/eurofxref-hist-90d.xml"),
+		)
+
+		// Backfill
+		rates, err := p.Fetch(context.Background())
+		require.NoError(t, err)
+
+		require.Len(t, rates, 6)
+
+		asOfs := make(map[time.Time]int)
+		for _, rate := range rates {
+			asOfs[rate.AsOf]++
+		}
+
+		assert.Equal(
+			t,
+			map[time.Time]int{
+				date(2026, time.January, 5): 2,
+				date(2026, time.January, 6): 2,
+				date(2026, time.January, 7): 2,
+			},
+			asOfs,
+		)
+
+		// Daily rates, once the backfill is saved
+		p.OnSaved(rates)
+
+		rates, err = p.Fetch(context.Background())
+		require.NoError(t, err)
+
+		assert.Len(t, rates, 6)
+		assert.EqualValues(t, 2, requests.Load())
+	})
+
+	t.Run("backfill kept until saved", func(t *testing.T) {
+		t.Parallel()
+
+		srv, _ := newFixtureServer(t)
+
+		p := NewProvider(
+			srv.URL+"/eurofxref-daily.xml",
+			time.Second*5,
+			WithBackfill(srv.URL+"/eurofxref-hist-90d.xml"),
+		)
+
+		var (
+			saves   atomic.Int32
+			savedCh = make(chan []*types.ExchangeRate, 1)
+
+			store = &mock.BatchStorage{
+				SaveExchangeRatesFn: func(
+					_ context.Context,
+					rates []*types.ExchangeRate,
+				) ([]types.SaveStatus, error) {
+					// The first save fails
+					if saves.Add(1) == 1 {
+						return nil, errors.New("database unavailable")
+					}
+
+					savedCh <- rates
+
+					return nil, nil
+				},
+			}
+
+			o = ingest.New(
+				store,
+				ingest.WithQueryInterval(time.Millisecond*10),
+				ingest.WithDefaultRetryPolicy(ingest.RetryPolicy{InitialDelay: time.Millisecond}),
+			)
+		)
+
+		require.NoError(t, o.Register(p))
+
+		ctx, cancelFn := context.WithCancel(context.Background())
+		defer cancelFn()
+
+		go func() {
+			_ = o.Start(ctx)
+		}()
+
+		// The retried run backfills the history again
+		var saved []*types.ExchangeRate
+
+		select {
+		case saved = <-savedCh:
+		case <-time.After(5 * time.Second):
+			t.Fatal("rates not saved in time")
+		}
+
+		asOfs := make(map[time.Time]struct{})
+		for _, rate := range saved {
+			asOfs[rate.AsOf] = struct{}{}
+		}
+
+		assert.Len(t, asOfs, 3)
+
+		// Once saved, the daily rates are fetched
+		assert.Eventually(t, p.backfilled.Load, 5*time.Second, time.Millisecond*10)
+
+		rates, err := p.Fetch(context.Background())
+		require.NoError(t, err)
+
+		for _, rate := range rates {
+			assert.Equal(t, date(2026, time.January, 7), rate.AsOf)
+		}
+	})
+
+	t.Run("backfill retried on failure", func(t *testing.T) {
+		t.Parallel()
+
+		srv, _ := newFixtureServer(t)
+
+		p := NewProvider(
+			srv.URL+"/eurofxref-daily.xml",
+			time.Second*5,
+			WithBackfill(srv.URL+"/missing.xml"),
+		)
+
+		for range 2 {
+			_, err := p.Fetch(context.Background())
+			assert.ErrorContains(t, err, "unable to backfill rates")
+		}
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		t.Parallel()
+
+		srv, _ := newFixtureServer(t)
+
+		_, err := NewProvider(srv.URL+"/missing.xml", time.Second*5).Fetch(context.Background())
+
+		assert.ErrorContains(t, err, "invalid status code")
+	})
+}
+
+func TestEnvelope_Rates(t *testing.T) {
+	t.Parallel()
+
+	fetchTime := time.Date(2026, time.January, 7, 15, 30, 0, 0, time.UTC)
+
+	testTable := []struct {
+		name        string
+		body        string
+		expectedErr string
+	}{
+		{
+			"no rates",
+			`<Envelope><Cube></Cube></Envelope>`,
+			errNoRates.Error(),
+		},
+		{
+			"invalid date",
+			`<Envelope><Cube><Cube time="07.01.2026"><Cube currency="USD" rate="1.1691"/></Cube></Cube></Envelope>`,
+			"unable to parse reference date",
+		},
+		{
+			"invalid rate",
+			`<Envelope><Cube><Cube time="2026-01-07"><Cube currency="USD" rate="1,1691"/></Cube></Cube></Envelope>`,
+			"unable to parse USD rate",
+		},
+		{
+			"missing currency",
+			`<Envelope><Cube><Cube time="2026-01-07"><Cube rate="1.1691"/></Cube></Cube></Envelope>`,
+			"missing currency",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			var env envelope
+			require.NoError(t, xml.Unmarshal([]byte(testCase.body), &env))
+
+			_, err := env.rates(fetchTime)
+			assert.ErrorContains(t, err, testCase.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package p2p
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// trimmedMeanFraction is the fraction of the prices dropped on each end for the trimmed mean
+const trimmedMeanFraction = 0.2
+
+var (
+	errUnknownAggregation = errors.New("unknown P2P aggregation")
+
+	// ErrInsufficientDepth is returned when the offers can't fill the notional amount
+	ErrInsufficientDepth = errors.New("insufficient P2P order book depth")
+)
+
+// Aggregation is the statistic a P2P rate is computed with, from the best offer prices
+type Aggregation string
+
+const (
+	// Median is the median price
+	Median Aggregation = "median"
+
+	// TrimmedMean is the mean price, without the 20% lowest and 20% highest prices
+	TrimmedMean Aggregation = "trimmed_mean"
+
+	// VolumeWeighted is the mean price, weighted by the available amount of each offer
+	VolumeWeighted Aggregation = "volume_weighted"
+
+	// Depth is the effective price to fill the notional amount, walking the order book
+	// from the best offer, within each offer's available amount and transaction limits
+	Depth Aggregation = "depth"
+)
+
+// ParseAggregation parses the aggregation name
+func ParseAggregation(name string) (Aggregation, error) {
+	switch aggregation := Aggregation(name); aggregation {
+	case Median, TrimmedMean, VolumeWeighted, Depth:
+		return aggregation, nil
+	default:
+		return "", fmt.Errorf("%w: %q", errUnknownAggregation, name)
+	}
+}
+
+// aggregate computes the aggregated price of the offers
+func aggregate(offers []Offer, aggregation Aggregation) (float64, error) {
+	prices := make([]float64, len(offers))
+	for i, offer := range offers {
+		prices[i] = offer.Price
+	}
+
+	switch aggregation {
+	case Median:
+		return median(prices), nil
+	case TrimmedMean:
+		return trimmedMean(prices, trimmedMeanFraction), nil
+	case VolumeWeighted:
+		return volumeWeightedMean(offers), nil
+	default:
+		return 0, fmt.Errorf("%w: %q", errUnknownAggregation, aggregation)
+	}
+}
+
+// fillPrice walks the offers (best first) to fill the notional amount, and returns
+// the effective price of the fill, along with the number of offers it takes
+func fillPrice(offers []Offer, notional float64) (float64, int, error) {
+	var (
+		remaining = notional
+		cost      float64
+		used      int
+	)
+
+	for _, offer := range offers {
+		if remaining <= 0 {
+			break
+		}
+
+		// The transaction limits are in fiat
+		if offer.MinLimit > 0 && remaining*offer.Price < offer.MinLimit {
+			continue // the remaining amount is below the offer's minimum
+		}
+
+		capacity := remaining
+
+		if offer.Available > 0 {
+			capacity = math.Min(capacity, offer.Available)
+		}
+
+		if offer.MaxLimit > 0 {
+			capacity = math.Min(capacity, offer.MaxLimit/offer.Price)
+		}
+
+		cost += capacity * offer.Price
+		remaining -= capacity
+		used++
+	}
+
+	// Tolerate float rounding leftovers
+	if remaining > notional*1e-9 {
+		return 0, 0, fmt.Errorf("%w: %s left unfilled", ErrInsufficientDepth, formatAmount(remaining))
+	}
+
+	return cost / notional, used, nil
+}
+
+// median calculates the median of a slice of float64 values
+func median(values []float64) float64 {
+	sort.Float64s(values)
+
+	n := len(values)
+	if n%2 == 0 {
+		return (values[n/2-1] + values[n/2]) / 2
+	}
+
+	return values[n/2]
+}
+
+// trimmedMean calculates the mean of the values, without the given fraction
+// of the lowest and highest values. Falls back to the median if nothing is left
+func trimmedMean(values []float64, fraction float64) float64 {
+	sort.Float64s(values)
+
+	var (
+		trim = int(float64(len(values)) * fraction)
+		kept = values[trim : len(values)-trim]
+	)
+
+	if len(kept) == 0 {
+		return median(values)
+	}
+
+	sum := 0.0
+	for _, v := range kept {
+		sum += v
+	}
+
+	return sum / float64(len(kept))
+}
+
+// volumeWeightedMean calculates the mean offer price, weighted by the available amounts.
+// Offers without a known available amount are weighted as the smallest known one
+func volumeWeightedMean(offers []Offer) float64 {
+	minAvailable := math.Inf(1)
+
+	for _, offer := range offers {
+		if offer.Available > 0 {
+			minAvailable = math.Min(minAvailable, offer.Available)
+		}
+	}
+
+	if math.IsInf(minAvailable, 1) {
+		minAvailable = 1 // no known amounts, plain mean
+	}
+
+	var sum, weights float64
+
+	for _, offer := range offers {
+		weight := offer.Available
+		if weight <= 0 {
+			weight = minAvailable
+		}
+
+		sum += offer.Price * weight
+		weights += weight
+	}
+
+	return sum / weights
+}
@@ -0,0 +1,203 @@
+package p2p
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sig-0/fxrates/provider/currencies"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+const (
+	// defaultPages is the default number of offer pages fetched per side
+	defaultPages = 3
+
+	// defaultRows is the default number of offers per page
+	defaultRows = 10
+
+	// defaultTopOffers is the default number of best offers the rate is computed from
+	defaultTopOffers = 12
+
+	// defaultNotional is the default amount, in the asset, filled by the depth aggregation
+	defaultNotional = 500
+)
+
+// Config defines the P2P market of a provider, and how its offers are aggregated into rates
+type Config struct {
+	// PayTypes limits the offers to the given payment methods, as identified by the exchange.
+	// Empty considers all methods
+	PayTypes []string
+
+	// URL is the exchange's offer search API URL
+	URL string
+
+	// Name is the provider name
+	Name string
+
+	// Source is the source of the rates
+	Source types.Source
+
+	// Asset is the traded asset (i.e. USDT)
+	Asset types.Currency
+
+	// Fiat is the fiat currency the asset is traded for (i.e. VES)
+	Fiat types.Currency
+
+	// Aggregation is the statistic the rates are computed with
+	Aggregation Aggregation
+
+	// Strict is the offer filter
+	Strict Filter
+
+	// Relaxed is the offer filter used if too few offers pass the strict one
+	Relaxed Filter
+
+	// Notional is the amount, in the asset, filled by the depth aggregation
+	Notional float64
+
+	// Pages is the number of offer pages fetched per side
+	Pages int
+
+	// Rows is the number of offers per page
+	Rows int
+
+	// TopOffers is the number of best offers the rate is computed from,
+	// except for the depth aggregation, which walks all filtered offers
+	TopOffers int
+}
+
+// Option is a single P2P provider option
+type Option func(c *Config)
+
+// WithMarket specifies the traded asset and fiat currency. Defaults to USDT/VES
+func WithMarket(asset, fiat types.Currency) Option {
+	return func(c *Config) {
+		c.Asset = asset
+		c.Fiat = fiat
+	}
+}
+
+// WithPayTypes limits the offers to the given payment methods, as identified by the exchange
+// (i.e. PagoMovil, Banesco on Binance). All methods are considered by default
+func WithPayTypes(payTypes ...string) Option {
+	return func(c *Config) {
+		c.PayTypes = payTypes
+	}
+}
+
+// WithDepth specifies the number of offer pages fetched per side,
+// and the number of offers per page. Defaults to 3 pages of 10 offers
+func WithDepth(pages, rows int) Option {
+	return func(c *Config) {
+		if pages > 0 {
+			c.Pages = pages
+		}
+
+		if rows > 0 {
+			c.Rows = rows
+		}
+	}
+}
+
+// WithFilters specifies the strict offer filter, and the relaxed one used
+// if too few offers pass the strict filter. Defaults to DefaultFilters
+func WithFilters(strict, relaxed Filter) Option {
+	return func(c *Config) {
+		c.Strict = strict
+		c.Relaxed = relaxed
+	}
+}
+
+// WithAggregation specifies the statistic the rates are computed with. Defaults to the median
+func WithAggregation(aggregation Aggregation) Option {
+	return func(c *Config) {
+		c.Aggregation = aggregation
+	}
+}
+
+// WithNotional specifies the amount, in the asset, filled by the depth aggregation. Defaults to 500
+func WithNotional(notional float64) Option {
+	return func(c *Config) {
+		if notional > 0 {
+			c.Notional = notional
+		}
+	}
+}
+
+// WithTopOffers specifies the number of best offers the rate is computed from,
+// except for the depth aggregation, which walks all filtered offers. Defaults to 12
+func WithTopOffers(topOffers int) Option {
+	return func(c *Config) {
+		if topOffers > 0 {
+			c.TopOffers = topOffers
+		}
+	}
+}
+
+// WithSource specifies the source of the rates, i.e. to distinguish instances
+// on the same market with different payment methods. Defaults to the exchange's source
+func WithSource(source types.Source) Option {
+	return func(c *Config) {
+		c.Source = source
+	}
+}
+
+// WithName specifies the provider name. Defaults to a name derived from
+// the exchange, market and payment methods, i.e. "Binance P2P (USDT/ARS)"
+func WithName(name string) Option {
+	return func(c *Config) {
+		c.Name = name
+	}
+}
+
+// WithURL specifies the exchange's offer search API URL
+func WithURL(url string) Option {
+	return func(c *Config) {
+		c.URL = url
+	}
+}
+
+// NewConfig creates a new P2P provider configuration for the exchange,
+// with the given defaults overridden by the options
+func NewConfig(exchange, url string, source types.Source, opts ...Option) *Config {
+	strict, relaxed := DefaultFilters()
+
+	c := &Config{
+		URL:         url,
+		Source:      source,
+		Asset:       currencies.USDT,
+		Fiat:        currencies.VES,
+		Aggregation: Median,
+		Strict:      strict,
+		Relaxed:     relaxed,
+		Notional:    defaultNotional,
+		Pages:       defaultPages,
+		Rows:        defaultRows,
+		TopOffers:   defaultTopOffers,
+	}
+
+	// Apply the options
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.Name == "" {
+		c.Name = c.defaultName(exchange)
+	}
+
+	return c
+}
+
+// defaultName derives the provider name from the exchange, market and payment methods
+func (c *Config) defaultName(exchange string) string {
+	market := c.Asset.String()
+	if c.Fiat != currencies.VES {
+		market += "/" + c.Fiat.String()
+	}
+
+	if len(c.PayTypes) > 0 {
+		market += ": " + strings.Join(c.PayTypes, ", ")
+	}
+
+	return fmt.Sprintf("%s P2P (%s)", exchange, market)
+}
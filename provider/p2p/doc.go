@@ -0,0 +1,17 @@
+// Package p2p provides the shared offer filtering and pricing of peer-to-peer market providers.
+//
+// Providers fetch the offers of an exchange's P2P market one page at a time, and Fetch turns
+// them into BUY and SELL rates:
+//
+//   - Offers are filtered by advertiser activity and completion rate, available amount, and
+//     transaction limits (strict, then relaxed if too few offers pass)
+//   - Advertisers are scored with the Wilson lower bound of their completion rate, to favor
+//     advertisers with both high completion rates and sufficient order volume
+//   - Offers are sorted by price (ascending for BUY, descending for SELL), with the score as tiebreaker
+//   - The best offers are aggregated with the median (default), a trimmed mean, or a mean weighted by
+//     the available amounts. The depth aggregation instead computes the effective price to fill a
+//     notional amount, walking all filtered offers within their available amounts and limits
+//
+// The rates carry the aggregation, the number of fetched and used offers, and the available depth
+// of the filtered offers as metadata.
+package p2p
@@ -0,0 +1,68 @@
+package p2p
+
+// Filter defines the thresholds offers need to meet to be considered for the rate
+type Filter struct {
+	// MinOrders is the minimum number of monthly orders of the advertiser
+	MinOrders int
+
+	// MinFinishRate is the minimum monthly order completion rate [0, 1] of the advertiser
+	MinFinishRate float64
+
+	// MinAvailable is the minimum available amount of the offer, in the asset. 0 disables the check
+	MinAvailable float64
+
+	// TypicalAmount is a typical transaction amount that needs to be within
+	// the offer's transaction limits. 0 disables the check
+	TypicalAmount float64
+}
+
+// DefaultFilters returns the default strict offer filter, and the relaxed one used
+// if too few offers pass the strict filter: 50 (20) monthly orders, a 95% (90%) completion rate,
+// 50 available and a 100 typical transaction amount
+func DefaultFilters() (Filter, Filter) {
+	strict := Filter{
+		MinOrders:     50,
+		MinFinishRate: 0.95,
+		MinAvailable:  50,
+		TypicalAmount: 100,
+	}
+
+	relaxed := strict
+	relaxed.MinOrders = 20
+	relaxed.MinFinishRate = 0.90
+
+	return strict, relaxed
+}
+
+// apply returns the offers meeting the filter thresholds
+func (f Filter) apply(offers []Offer) []Offer {
+	filtered := make([]Offer, 0, len(offers))
+
+	for _, offer := range offers {
+		if offer.Orders < f.MinOrders {
+			continue
+		}
+
+		if offer.FinishRate < f.MinFinishRate {
+			continue
+		}
+
+		if f.MinAvailable > 0 && offer.Available > 0 && offer.Available < f.MinAvailable {
+			continue
+		}
+
+		if f.TypicalAmount > 0 {
+			if offer.MinLimit > 0 && f.TypicalAmount < offer.MinLimit {
+				continue
+			}
+
+			if offer.MaxLimit > 0 && f.TypicalAmount > offer.MaxLimit {
+				continue
+			}
+		}
+
+		filtered = append(filtered, offer)
+	}
+
+	return filtered
+}
@@ -0,0 +1,90 @@
+package p2p
+
+import (
+	"math"
+	"strconv"
+)
+
+// Offer is a single P2P market offer
+type Offer struct {
+	// Price is the offer price, in fiat per asset unit
+	Price float64
+
+	// MinLimit is the minimum transaction amount, in fiat. 0 if unknown
+	MinLimit float64
+
+	// MaxLimit is the maximum transaction amount, in fiat. 0 if unknown
+	MaxLimit float64
+
+	// Available is the available amount of the offer, in the asset. 0 if unknown
+	Available float64
+
+	// Orders is the number of monthly orders of the advertiser
+	Orders int
+
+	// FinishRate is the monthly order completion rate [0, 1] of the advertiser
+	FinishRate float64
+
+	// Quality is the advertiser score, used as the tiebreaker between equal prices
+	Quality float64
+}
+
+// NewOffer creates a new offer. Finish rates given as percentages are normalized, and the
+// advertiser is scored with the Wilson lower bound of its completion rate, to favor advertisers
+// with both high completion rates and sufficient order volume
+func NewOffer(price, minLimit, maxLimit, available float64, orders int, finishRate float64) Offer {
+	finishRate = normalizeFinishRate(finishRate)
+
+	return Offer{
+		Price:      price,
+		MinLimit:   minLimit,
+		MaxLimit:   maxLimit,
+		Available:  available,
+		Orders:     orders,
+		FinishRate: finishRate,
+		Quality:    wilsonLowerBound(finishRate, orders),
+	}
+}
+
+// ParseAmount parses an API amount string
+func ParseAmount(value string) (float64, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return parsed, true
+}
+
+// normalizeFinishRate ensures the finish rate is within [0, 1]
+func normalizeFinishRate(rate float64) float64 {
+	if rate <= 0 {
+		return 0
+	}
+
+	if rate > 1 {
+		return rate / 100
+	}
+
+	return rate
+}
+
+// wilsonLowerBound returns a conservative completion score
+func wilsonLowerBound(rate float64, n int) float64 {
+	if n <= 0 {
+		return 0
+	}
+
+	var (
+		z           = 1.96
+		denominator = 1 + z*z/float64(n)
+		center      = rate + z*z/(2*float64(n))
+		adjust      = z * math.Sqrt((rate*(1-rate)+z*z/(4*float64(n)))/float64(n))
+	)
+
+	return (center - adjust) / denominator
+}
@@ -0,0 +1,179 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+// The metadata keys of the P2P rates
+const (
+	// MetadataAggregation is the aggregation the rate is computed with
+	MetadataAggregation = "aggregation"
+
+	// MetadataOffers is the number of offers the rate is computed from
+	MetadataOffers = "offers"
+
+	// MetadataOffersFetched is the number of fetched offers, before filtering
+	MetadataOffersFetched = "offers_fetched"
+
+	// MetadataDepth is the total available amount of the filtered offers, in the asset
+	MetadataDepth = "depth"
+
+	// MetadataNotional is the amount filled by the depth aggregation, in the asset
+	MetadataNotional = "notional"
+)
+
+// PageFetcher fetches a single page (starting from 1) of the offers on the given side of the market.
+// BUY offers are the ones the asset is bought from. An empty page ends the offer collection
+type PageFetcher func(ctx context.Context, side types.RateType, page int) ([]Offer, error)
+
+// Fetch fetches the offers of both sides of the market, and aggregates them into the BUY and SELL rates
+func Fetch(ctx context.Context, c *Config, fetchPage PageFetcher) ([]*types.ExchangeRate, error) {
+	var (
+		fetchTime = time.Now().UTC()
+		rates     = make([]*types.ExchangeRate, 0, 2)
+	)
+
+	for _, side := range []types.RateType{types.RateTypeBUY, types.RateTypeSELL} {
+		offers, err := fetchOffers(ctx, c, side, fetchPage)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch %s price: %w", side, err)
+		}
+
+		price, metadata, err := c.Quote(offers, side)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch %s price: %w", side, err)
+		}
+
+		rates = append(rates, &types.ExchangeRate{
+			AsOf:      fetchTime,
+			FetchedAt: fetchTime,
+			Base:      c.Asset,
+			Target:    c.Fiat,
+			RateType:  side,
+			Source:    c.Source,
+			Rate:      price.Float64(),
+			RateExact: price,
+			Metadata:  metadata,
+		})
+	}
+
+	return rates, nil
+}
+
+// fetchOffers fetches the configured number of offer pages
+func fetchOffers(
+	ctx context.Context,
+	c *Config,
+	side types.RateType,
+	fetchPage PageFetcher,
+) ([]Offer, error) {
+	offers := make([]Offer, 0, c.Pages*c.Rows)
+
+	for page := 1; page <= c.Pages; page++ {
+		pageOffers, err := fetchPage(ctx, side, page)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(pageOffers) == 0 {
+			break
+		}
+
+		offers = append(offers, pageOffers...)
+	}
+
+	if len(offers) == 0 {
+		return nil, fmt.Errorf("no valid offers found for %s", side)
+	}
+
+	return offers, nil
+}
+
+// Quote filters the offers, and aggregates the best ones into the price.
+// The order book depth and offer counts are returned as the rate metadata
+func (c *Config) Quote(offers []Offer, side types.RateType) (types.Decimal, map[string]string, error) {
+	// Filter out the very best for the aggregation
+	filtered := c.Strict.apply(offers)
+
+	if len(filtered) < c.TopOffers {
+		// Filter with relaxed criteria
+		if relaxed := c.Relaxed.apply(offers); len(relaxed) > len(filtered) {
+			filtered = relaxed
+		}
+	}
+
+	if len(filtered) == 0 {
+		// Fallback, use all offers as none match criteria
+		filtered = append([]Offer(nil), offers...)
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		if filtered[i].Price != filtered[j].Price {
+			if side == types.RateTypeBUY {
+				return filtered[i].Price < filtered[j].Price
+			}
+
+			return filtered[i].Price > filtered[j].Price
+		}
+
+		return filtered[i].Quality > filtered[j].Quality
+	})
+
+	metadata := map[string]string{
+		MetadataAggregation:   string(c.Aggregation),
+		MetadataOffersFetched: strconv.Itoa(len(offers)),
+		MetadataDepth:         formatAmount(totalAvailable(filtered)),
+	}
+
+	var (
+		price float64
+		used  int
+		err   error
+	)
+
+	if c.Aggregation == Depth {
+		price, used, err = fillPrice(filtered, c.Notional)
+		if err != nil {
+			return types.Decimal{}, nil, fmt.Errorf("unable to fill %s %s: %w", formatAmount(c.Notional), c.Asset, err)
+		}
+
+		metadata[MetadataNotional] = formatAmount(c.Notional)
+	} else {
+		if len(filtered) > c.TopOffers {
+			filtered = filtered[:c.TopOffers]
+		}
+
+		if price, err = aggregate(filtered, c.Aggregation); err != nil {
+			return types.Decimal{}, nil, err
+		}
+
+		used = len(filtered)
+	}
+
+	metadata[MetadataOffers] = strconv.Itoa(used)
+
+	return types.NewDecimalFromFloat(price).Round(4), metadata, nil
+}
+
+// totalAvailable sums the known available amounts of the offers
+func totalAvailable(offers []Offer) float64 {
+	total := 0.0
+
+	for _, offer := range offers {
+		total += math.Max(offer.Available, 0)
+	}
+
+	return total
+}
+
+// formatAmount formats the amount for the rate metadata, with at most 2 decimals
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(math.Round(amount*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,261 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/provider/currencies"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+func TestNewConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConfig("Bybit", "https://bybit.test", "BybitP2P")
+
+		assert.Equal(t, "Bybit P2P (USDT)", c.Name)
+		assert.Equal(t, types.Source("BybitP2P"), c.Source)
+		assert.Equal(t, currencies.USDT, c.Asset)
+		assert.Equal(t, currencies.VES, c.Fiat)
+		assert.Equal(t, Median, c.Aggregation)
+		assert.Equal(t, 3, c.Pages)
+		assert.Equal(t, 10, c.Rows)
+	})
+
+	t.Run("derived name", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConfig(
+			"OKX",
+			"https://okx.test",
+			"OKXP2P",
+			WithMarket(currencies.USDT, "COP"),
+			WithPayTypes("Nequi", "Bancolombia"),
+			WithDepth(0, 20), // unset pages are kept
+		)
+
+		assert.Equal(t, "OKX P2P (USDT/COP: Nequi, Bancolombia)", c.Name)
+		assert.Equal(t, 3, c.Pages)
+		assert.Equal(t, 20, c.Rows)
+	})
+}
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pages collected until empty", func(t *testing.T) {
+		t.Parallel()
+
+		var requested []int
+
+		c := NewConfig("Test", "", "TestP2P", WithDepth(5, 1))
+
+		rates, err := Fetch(context.Background(), c, func(_ context.Context, side types.RateType, page int) ([]Offer, error) {
+			if side == types.RateTypeBUY {
+				requested = append(requested, page)
+			}
+
+			if page > 2 {
+				return nil, nil
+			}
+
+			return []Offer{NewOffer(500+float64(page), 0, 0, 100, 100, 99)}, nil
+		})
+		require.NoError(t, err)
+		require.Len(t, rates, 2)
+
+		assert.Equal(t, []int{1, 2, 3}, requested)
+
+		assert.Equal(t, types.RateTypeBUY, rates[0].RateType)
+		assert.Equal(t, "501.5", rates[0].RateExact.String())
+		assert.Equal(t, "2", rates[0].Metadata[MetadataOffersFetched])
+
+		assert.Equal(t, types.RateTypeSELL, rates[1].RateType)
+		assert.Equal(t, types.Source("TestP2P"), rates[1].Source)
+	})
+
+	t.Run("no offers", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConfig("Test", "", "TestP2P")
+
+		_, err := Fetch(context.Background(), c, func(context.Context, types.RateType, int) ([]Offer, error) {
+			return nil, nil
+		})
+		assert.ErrorContains(t, err, "no valid offers found")
+	})
+
+	t.Run("page error", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			c       = NewConfig("Test", "", "TestP2P")
+			pageErr = errors.New("rate limited")
+		)
+
+		_, err := Fetch(context.Background(), c, func(context.Context, types.RateType, int) ([]Offer, error) {
+			return nil, pageErr
+		})
+		assert.ErrorIs(t, err, pageErr)
+	})
+}
+
+func TestConfig_Quote(t *testing.T) {
+	t.Parallel()
+
+	offers := []Offer{
+		NewOffer(510, 0, 0, 1000, 300, 0.99),
+		NewOffer(500, 0, 0, 100, 200, 0.98),
+		NewOffer(520, 0, 0, 400, 100, 0.97),
+		NewOffer(450, 0, 0, 5000, 5, 0.50), // filtered out
+	}
+
+	t.Run("median", func(t *testing.T) {
+		t.Parallel()
+
+		price, metadata, err := NewConfig("Test", "", "TestP2P").Quote(offers, types.RateTypeBUY)
+		require.NoError(t, err)
+
+		assert.Equal(t, "510", price.String())
+		assert.Equal(t, map[string]string{
+			MetadataAggregation:   "median",
+			MetadataOffers:        "3",
+			MetadataOffersFetched: "4",
+			MetadataDepth:         "1500",
+		}, metadata)
+	})
+
+	t.Run("top offers", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConfig("Test", "", "TestP2P", WithTopOffers(2))
+
+		// The best SELL offers are the highest ones
+		price, _, err := c.Quote(offers, types.RateTypeSELL)
+		require.NoError(t, err)
+
+		assert.Equal(t, "515", price.String())
+	})
+
+	t.Run("no offers pass the filters", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConfig("Test", "", "TestP2P")
+
+		// All offers are used as a fallback
+		price, _, err := c.Quote(offers[3:], types.RateTypeBUY)
+		require.NoError(t, err)
+
+		assert.Equal(t, "450", price.String())
+	})
+
+	t.Run("depth", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewConfig("Test", "", "TestP2P", WithAggregation(Depth), WithNotional(800))
+
+		price, metadata, err := c.Quote(offers, types.RateTypeBUY)
+		require.NoError(t, err)
+
+		// (100*500 + 700*510) / 800
+		assert.Equal(t, "508.75", price.String())
+		assert.Equal(t, "2", metadata[MetadataOffers])
+		assert.Equal(t, "800", metadata[MetadataNotional])
+
+		_, _, err = NewConfig("Test", "", "TestP2P", WithAggregation(Depth), WithNotional(5000)).
+			Quote(offers, types.RateTypeBUY)
+		assert.ErrorIs(t, err, ErrInsufficientDepth)
+	})
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	offers := []Offer{
+		{Price: 500, Orders: 100, FinishRate: 0.99, MinLimit: 20, MaxLimit: 1_000},
+		{Price: 500, Orders: 100, FinishRate: 0.99, MinLimit: 120}, // above the typical amount
+		{Price: 500, Orders: 100, FinishRate: 0.99, MaxLimit: 80},  // below the typical amount
+		{Price: 500, Orders: 30, FinishRate: 0.92},                 // relaxed only
+		{Price: 500, Orders: 100, FinishRate: 0.99, Available: 10}, // not enough available
+	}
+
+	strict, relaxed := DefaultFilters()
+
+	// The typical amount is compared to the limits as-is
+	assert.Len(t, strict.apply(offers), 1)
+	assert.Len(t, relaxed.apply(offers), 2)
+}
+
+func TestAggregate(t *testing.T) {
+	t.Parallel()
+
+	offers := []Offer{
+		{Price: 100, Available: 10},
+		{Price: 102, Available: 30},
+		{Price: 104, Available: 10},
+		{Price: 106, Available: 10},
+		{Price: 200}, // outlier, without a known available amount
+	}
+
+	price, err := aggregate(offers, Median)
+	require.NoError(t, err)
+	assert.Equal(t, 104.0, price)
+
+	price, err = aggregate(offers, TrimmedMean)
+	require.NoError(t, err)
+	assert.Equal(t, 104.0, price)
+
+	price, err = aggregate(offers, VolumeWeighted)
+	require.NoError(t, err)
+	assert.InDelta(t, 116.571, price, 0.001) // 8160 / 70
+
+	_, err = aggregate(offers, "mode")
+	assert.ErrorIs(t, err, errUnknownAggregation)
+
+	_, err = ParseAggregation("mode")
+	assert.ErrorIs(t, err, errUnknownAggregation)
+}
+
+func TestFillPrice(t *testing.T) {
+	t.Parallel()
+
+	offers := []Offer{
+		{Price: 500, Available: 1000, MinLimit: 400_000}, // minimum above the notional
+		{Price: 505, Available: 1000, MaxLimit: 50_500},  // up to 100 per transaction
+		{Price: 510}, // unknown depth, fills the rest
+		{Price: 600, Available: 1000, MinLimit: 100_000},
+	}
+
+	price, used, err := fillPrice(offers, 500)
+	require.NoError(t, err)
+
+	// (100*505 + 400*510) / 500
+	assert.InDelta(t, 509.0, price, 1e-9)
+	assert.Equal(t, 2, used)
+
+	_, _, err = fillPrice(offers[:2], 500)
+	assert.ErrorIs(t, err, ErrInsufficientDepth)
+}
+
+func TestNewOffer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		percent  = NewOffer(500, 0, 0, 0, 100, 98)
+		fraction = NewOffer(500, 0, 0, 0, 100, 0.98)
+		newcomer = NewOffer(500, 0, 0, 0, 2, 1)
+	)
+
+	// Percentages are normalized
+	assert.Equal(t, fraction, percent)
+
+	// A perfect rate over few orders scores lower than a high rate over many
+	assert.Less(t, newcomer.Quality, fraction.Quality)
+}
@@ -0,0 +1,24 @@
+// Package scrape provides a generic HTML scraping provider, defined by configuration
+// instead of code, for simple sources publishing their rates in a page.
+//
+// # Providers
+//
+// ## Scrape (Configured Sources)
+//
+// Source: configured
+// URL: configured
+// Interval: configured (1 hour by default)
+//
+// Fetches the page, and parses one rate per row matched by the rows CSS selector.
+// The rate, currency and date selectors are relative to the row, falling back to
+// the whole page for values published once (i.e. the publication date). Currencies
+// without a selector are fixed by the configuration.
+//
+// Rate numbers are parsed with dot (1,234.56) or comma (1.234,56) decimals,
+// ignoring the surrounding currency symbols and codes (i.e. "Bs. 36,50"). Rows that don't parse
+// (i.e. headers, or unavailable and negative rates) are skipped.
+//
+// The effective date (AsOf) is parsed with the configured Go layout and time zone.
+// Dates without a time of day are effective from their midnight in that time zone, and rates
+// without a date selector from their fetch time.
+package scrape
@@ -0,0 +1,320 @@
+package scrape
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+// DefaultInterval is the fetch interval of sources without one
+const DefaultInterval = time.Hour
+
+var (
+	errNoRates         = errors.New("no rates found")
+	errInvalidRate     = errors.New("invalid rate")
+	errInvalidCurrency = errors.New("invalid currency")
+	errMissingValue    = errors.New("missing value")
+)
+
+var currencyRegex = regexp.MustCompile(`^[A-Z]{3,4}$`)
+
+// Locale is the number format of the scraped rates
+type Locale string
+
+const (
+	// LocaleDot uses dot decimals and comma thousands (i.e. 1,234.56)
+	LocaleDot Locale = "dot"
+
+	// LocaleComma uses comma decimals and dot thousands (i.e. 1.234,56)
+	LocaleComma Locale = "comma"
+)
+
+// Selectors are the CSS selectors of the scraped values.
+// Except for the rows, they are relative to a row, falling back to the whole page
+// for values published once (i.e. the publication date)
+type Selectors struct {
+	// The rate rows. Each row yields a single rate
+	Rows string
+
+	// The rate number
+	Rate string
+
+	// The base currency code. Empty uses the fixed base
+	Base string
+
+	// The target currency code. Empty uses the fixed target
+	Target string
+
+	// The effective date. Empty uses the fetch time
+	Date string
+}
+
+// Config is the scraped source definition
+type Config struct {
+	Selectors Selectors
+
+	// The time zone of the parsed dates. Nil is UTC
+	Location *time.Location
+
+	URL    string
+	Name   string // the source name if empty
+	Source types.Source
+
+	// The fixed currencies, used when the currency selectors are empty
+	Base   types.Currency
+	Target types.Currency
+
+	RateType types.RateType
+	Locale   Locale // dot if empty
+
+	// The Go time layout of the dates (i.e. 02/01/2006)
+	DateFormat string
+
+	Interval time.Duration // DefaultInterval if unset
+}
+
+// Provider is a generic HTML scraping provider, defined by its configuration
+type Provider struct {
+	client *http.Client
+	config *Config
+}
+
+// NewProvider creates a new instance of the scraping provider for the given source
+func NewProvider(config *Config, timeout time.Duration) *Provider {
+	return &Provider{
+		client: &http.Client{
+			Timeout: timeout,
+		},
+		config: config,
+	}
+}
+
+func (p *Provider) Name() string {
+	if p.config.Name != "" {
+		return p.config.Name
+	}
+
+	return p.config.Source.String()
+}
+
+func (p *Provider) Interval() time.Duration {
+	if p.config.Interval > 0 {
+		return p.config.Interval
+	}
+
+	return DefaultInterval
+}
+
+func (p *Provider) Fetch(ctx context.Context) ([]*types.ExchangeRate, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.config.URL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create GET request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute GET request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("invalid status code received: %d", resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse html: %w", err)
+	}
+
+	var (
+		fetchTime = time.Now().UTC()
+		out       = make([]*types.ExchangeRate, 0)
+	)
+
+	// Rows that don't parse (i.e. headers, or empty cells) are skipped
+	doc.Find(p.config.Selectors.Rows).Each(func(_ int, row *goquery.Selection) {
+		rate, err := p.parseRow(doc, row, fetchTime)
+		if err != nil {
+			return
+		}
+
+		out = append(out, rate)
+	})
+
+	if len(out) == 0 {
+		return nil, errNoRates
+	}
+
+	return out, nil
+}
+
+// parseRow parses a single rate row
+func (p *Provider) parseRow(
+	doc *goquery.Document,
+	row *goquery.Selection,
+	fetchTime time.Time,
+) (*types.ExchangeRate, error) {
+	sel := p.config.Selectors
+
+	rate, err := parseNumber(strings.TrimSpace(row.Find(sel.Rate).First().Text()), p.config.Locale)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := p.currency(doc, row, sel.Base, p.config.Base)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := p.currency(doc, row, sel.Target, p.config.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	asOf := fetchTime
+
+	if sel.Date != "" {
+		asOf, err = p.parseDate(find(doc, row, sel.Date))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &types.ExchangeRate{
+		AsOf:      asOf,
+		FetchedAt: fetchTime,
+		Base:      base,
+		Target:    target,
+		RateType:  p.config.RateType,
+		Source:    p.config.Source,
+		Rate:      rate.Float64(),
+		RateExact: rate,
+	}, nil
+}
+
+// currency returns the scraped currency, or the fixed one if there is no selector
+func (p *Provider) currency(
+	doc *goquery.Document,
+	row *goquery.Selection,
+	selector string,
+	fixed types.Currency,
+) (types.Currency, error) {
+	if selector == "" {
+		return fixed, nil
+	}
+
+	code := strings.ToUpper(find(doc, row, selector))
+	if !currencyRegex.MatchString(code) {
+		return "", fmt.Errorf("%w: %q", errInvalidCurrency, code)
+	}
+
+	return types.Currency(code), nil
+}
+
+// parseDate parses the effective date in the configured time zone.
+// Dates without a time of day are effective from their local midnight
+func (p *Provider) parseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("%w: date", errMissingValue)
+	}
+
+	loc := p.config.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	t, err := time.ParseInLocation(p.config.DateFormat, value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to parse date %q: %w", value, err)
+	}
+
+	return t.UTC(), nil
+}
+
+// find returns the trimmed text of the selected value in the row,
+// falling back to the whole page
+func find(doc *goquery.Document, row *goquery.Selection, selector string) string {
+	sel := row.Find(selector).First()
+	if sel.Length() == 0 {
+		sel = doc.Find(selector).First()
+	}
+
+	return strings.TrimSpace(sel.Text())
+}
+
+// isAffix returns true if the rune can surround a rate number,
+// as part of a currency symbol or code
+func isAffix(r rune) bool {
+	return unicode.IsLetter(r) || unicode.Is(unicode.Sc, r) || unicode.IsSpace(r)
+}
+
+// trimAffixes trims the currency symbols and codes surrounding the number,
+// along with the dot of an abbreviation (i.e. "Bs."). Other dots are kept,
+// so ".5" and "USD .9123" keep their leading decimal point
+func trimAffixes(s string) string {
+	runes := []rune(s)
+
+	// abbreviation returns true if the rune at i is a dot right after a letter
+	abbreviation := func(i int) bool {
+		return runes[i] == '.' && i > 0 && unicode.IsLetter(runes[i-1])
+	}
+
+	start := 0
+	for start < len(runes) && (isAffix(runes[start]) || abbreviation(start)) {
+		start++
+	}
+
+	end := len(runes)
+	for end > start && (isAffix(runes[end-1]) || abbreviation(end-1)) {
+		end--
+	}
+
+	return string(runes[start:end])
+}
+
+// parseNumber parses the rate number in the given locale, ignoring
+// the surrounding currency symbols and codes (i.e. "Bs. 36,50").
+// The sign is kept, so negative numbers are rejected as invalid rates
+func parseNumber(s string, locale Locale) (types.Decimal, error) {
+	s = trimAffixes(s)
+
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+
+		return r
+	}, s)
+
+	if !strings.ContainsFunc(s, unicode.IsDigit) {
+		return types.Decimal{}, errInvalidRate
+	}
+
+	switch locale {
+	case LocaleComma:
+		s = strings.ReplaceAll(s, ".", "")
+		s = strings.ReplaceAll(s, ",", ".")
+	default:
+		s = strings.ReplaceAll(s, ",", "")
+	}
+
+	d, err := types.ParseDecimal(s)
+	if err != nil {
+		return types.Decimal{}, fmt.Errorf("unable to parse rate %q: %w", s, err)
+	}
+
+	if d.Sign() <= 0 {
+		return types.Decimal{}, fmt.Errorf("%w: %s", errInvalidRate, d)
+	}
+
+	return d, nil
+}
@@ -0,0 +1,272 @@
+package scrape
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/provider/currencies"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+// newFixtureServer creates a new test server serving the testdata files
+func newFixtureServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := os.ReadFile(filepath.Join("testdata", filepath.Base(r.URL.Path)))
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(data)
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestProvider_Fetch(t *testing.T) {
+	t.Parallel()
+
+	srv := newFixtureServer(t)
+
+	t.Run("comma decimals with a page date", func(t *testing.T) {
+		t.Parallel()
+
+		caracas := time.FixedZone("VET", -4*60*60)
+
+		p := NewProvider(&Config{
+			Selectors: Selectors{
+				Rows: "table.tabla-tasas tbody tr",
+				Rate: "td.compra",
+				Base: "td.moneda",
+				Date: "p.fecha span",
+			},
+			Location:   caracas,
+			URL:        srv.URL + "/bank.html",
+			Source:     "Banco Ejemplo",
+			Target:     currencies.VES,
+			RateType:   types.RateTypeBUY,
+			Locale:     LocaleComma,
+			DateFormat: "02/01/2006",
+		}, time.Second*5)
+
+		assert.Equal(t, "Banco Ejemplo", p.Name())
+		assert.Equal(t, DefaultInterval, p.Interval())
+
+		rates, err := p.Fetch(context.Background())
+		require.NoError(t, err)
+
+		// The unavailable CNY rate is skipped
+		require.Len(t, rates, 2)
+
+		// The page date is effective from the Caracas midnight
+		asOf := time.Date(2026, time.October, 14, 4, 0, 0, 0, time.UTC)
+
+		assert.Equal(t, currencies.USD, rates[0].Base)
+		assert.Equal(t, currencies.VES, rates[0].Target)
+		assert.Equal(t, types.RateTypeBUY, rates[0].RateType)
+		assert.Equal(t, types.Source("Banco Ejemplo"), rates[0].Source)
+		assert.Equal(t, "36.5", rates[0].RateExact.String())
+		assert.Equal(t, asOf, rates[0].AsOf)
+
+		assert.Equal(t, currencies.EUR, rates[1].Base)
+		assert.Equal(t, "1234.56", rates[1].RateExact.String())
+		assert.Equal(t, asOf, rates[1].AsOf)
+	})
+
+	t.Run("dot decimals with row times", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			bogota = time.FixedZone("COT", -5*60*60)
+			cop    = types.Currency("COP")
+		)
+
+		p := NewProvider(&Config{
+			Selectors: Selectors{
+				Rows: "ul.quotes li.quote",
+				Rate: ".price",
+				Base: ".pair",
+				Date: "time",
+			},
+			Location:   bogota,
+			URL:        srv.URL + "/exchange.html",
+			Name:       "Casa de Cambio",
+			Source:     "CasaDeCambio",
+			Target:     cop,
+			RateType:   types.RateTypeMID,
+			DateFormat: "2006-01-02 15:04",
+			Interval:   time.Minute * 30,
+		}, time.Second*5)
+
+		assert.Equal(t, "Casa de Cambio", p.Name())
+		assert.Equal(t, time.Minute*30, p.Interval())
+
+		rates, err := p.Fetch(context.Background())
+		require.NoError(t, err)
+
+		// The row without a currency code is skipped
+		require.Len(t, rates, 2)
+
+		assert.Equal(t, currencies.USD, rates[0].Base)
+		assert.Equal(t, cop, rates[0].Target)
+		assert.Equal(t, "4125.5", rates[0].RateExact.String())
+		assert.Equal(t, time.Date(2026, time.October, 14, 20, 30, 0, 0, time.UTC), rates[0].AsOf)
+
+		assert.Equal(t, currencies.EUR, rates[1].Base)
+		assert.Equal(t, "4790.25", rates[1].RateExact.String())
+	})
+
+	t.Run("no date selector", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewProvider(&Config{
+			Selectors: Selectors{
+				Rows: "table.tabla-tasas tbody tr",
+				Rate: "td.venta",
+			},
+			URL:      srv.URL + "/bank.html",
+			Source:   "Banco Ejemplo",
+			Base:     currencies.USD,
+			Target:   currencies.VES,
+			RateType: types.RateTypeSELL,
+			Locale:   LocaleComma,
+		}, time.Second*5)
+
+		rates, err := p.Fetch(context.Background())
+		require.NoError(t, err)
+		require.Len(t, rates, 2)
+
+		assert.Equal(t, currencies.USD, rates[0].Base)
+		assert.Equal(t, "36.9", rates[0].RateExact.String())
+		assert.Equal(t, rates[0].FetchedAt, rates[0].AsOf)
+	})
+
+	t.Run("no rows matched", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewProvider(&Config{
+			Selectors: Selectors{
+				Rows: "table.missing tr",
+				Rate: "td",
+			},
+			URL:    srv.URL + "/bank.html",
+			Source: "Banco Ejemplo",
+		}, time.Second*5)
+
+		_, err := p.Fetch(context.Background())
+		assert.ErrorIs(t, err, errNoRates)
+	})
+
+	t.Run("invalid status code", func(t *testing.T) {
+		t.Parallel()
+
+		p := NewProvider(&Config{
+			URL:    srv.URL + "/missing.html",
+			Source: "Banco Ejemplo",
+		}, time.Second*5)
+
+		_, err := p.Fetch(context.Background())
+		assert.ErrorContains(t, err, "invalid status code")
+	})
+}
+
+func TestParseNumber(t *testing.T) {
+	t.Parallel()
+
+	t.Run("comma decimals", func(t *testing.T) {
+		t.Parallel()
+
+		d, err := parseNumber("Bs. 1.234.567,891", LocaleComma)
+		require.NoError(t, err)
+
+		assert.Equal(t, "1234567.891", d.String())
+	})
+
+	t.Run("dot decimals", func(t *testing.T) {
+		t.Parallel()
+
+		d, err := parseNumber("1 234,567.5 COP", LocaleDot)
+		require.NoError(t, err)
+
+		assert.Equal(t, "1234567.5", d.String())
+	})
+
+	t.Run("invalid numbers", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parseNumber("N/D", LocaleDot)
+		assert.ErrorIs(t, err, errInvalidRate)
+
+		_, err = parseNumber("0,00", LocaleComma)
+		assert.ErrorIs(t, err, errInvalidRate)
+
+		_, err = parseNumber("1.2.3", LocaleDot)
+		assert.Error(t, err)
+	})
+
+	t.Run("negative numbers", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parseNumber("-1.5", LocaleDot)
+		assert.ErrorIs(t, err, errInvalidRate)
+
+		_, err = parseNumber("Bs. -36,50", LocaleComma)
+		assert.ErrorIs(t, err, errInvalidRate)
+	})
+
+	t.Run("currency symbols", func(t *testing.T) {
+		t.Parallel()
+
+		d, err := parseNumber("$ 4.125,50 COP", LocaleComma)
+		require.NoError(t, err)
+
+		assert.Equal(t, "4125.5", d.String())
+
+		d, err = parseNumber("€1,234.5", LocaleDot)
+		require.NoError(t, err)
+
+		assert.Equal(t, "1234.5", d.String())
+	})
+
+	t.Run("leading decimal point", func(t *testing.T) {
+		t.Parallel()
+
+		d, err := parseNumber(".5", LocaleDot)
+		require.NoError(t, err)
+
+		assert.Equal(t, "0.5", d.String())
+
+		d, err = parseNumber("USD .9123", LocaleDot)
+		require.NoError(t, err)
+
+		assert.Equal(t, "0.9123", d.String())
+	})
+
+	t.Run("abbreviations", func(t *testing.T) {
+		t.Parallel()
+
+		d, err := parseNumber("Bs.36,50", LocaleComma)
+		require.NoError(t, err)
+
+		assert.Equal(t, "36.5", d.String())
+
+		d, err = parseNumber("36,50 Bs.", LocaleComma)
+		require.NoError(t, err)
+
+		assert.Equal(t, "36.5", d.String())
+	})
+}
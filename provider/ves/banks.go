@@ -11,18 +11,26 @@ import (
 
 	"github.com/PuerkitoBio/goquery"
 
+	"github.com/sig-0/fxrates/ingest/schedule"
 	"github.com/sig-0/fxrates/provider/currencies"
 	"github.com/sig-0/fxrates/storage/types"
 )
 
+// bcvBanksCron checks the BCV bank rates once per business day (Caracas time),
+// after the banks have reported the day's rates
+const bcvBanksCron = "30 18 * * *"
+
 // BCVBanksProvider is the BCV website banks scraping provider
 type BCVBanksProvider struct {
-	client *http.Client
-	url    string
+	client   *http.Client
+	schedule schedule.Schedule
+	url      string
 }
 
 // NewBCVBanksProvider creates a new instance of the BCV website banks provider
 func NewBCVBanksProvider(url string, timeout time.Duration) *BCVBanksProvider {
+	loc := caracasLocation()
+
 	tr := http.DefaultTransport.(*http.Transport).Clone()
 	tr.TLSClientConfig = &tls.Config{
 		InsecureSkipVerify: true, //nolint:gosec // Fine to ignore
@@ -33,6 +41,10 @@ func NewBCVBanksProvider(url string, timeout time.Duration) *BCVBanksProvider {
 			Timeout:   timeout,
 			Transport: tr,
 		},
+		schedule: schedule.NewBusinessDays(
+			schedule.MustParseCron(bcvBanksCron, loc),
+			loc,
+		),
 		url: url,
 	}
 }
@@ -45,6 +57,10 @@ func (p *BCVBanksProvider) Interval() time.Duration {
 	return time.Hour * 24 // the rates are updated daily
 }
 
+func (p *BCVBanksProvider) NextRun(after time.Time) time.Time {
+	return p.schedule.Next(after)
+}
+
 func (p *BCVBanksProvider) Fetch(ctx context.Context) ([]*types.ExchangeRate, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, http.NoBody)
 	if err != nil {
@@ -69,8 +85,8 @@ func (p *BCVBanksProvider) Fetch(ctx context.Context) ([]*types.ExchangeRate, er
 	type row struct {
 		asOfUTC time.Time // UTC midnight of the effective date
 		bank    string
-		buy     float64
-		sell    float64
+		buy     types.Decimal
+		sell    types.Decimal
 	}
 
 	var (
@@ -181,7 +197,8 @@ func (p *BCVBanksProvider) Fetch(ctx context.Context) ([]*types.ExchangeRate, er
 				Target:    currencies.VES,
 				RateType:  types.RateTypeBUY,
 				Source:    src,
-				Rate:      r.buy,
+				Rate:      r.buy.Float64(),
+				RateExact: r.buy,
 			},
 			&types.ExchangeRate{
 				AsOf:      r.asOfUTC,
@@ -190,7 +207,8 @@ func (p *BCVBanksProvider) Fetch(ctx context.Context) ([]*types.ExchangeRate, er
 				Target:    currencies.VES,
 				RateType:  types.RateTypeSELL,
 				Source:    src,
-				Rate:      r.sell,
+				Rate:      r.sell.Float64(),
+				RateExact: r.sell,
 			},
 		)
 	}
@@ -5,7 +5,6 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
-	"math"
 	"net/http"
 	"strconv"
 	"strings"
@@ -13,6 +12,7 @@ import (
 
 	"github.com/PuerkitoBio/goquery"
 
+	"github.com/sig-0/fxrates/ingest/schedule"
 	"github.com/sig-0/fxrates/provider/currencies"
 	"github.com/sig-0/fxrates/storage/types"
 )
@@ -21,14 +21,21 @@ var errInvalidRate = errors.New("invalid rate")
 
 var BCVSource types.Source = "BCV"
 
+// bcvCron checks the BCV rate on business day mornings (Caracas time), in case the service was reset,
+// and hourly in the afternoon, when the next business day's rate is usually published
+const bcvCron = "0 9,15-19 * * *"
+
 // BCVProvider is the BCV website scraping provider
 type BCVProvider struct {
-	client *http.Client
-	url    string
+	client   *http.Client
+	schedule schedule.Schedule
+	url      string
 }
 
 // NewBCVProvider creates a new instance of the BCV website provider
 func NewBCVProvider(url string, timeout time.Duration) *BCVProvider {
+	loc := caracasLocation()
+
 	tr := http.DefaultTransport.(*http.Transport).Clone()
 	tr.TLSClientConfig = &tls.Config{
 		InsecureSkipVerify: true, //nolint:gosec // Fine to ignore
@@ -39,6 +46,10 @@ func NewBCVProvider(url string, timeout time.Duration) *BCVProvider {
 			Timeout:   timeout,
 			Transport: tr,
 		},
+		schedule: schedule.NewBusinessDays(
+			schedule.MustParseCron(bcvCron, loc),
+			loc,
+		),
 		url: url,
 	}
 }
@@ -53,6 +64,10 @@ func (p *BCVProvider) Interval() time.Duration {
 	return time.Hour * 3
 }
 
+func (p *BCVProvider) NextRun(after time.Time) time.Time {
+	return p.schedule.Next(after)
+}
+
 func (p *BCVProvider) Fetch(ctx context.Context) ([]*types.ExchangeRate, error) {
 	// Prepare the request
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, http.NoBody)
@@ -77,11 +92,11 @@ func (p *BCVProvider) Fetch(ctx context.Context) ([]*types.ExchangeRate, error)
 		return nil, fmt.Errorf("unable to construct query doc: %w", err)
 	}
 
-	fetchCurrencyRate := func(currencyID string) (float64, error) {
+	fetchCurrencyRate := func(currencyID string) (types.Decimal, error) {
 		sel := doc.Find("#" + currencyID)
 
 		if sel.Length() == 0 {
-			return 0, fmt.Errorf("missing element #%s", currencyID)
+			return types.Decimal{}, fmt.Errorf("missing element #%s", currencyID)
 		}
 
 		txt := sel.Find(".col-sm-6.col-xs-6.centrado").First().Text()
@@ -93,10 +108,10 @@ func (p *BCVProvider) Fetch(ctx context.Context) ([]*types.ExchangeRate, error)
 
 		v, err := parseBCVNumber(txt)
 		if err != nil {
-			return 0, fmt.Errorf("unable to parse rate value for %s: %w", currencyID, err)
+			return types.Decimal{}, fmt.Errorf("unable to parse rate value for %s: %w", currencyID, err)
 		}
 
-		return math.Round(v*1e4) / 1e4, nil
+		return v, nil
 	}
 
 	var (
@@ -134,7 +149,8 @@ func (p *BCVProvider) Fetch(ctx context.Context) ([]*types.ExchangeRate, error)
 			Target:    currencies.VES,
 			RateType:  types.RateTypeMID,
 			Source:    BCVSource,
-			Rate:      rate,
+			Rate:      rate.Float64(),
+			RateExact: rate,
 		}
 
 		exchangeRates = append(exchangeRates, exchangeRate)
@@ -144,10 +160,10 @@ func (p *BCVProvider) Fetch(ctx context.Context) ([]*types.ExchangeRate, error)
 }
 
 // parseBCVNumber parses the rate number from the BCV website
-func parseBCVNumber(s string) (float64, error) {
+func parseBCVNumber(s string) (types.Decimal, error) {
 	s = strings.TrimSpace(s)
 	if s == "" {
-		return 0, errInvalidRate
+		return types.Decimal{}, errInvalidRate
 	}
 
 	// BCV typically uses comma as decimal separator and no thousands:
@@ -155,12 +171,12 @@ func parseBCVNumber(s string) (float64, error) {
 	s = strings.ReplaceAll(s, ".", "")
 	s = strings.ReplaceAll(s, ",", ".")
 
-	f, err := strconv.ParseFloat(s, 64)
+	d, err := types.ParseDecimal(s)
 	if err != nil {
-		return 0, fmt.Errorf("unable to parse rate %q: %w", s, err)
+		return types.Decimal{}, fmt.Errorf("unable to parse rate %q: %w", s, err)
 	}
 
-	return f, nil
+	return d, nil
 }
 
 // parseEffectiveDate parses the "Fecha Valor" date on the BCV website
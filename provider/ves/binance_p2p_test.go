@@ -0,0 +1,238 @@
+package ves
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/provider/currencies"
+	"github.com/sig-0/fxrates/provider/p2p"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+// newBinanceOffer creates a new Binance P2P API offer
+func newBinanceOffer(price string, orders int, finishRate float64, available string) binanceP2POffer {
+	return binanceP2POffer{
+		Adv: binanceP2PAdv{
+			Price:         price,
+			SurplusAmount: available,
+		},
+		Advertiser: binanceP2PAdvertiser{
+			MonthOrderCount: orders,
+			MonthFinishRate: finishRate,
+		},
+	}
+}
+
+// newBinanceP2PServer creates a new test server serving the given offers per trade type on every page,
+// and records the received requests
+func newBinanceP2PServer(
+	t *testing.T,
+	offers map[types.RateType][]binanceP2POffer,
+) (*httptest.Server, func() []binanceP2PRequest) {
+	t.Helper()
+
+	var (
+		requests []binanceP2PRequest
+		mux      sync.Mutex
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req binanceP2PRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		mux.Lock()
+		requests = append(requests, req)
+		mux.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(binanceP2PResponse{Data: offers[req.TradeType]})
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return srv, func() []binanceP2PRequest {
+		mux.Lock()
+		defer mux.Unlock()
+
+		return append([]binanceP2PRequest(nil), requests...)
+	}
+}
+
+func TestBinanceP2PProvider_Fetch(t *testing.T) {
+	t.Parallel()
+
+	offers := map[types.RateType][]binanceP2POffer{
+		types.RateTypeBUY: {
+			newBinanceOffer("510", 300, 0.99, "1000"),
+			newBinanceOffer("500", 200, 0.98, "100"),
+			newBinanceOffer("520", 100, 0.97, "400"),
+			newBinanceOffer("450", 5, 0.50, "5000"), // filtered out
+		},
+		types.RateTypeSELL: {
+			newBinanceOffer("490", 300, 0.99, "500"),
+			newBinanceOffer("495", 200, 0.98, "500"),
+		},
+	}
+
+	t.Run("default market", func(t *testing.T) {
+		t.Parallel()
+
+		srv, requests := newBinanceP2PServer(t, offers)
+
+		p := NewBinanceP2PProvider(time.Second*5, p2p.WithURL(srv.URL))
+
+		assert.Equal(t, "Binance P2P (USDT)", p.Name())
+
+		rates, err := p.Fetch(context.Background())
+		require.NoError(t, err)
+		require.Len(t, rates, 2)
+
+		assert.Equal(t, currencies.USDT, rates[0].Base)
+		assert.Equal(t, currencies.VES, rates[0].Target)
+		assert.Equal(t, BinanceP2PSource, rates[0].Source)
+		assert.Equal(t, types.RateTypeBUY, rates[0].RateType)
+		assert.Equal(t, "510", rates[0].RateExact.String())
+
+		assert.Equal(t, types.RateTypeSELL, rates[1].RateType)
+		assert.Equal(t, "492.5", rates[1].RateExact.String())
+
+		// Each page serves the same offers
+		assert.Equal(t, map[string]string{
+			p2p.MetadataAggregation:   "median",
+			p2p.MetadataOffers:        "9",
+			p2p.MetadataOffersFetched: "12",
+			p2p.MetadataDepth:         "4500",
+		}, rates[0].Metadata)
+
+		// 3 pages of 10 offers per trade type
+		reqs := requests()
+		require.Len(t, reqs, 6)
+
+		for _, req := range reqs {
+			assert.Equal(t, currencies.USDT, req.Asset)
+			assert.Equal(t, currencies.VES, req.Fiat)
+			assert.Empty(t, req.PayTypes)
+			assert.Equal(t, 10, req.Rows)
+		}
+	})
+
+	t.Run("configured market", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			srv, requests = newBinanceP2PServer(t, offers)
+			ars           = types.Currency("ARS")
+		)
+
+		p := NewBinanceP2PProvider(
+			time.Second*5,
+			p2p.WithURL(srv.URL),
+			p2p.WithMarket(currencies.USDT, ars),
+			p2p.WithPayTypes("MercadoPagoNew", "BancoBrubankNew"),
+			p2p.WithDepth(1, 20),
+			p2p.WithAggregation(p2p.VolumeWeighted),
+			p2p.WithSource("BinanceP2P-ARS"),
+		)
+
+		assert.Equal(t, "Binance P2P (USDT/ARS: MercadoPagoNew, BancoBrubankNew)", p.Name())
+
+		rates, err := p.Fetch(context.Background())
+		require.NoError(t, err)
+		require.Len(t, rates, 2)
+
+		assert.Equal(t, ars, rates[0].Target)
+		assert.Equal(t, types.Source("BinanceP2P-ARS"), rates[0].Source)
+
+		// (510*1000 + 500*100 + 520*400) / 1500
+		assert.Equal(t, "512", rates[0].RateExact.String())
+
+		reqs := requests()
+		require.Len(t, reqs, 2)
+
+		for _, req := range reqs {
+			assert.Equal(t, ars, req.Fiat)
+			assert.Equal(t, []string{"MercadoPagoNew", "BancoBrubankNew"}, req.PayTypes)
+			assert.Equal(t, 20, req.Rows)
+		}
+	})
+
+	t.Run("depth aggregation", func(t *testing.T) {
+		t.Parallel()
+
+		srv, _ := newBinanceP2PServer(t, offers)
+
+		p := NewBinanceP2PProvider(
+			time.Second*5,
+			p2p.WithURL(srv.URL),
+			p2p.WithDepth(1, 10),
+			p2p.WithAggregation(p2p.Depth),
+			p2p.WithNotional(800),
+		)
+
+		rates, err := p.Fetch(context.Background())
+		require.NoError(t, err)
+
+		// (100*500 + 700*510) / 800
+		assert.Equal(t, "508.75", rates[0].RateExact.String())
+		assert.Equal(t, map[string]string{
+			p2p.MetadataAggregation:   "depth",
+			p2p.MetadataOffers:        "2",
+			p2p.MetadataOffersFetched: "4",
+			p2p.MetadataDepth:         "1500",
+			p2p.MetadataNotional:      "800",
+		}, rates[0].Metadata)
+
+		// (500*495 + 300*490) / 800
+		assert.Equal(t, "493.125", rates[1].RateExact.String())
+	})
+
+	t.Run("insufficient depth", func(t *testing.T) {
+		t.Parallel()
+
+		srv, _ := newBinanceP2PServer(t, offers)
+
+		p := NewBinanceP2PProvider(
+			time.Second*5,
+			p2p.WithURL(srv.URL),
+			p2p.WithDepth(1, 10),
+			p2p.WithAggregation(p2p.Depth),
+			p2p.WithNotional(5000),
+		)
+
+		_, err := p.Fetch(context.Background())
+		assert.ErrorIs(t, err, p2p.ErrInsufficientDepth)
+	})
+
+	t.Run("custom filters", func(t *testing.T) {
+		t.Parallel()
+
+		srv, _ := newBinanceP2PServer(t, offers)
+
+		// Only the most active advertisers pass
+		strict := p2p.Filter{MinOrders: 250, MinFinishRate: 0.9}
+
+		p := NewBinanceP2PProvider(
+			time.Second*5,
+			p2p.WithURL(srv.URL),
+			p2p.WithFilters(strict, strict),
+		)
+
+		rates, err := p.Fetch(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "510", rates[0].RateExact.String())
+		assert.Equal(t, "490", rates[1].RateExact.String())
+	})
+}
@@ -0,0 +1,163 @@
+//nolint:tagliatelle // Bybit API uses snake case
+package ves
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sig-0/fxrates/provider/p2p"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+var BybitP2PSource types.Source = "BybitP2P"
+
+// BybitP2PExchange is the exchange name the provider names are derived from
+const BybitP2PExchange = "Bybit"
+
+const bybitP2PURL = "https://api2.bybit.com/fiat/otc/item/online"
+
+// bybitP2PRequest is the request body for the Bybit P2P API
+type bybitP2PRequest struct {
+	TokenID    types.Currency `json:"tokenId"`
+	CurrencyID types.Currency `json:"currencyId"`
+	Side       string         `json:"side"`
+	Payment    []string       `json:"payment"`
+	Size       string         `json:"size"`
+	Page       string         `json:"page"`
+}
+
+// bybitP2PResponse is the response from the Bybit P2P API
+type bybitP2PResponse struct {
+	RetMsg string `json:"ret_msg"`
+	Result struct {
+		Items []bybitP2PItem `json:"items"`
+	} `json:"result"`
+	RetCode int `json:"ret_code"`
+}
+
+type bybitP2PItem struct {
+	Price             string `json:"price"`
+	LastQuantity      string `json:"lastQuantity"`
+	MinAmount         string `json:"minAmount"`
+	MaxAmount         string `json:"maxAmount"`
+	RecentOrderNum    int    `json:"recentOrderNum"`
+	RecentExecuteRate int    `json:"recentExecuteRate"` // percentage
+}
+
+// BybitP2PProvider fetches P2P market rates from Bybit P2P (USDT/VES by default)
+type BybitP2PProvider struct {
+	client *http.Client
+	config *p2p.Config
+}
+
+// NewBybitP2PProvider creates a new instance of the Bybit P2P provider.
+// Payment methods are identified by their Bybit IDs
+func NewBybitP2PProvider(timeout time.Duration, opts ...p2p.Option) *BybitP2PProvider {
+	return &BybitP2PProvider{
+		client: &http.Client{
+			Timeout: timeout,
+		},
+		config: p2p.NewConfig(BybitP2PExchange, bybitP2PURL, BybitP2PSource, opts...),
+	}
+}
+
+func (p *BybitP2PProvider) Name() string {
+	return p.config.Name
+}
+
+func (p *BybitP2PProvider) Interval() time.Duration {
+	return time.Minute * 10
+}
+
+func (p *BybitP2PProvider) Fetch(ctx context.Context) ([]*types.ExchangeRate, error) {
+	return p2p.Fetch(ctx, p.config, p.fetchPage)
+}
+
+// fetchPage queries a single page of Bybit P2P offers
+func (p *BybitP2PProvider) fetchPage(
+	ctx context.Context,
+	side types.RateType,
+	page int,
+) ([]p2p.Offer, error) {
+	// Bybit sides are from the taker's perspective: 1 lists the offers to buy from
+	bybitSide := "0"
+	if side == types.RateTypeBUY {
+		bybitSide = "1"
+	}
+
+	payment := p.config.PayTypes
+	if payment == nil {
+		payment = []string{}
+	}
+
+	reqBody := bybitP2PRequest{
+		TokenID:    p.config.Asset,
+		CurrencyID: p.config.Fiat,
+		Side:       bybitSide,
+		Payment:    payment,
+		Size:       strconv.Itoa(p.config.Rows),
+		Page:       strconv.Itoa(page),
+	}
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create POST request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute POST request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("invalid status code received: %d", resp.StatusCode)
+	}
+
+	var apiResp bybitP2PResponse
+	if err = json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, fmt.Errorf("unable to decode response: %w", err)
+	}
+
+	if apiResp.RetCode != 0 {
+		return nil, fmt.Errorf("API error %d: %s", apiResp.RetCode, apiResp.RetMsg)
+	}
+
+	offers := make([]p2p.Offer, 0, len(apiResp.Result.Items))
+
+	for _, item := range apiResp.Result.Items {
+		price, ok := p2p.ParseAmount(item.Price)
+		if !ok {
+			continue
+		}
+
+		var (
+			minLimit, _  = p2p.ParseAmount(item.MinAmount)
+			maxLimit, _  = p2p.ParseAmount(item.MaxAmount)
+			available, _ = p2p.ParseAmount(item.LastQuantity)
+		)
+
+		offers = append(offers, p2p.NewOffer(
+			price,
+			minLimit,
+			maxLimit,
+			available,
+			item.RecentOrderNum,
+			float64(item.RecentExecuteRate),
+		))
+	}
+
+	return offers, nil
+}
@@ -0,0 +1,161 @@
+package ves
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/provider/currencies"
+	"github.com/sig-0/fxrates/provider/p2p"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+// readFixture reads the recorded API response from testdata
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+
+	return data
+}
+
+// newBybitP2PServer creates a new test server serving the recorded offers on the first page,
+// and records the received requests
+func newBybitP2PServer(t *testing.T) (*httptest.Server, func() []bybitP2PRequest) {
+	t.Helper()
+
+	var (
+		fixtures = map[string][]byte{
+			"1": readFixture(t, "bybit_p2p_buy.json"),
+			"0": readFixture(t, "bybit_p2p_sell.json"),
+		}
+
+		requests []bybitP2PRequest
+		mux      sync.Mutex
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req bybitP2PRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		mux.Lock()
+		requests = append(requests, req)
+		mux.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if req.Page != "1" {
+			_, _ = w.Write([]byte(`{"ret_code":0,"ret_msg":"SUCCESS","result":{"count":0,"items":[]}}`))
+
+			return
+		}
+
+		_, _ = w.Write(fixtures[req.Side])
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return srv, func() []bybitP2PRequest {
+		mux.Lock()
+		defer mux.Unlock()
+
+		return append([]bybitP2PRequest(nil), requests...)
+	}
+}
+
+func TestBybitP2PProvider_Fetch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("recorded offers", func(t *testing.T) {
+		t.Parallel()
+
+		srv, requests := newBybitP2PServer(t)
+
+		p := NewBybitP2PProvider(time.Second*5, p2p.WithURL(srv.URL))
+
+		assert.Equal(t, "Bybit P2P (USDT)", p.Name())
+
+		rates, err := p.Fetch(context.Background())
+		require.NoError(t, err)
+		require.Len(t, rates, 2)
+
+		assert.Equal(t, currencies.USDT, rates[0].Base)
+		assert.Equal(t, currencies.VES, rates[0].Target)
+		assert.Equal(t, BybitP2PSource, rates[0].Source)
+		assert.Equal(t, types.RateTypeBUY, rates[0].RateType)
+
+		// The offers of inactive advertisers, or with limits below
+		// the typical amount are filtered out
+		assert.Equal(t, "513.45", rates[0].RateExact.String())
+		assert.Equal(t, map[string]string{
+			p2p.MetadataAggregation:   "median",
+			p2p.MetadataOffers:        "4",
+			p2p.MetadataOffersFetched: "6",
+			p2p.MetadataDepth:         "5080.25",
+		}, rates[0].Metadata)
+
+		assert.Equal(t, types.RateTypeSELL, rates[1].RateType)
+		assert.Equal(t, "507.925", rates[1].RateExact.String())
+
+		// Paging stops at the first empty page
+		reqs := requests()
+		require.Len(t, reqs, 4)
+
+		assert.Equal(t, currencies.USDT, reqs[0].TokenID)
+		assert.Equal(t, currencies.VES, reqs[0].CurrencyID)
+		assert.Equal(t, "1", reqs[0].Side)
+		assert.Equal(t, "10", reqs[0].Size)
+		assert.Empty(t, reqs[0].Payment)
+	})
+
+	t.Run("configured market", func(t *testing.T) {
+		t.Parallel()
+
+		srv, requests := newBybitP2PServer(t)
+
+		p := NewBybitP2PProvider(
+			time.Second*5,
+			p2p.WithURL(srv.URL),
+			p2p.WithPayTypes("14"),
+			p2p.WithSource("BybitP2P-14"),
+			p2p.WithName("Bybit P2P (USDT: Banesco)"),
+		)
+
+		assert.Equal(t, "Bybit P2P (USDT: Banesco)", p.Name())
+
+		rates, err := p.Fetch(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, types.Source("BybitP2P-14"), rates[0].Source)
+
+		for _, req := range requests() {
+			assert.Equal(t, []string{"14"}, req.Payment)
+		}
+	})
+
+	t.Run("API error", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"ret_code":10006,"ret_msg":"Too many visits!","result":null}`))
+		}))
+		t.Cleanup(srv.Close)
+
+		_, err := NewBybitP2PProvider(time.Second*5, p2p.WithURL(srv.URL)).Fetch(context.Background())
+		assert.ErrorContains(t, err, "Too many visits!")
+	})
+}
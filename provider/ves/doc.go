@@ -6,7 +6,7 @@
 //
 // Source: "BCV"
 // URL: https://www.bcv.org.ve/
-// Interval: 24 hours
+// Schedule: business days at 09:00 and hourly 15:00-19:00 (Caracas time)
 //
 // Scrapes official exchange rates from Banco Central de Venezuela.
 // Returns MID rates for multiple currency pairs:
@@ -19,7 +19,7 @@
 //
 // Source: Bank name (e.g., "Banesco", "Mercantil")
 // URL: https://www.bcv.org.ve/tasas-informativas-sistema-bancario
-// Interval: 24 hours
+// Schedule: business days at 18:30 (Caracas time)
 //
 // Scrapes USD/VES rates reported by individual Venezuelan banks.
 // Returns BUY and SELL rates for each bank. Only the most recent
@@ -31,22 +31,33 @@
 // API: https://p2p.binance.com/bapi/c2c/v2/friendly/c2c/adv/search
 // Interval: 10 minutes
 //
-// Fetches peer-to-peer USDT/VES rates from Binance.
-// Returns BUY and SELL rates calculated as the median of filtered offers.
+// Fetches peer-to-peer USDT/VES rates from Binance, 3 pages of 10 offers per side.
+// Returns BUY and SELL rates priced by the p2p package: offers are filtered (strict,
+// then relaxed if needed) by advertiser activity and completion rate, available
+// amount, and transaction limits, and the median of the top 12 offers is taken.
 //
-// Offer collection:
-//   - Fetches up to 30 offers (3 pages of 10)
-//   - Parses price, limits, availability, and advertiser metrics
+// ## Bybit P2P (USDT)
 //
-// Offer filtering (strict, then relaxed if needed):
-//   - Minimum 50 monthly orders (relaxed: 20)
-//   - Minimum 95% completion rate (relaxed: 90%)
-//   - Minimum 50 USDT available
-//   - Typical transaction amount of 100 USDT must be within limits
+// Source: "BybitP2P"
+// API: https://api2.bybit.com/fiat/otc/item/online
+// Interval: 10 minutes
+//
+// Fetches peer-to-peer USDT/VES rates from Bybit, paged like Binance,
+// and priced the same way.
+//
+// ## OKX P2P (USDT)
+//
+// Source: "OKXP2P"
+// API: https://www.okx.com/v3/c2c/tradingOrders/books
+// Interval: 10 minutes
 //
-// Quality scoring uses Wilson lower bound on completion rate to favor
-// advertisers with both high completion rates and sufficient order volume.
+// Fetches peer-to-peer USDT/VES rates from OKX. The order book side is served
+// at once, so offers are filtered by payment method locally, and capped at
+// the configured page depth.
 //
-// Final rate is the median of the top 12 offers sorted by price
-// (ascending for BUY, descending for SELL), with quality as tiebreaker.
+// Other markets (i.e. USDT/ARS) of each exchange are tracked with more instances,
+// configured with the p2p package options: asset and fiat, payment methods,
+// page depth, filter thresholds, and the aggregation statistic (median,
+// trimmed mean, mean weighted by the available amounts, or depth). Each instance
+// needs a distinct source.
 package ves
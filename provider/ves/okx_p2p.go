@@ -0,0 +1,166 @@
+package ves
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sig-0/fxrates/provider/p2p"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+var OKXP2PSource types.Source = "OKXP2P"
+
+// OKXP2PExchange is the exchange name the provider names are derived from
+const OKXP2PExchange = "OKX"
+
+const okxP2PURL = "https://www.okx.com/v3/c2c/tradingOrders/books"
+
+// okxP2PResponse is the response from the OKX P2P API
+type okxP2PResponse struct {
+	Msg  string `json:"msg"`
+	Data struct {
+		Buy  []okxP2POrder `json:"buy"`
+		Sell []okxP2POrder `json:"sell"`
+	} `json:"data"`
+	Code int `json:"code"`
+}
+
+type okxP2POrder struct {
+	PaymentMethods         []string `json:"paymentMethods"`
+	Price                  string   `json:"price"`
+	AvailableAmount        string   `json:"availableAmount"`
+	QuoteMinAmountPerOrder string   `json:"quoteMinAmountPerOrder"`
+	QuoteMaxAmountPerOrder string   `json:"quoteMaxAmountPerOrder"`
+	CompletedRate          string   `json:"completedRate"`
+	CompletedOrderQuantity int      `json:"completedOrderQuantity"`
+}
+
+// OKXP2PProvider fetches P2P market rates from OKX P2P (USDT/VES by default)
+type OKXP2PProvider struct {
+	client *http.Client
+	config *p2p.Config
+}
+
+// NewOKXP2PProvider creates a new instance of the OKX P2P provider.
+// Payment methods are identified by their OKX names (i.e. "Pago Movil"). OKX serves the
+// whole order book at once, so the offers are limited to the configured pages of rows
+func NewOKXP2PProvider(timeout time.Duration, opts ...p2p.Option) *OKXP2PProvider {
+	return &OKXP2PProvider{
+		client: &http.Client{
+			Timeout: timeout,
+		},
+		config: p2p.NewConfig(OKXP2PExchange, okxP2PURL, OKXP2PSource, opts...),
+	}
+}
+
+func (p *OKXP2PProvider) Name() string {
+	return p.config.Name
+}
+
+func (p *OKXP2PProvider) Interval() time.Duration {
+	return time.Minute * 10
+}
+
+func (p *OKXP2PProvider) Fetch(ctx context.Context) ([]*types.ExchangeRate, error) {
+	return p2p.Fetch(ctx, p.config, p.fetchPage)
+}
+
+// fetchPage queries the OKX P2P order book side, served as a single page
+func (p *OKXP2PProvider) fetchPage(
+	ctx context.Context,
+	side types.RateType,
+	page int,
+) ([]p2p.Offer, error) {
+	if page > 1 {
+		return nil, nil
+	}
+
+	// OKX sides are from the maker's perspective: sell orders are the ones to buy from
+	okxSide := "buy"
+	if side == types.RateTypeBUY {
+		okxSide = "sell"
+	}
+
+	query := url.Values{}
+	query.Set("quoteCurrency", strings.ToLower(p.config.Fiat.String()))
+	query.Set("baseCurrency", strings.ToLower(p.config.Asset.String()))
+	query.Set("side", okxSide)
+	query.Set("paymentMethod", "all")
+	query.Set("userType", "all")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.config.URL+"?"+query.Encode(), http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create GET request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute GET request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("invalid status code received: %d", resp.StatusCode)
+	}
+
+	var apiResp okxP2PResponse
+	if err = json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, fmt.Errorf("unable to decode response: %w", err)
+	}
+
+	if apiResp.Code != 0 {
+		return nil, fmt.Errorf("API error %d: %s", apiResp.Code, apiResp.Msg)
+	}
+
+	orders := apiResp.Data.Buy
+	if okxSide == "sell" {
+		orders = apiResp.Data.Sell
+	}
+
+	var (
+		limit  = p.config.Pages * p.config.Rows
+		offers = make([]p2p.Offer, 0, min(len(orders), limit))
+	)
+
+	for _, order := range orders {
+		if len(offers) == limit {
+			break
+		}
+
+		// The payment methods are filtered locally
+		if len(p.config.PayTypes) > 0 && !slices.ContainsFunc(order.PaymentMethods, func(method string) bool {
+			return slices.Contains(p.config.PayTypes, method)
+		}) {
+			continue
+		}
+
+		price, ok := p2p.ParseAmount(order.Price)
+		if !ok {
+			continue
+		}
+
+		var (
+			minLimit, _   = p2p.ParseAmount(order.QuoteMinAmountPerOrder)
+			maxLimit, _   = p2p.ParseAmount(order.QuoteMaxAmountPerOrder)
+			available, _  = p2p.ParseAmount(order.AvailableAmount)
+			finishRate, _ = p2p.ParseAmount(order.CompletedRate)
+		)
+
+		offers = append(offers, p2p.NewOffer(
+			price,
+			minLimit,
+			maxLimit,
+			available,
+			order.CompletedOrderQuantity,
+			finishRate,
+		))
+	}
+
+	return offers, nil
+}
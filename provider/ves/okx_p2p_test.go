@@ -0,0 +1,131 @@
+package ves
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/provider/currencies"
+	"github.com/sig-0/fxrates/provider/p2p"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+// newOKXP2PServer creates a new test server serving the recorded order book sides,
+// and records the received queries
+func newOKXP2PServer(t *testing.T) (*httptest.Server, func() []url.Values) {
+	t.Helper()
+
+	var (
+		fixtures = map[string][]byte{
+			"sell": readFixture(t, "okx_p2p_sell.json"),
+			"buy":  readFixture(t, "okx_p2p_buy.json"),
+		}
+
+		queries []url.Values
+		mux     sync.Mutex
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.Lock()
+		queries = append(queries, r.URL.Query())
+		mux.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(fixtures[r.URL.Query().Get("side")])
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return srv, func() []url.Values {
+		mux.Lock()
+		defer mux.Unlock()
+
+		return append([]url.Values(nil), queries...)
+	}
+}
+
+func TestOKXP2PProvider_Fetch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("recorded offers", func(t *testing.T) {
+		t.Parallel()
+
+		srv, queries := newOKXP2PServer(t)
+
+		p := NewOKXP2PProvider(time.Second*5, p2p.WithURL(srv.URL))
+
+		assert.Equal(t, "OKX P2P (USDT)", p.Name())
+
+		rates, err := p.Fetch(context.Background())
+		require.NoError(t, err)
+		require.Len(t, rates, 2)
+
+		assert.Equal(t, currencies.USDT, rates[0].Base)
+		assert.Equal(t, currencies.VES, rates[0].Target)
+		assert.Equal(t, OKXP2PSource, rates[0].Source)
+
+		// BUY rates come from the sell orders
+		assert.Equal(t, types.RateTypeBUY, rates[0].RateType)
+		assert.Equal(t, "512.675", rates[0].RateExact.String())
+		assert.Equal(t, "5", rates[0].Metadata[p2p.MetadataOffersFetched])
+
+		assert.Equal(t, types.RateTypeSELL, rates[1].RateType)
+		assert.Equal(t, "507.6", rates[1].RateExact.String())
+
+		// The whole book side is served at once
+		qs := queries()
+		require.Len(t, qs, 2)
+
+		assert.Equal(t, "sell", qs[0].Get("side"))
+		assert.Equal(t, "usdt", qs[0].Get("baseCurrency"))
+		assert.Equal(t, "ves", qs[0].Get("quoteCurrency"))
+		assert.Equal(t, "buy", qs[1].Get("side"))
+	})
+
+	t.Run("payment methods", func(t *testing.T) {
+		t.Parallel()
+
+		srv, _ := newOKXP2PServer(t)
+
+		p := NewOKXP2PProvider(
+			time.Second*5,
+			p2p.WithURL(srv.URL),
+			p2p.WithPayTypes("Pago Movil"),
+			p2p.WithSource("OKXP2P-PagoMovil"),
+		)
+
+		assert.Equal(t, "OKX P2P (USDT: Pago Movil)", p.Name())
+
+		rates, err := p.Fetch(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, types.Source("OKXP2P-PagoMovil"), rates[0].Source)
+		assert.Equal(t, "512.15", rates[0].RateExact.String())
+		assert.Equal(t, "3", rates[0].Metadata[p2p.MetadataOffersFetched])
+	})
+
+	t.Run("book limited to the configured depth", func(t *testing.T) {
+		t.Parallel()
+
+		srv, _ := newOKXP2PServer(t)
+
+		p := NewOKXP2PProvider(
+			time.Second*5,
+			p2p.WithURL(srv.URL),
+			p2p.WithDepth(1, 2),
+		)
+
+		rates, err := p.Fetch(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "512.15", rates[0].RateExact.String())
+		assert.Equal(t, "2", rates[0].Metadata[p2p.MetadataOffersFetched])
+	})
+}
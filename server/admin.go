@@ -0,0 +1,92 @@
+package server
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/sig-0/fxrates/ingest"
+)
+
+var (
+	errUnauthorized    = errors.New("unauthorized")
+	errUnableToControl = errors.New("unable to control provider")
+)
+
+// requireAdminToken is the admin API middleware that checks the bearer token
+func (s *Server) requireAdminToken(next http.Handler) http.Handler {
+	expected := []byte("Bearer " + s.config.AdminConfig.Token)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		given := []byte(strings.TrimSpace(r.Header.Get("Authorization")))
+
+		if subtle.ConstantTimeCompare(given, expected) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="fxrates-admin"`)
+			writeError(w, http.StatusUnauthorized, errUnauthorized)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RunProvider triggers an immediate fetch for the provider
+func (s *Server) RunProvider(w http.ResponseWriter, r *http.Request) {
+	s.controlProvider(w, r, "run", s.controller.Trigger, http.StatusAccepted)
+}
+
+// PauseProvider stops scheduling fetches for the provider
+func (s *Server) PauseProvider(w http.ResponseWriter, r *http.Request) {
+	s.controlProvider(w, r, "pause", s.controller.Pause, http.StatusNoContent)
+}
+
+// ResumeProvider resumes scheduling fetches for the provider
+func (s *Server) ResumeProvider(w http.ResponseWriter, r *http.Request) {
+	s.controlProvider(w, r, "resume", s.controller.Resume, http.StatusNoContent)
+}
+
+// controlProvider applies the control action to the provider in the route
+func (s *Server) controlProvider(
+	w http.ResponseWriter,
+	r *http.Request,
+	action string,
+	actionFn func(name string) error,
+	status int,
+) {
+	name := chi.URLParam(r, "name")
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+
+	if err := actionFn(name); err != nil {
+		if errors.Is(err, ingest.ErrProviderNotFound) {
+			writeError(w, http.StatusNotFound, err)
+
+			return
+		}
+
+		s.logger.Debug(
+			"unable to control provider",
+			"name", name,
+			"action", action,
+			"err", err,
+		)
+
+		writeError(w, http.StatusInternalServerError, errUnableToControl)
+
+		return
+	}
+
+	s.logger.Info(
+		"provider controlled via admin API",
+		"name", name,
+		"action", action,
+	)
+
+	w.WriteHeader(status)
+}
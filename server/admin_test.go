@@ -0,0 +1,179 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/ingest"
+	"github.com/sig-0/fxrates/server/config"
+	"github.com/sig-0/fxrates/storage/mock"
+)
+
+const testAdminToken = "0123456789abcdef"
+
+// mockController is a provider controller that records the controlled providers
+type mockController struct {
+	err error
+
+	triggered []string
+	paused    []string
+	resumed   []string
+}
+
+func (m *mockController) Trigger(name string) error {
+	m.triggered = append(m.triggered, name)
+
+	return m.err
+}
+
+func (m *mockController) Pause(name string) error {
+	m.paused = append(m.paused, name)
+
+	return m.err
+}
+
+func (m *mockController) Resume(name string) error {
+	m.resumed = append(m.resumed, name)
+
+	return m.err
+}
+
+// newAdminServer creates a new server with the admin API enabled
+func newAdminServer(t *testing.T, token string, controller ingest.Controller) *Server {
+	t.Helper()
+
+	cfg := config.DefaultConfig()
+	cfg.AdminConfig = &config.Admin{Token: token}
+
+	s, err := New(
+		&mock.Storage{},
+		WithConfig(cfg),
+		WithProviderController(controller),
+	)
+	require.NoError(t, err)
+
+	return s
+}
+
+// adminRequest executes an admin API request against the server
+func adminRequest(t *testing.T, s *Server, path, token string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, path, http.NoBody)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	w := httptest.NewRecorder()
+	s.mux.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestAdmin_Auth(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled without token", func(t *testing.T) {
+		t.Parallel()
+
+		controller := &mockController{}
+		s := newAdminServer(t, "", controller)
+
+		w := adminRequest(t, s, "/admin/providers/BCV/run", "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Empty(t, controller.triggered)
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		t.Parallel()
+
+		controller := &mockController{}
+		s := newAdminServer(t, testAdminToken, controller)
+
+		w := adminRequest(t, s, "/admin/providers/BCV/run", "")
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+		assert.Empty(t, controller.triggered)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		t.Parallel()
+
+		controller := &mockController{}
+		s := newAdminServer(t, testAdminToken, controller)
+
+		w := adminRequest(t, s, "/admin/providers/BCV/run", "fedcba9876543210")
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Empty(t, controller.triggered)
+	})
+}
+
+func TestAdmin_ControlProvider(t *testing.T) {
+	t.Parallel()
+
+	t.Run("run", func(t *testing.T) {
+		t.Parallel()
+
+		controller := &mockController{}
+		s := newAdminServer(t, testAdminToken, controller)
+
+		w := adminRequest(t, s, "/admin/providers/BCV%20Banks/run", testAdminToken)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Equal(t, []string{"BCV Banks"}, controller.triggered)
+	})
+
+	t.Run("pause", func(t *testing.T) {
+		t.Parallel()
+
+		controller := &mockController{}
+		s := newAdminServer(t, testAdminToken, controller)
+
+		w := adminRequest(t, s, "/admin/providers/BCV/pause", testAdminToken)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, []string{"BCV"}, controller.paused)
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		t.Parallel()
+
+		controller := &mockController{}
+		s := newAdminServer(t, testAdminToken, controller)
+
+		w := adminRequest(t, s, "/admin/providers/BCV/resume", testAdminToken)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, []string{"BCV"}, controller.resumed)
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		t.Parallel()
+
+		controller := &mockController{err: ingest.ErrProviderNotFound}
+		s := newAdminServer(t, testAdminToken, controller)
+
+		w := adminRequest(t, s, "/admin/providers/ECB/run", testAdminToken)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("controller error", func(t *testing.T) {
+		t.Parallel()
+
+		controller := &mockController{err: errors.New("boom")}
+		s := newAdminServer(t, testAdminToken, controller)
+
+		w := adminRequest(t, s, "/admin/providers/BCV/pause", testAdminToken)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
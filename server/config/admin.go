@@ -0,0 +1,29 @@
+package config
+
+import "errors"
+
+// MinAdminTokenLength is the minimum length of the admin API token
+const MinAdminTokenLength = 16
+
+var ErrInvalidAdminToken = errors.New("invalid admin token")
+
+// Admin defines the admin API configuration
+type Admin struct {
+	// The bearer token required by the admin API.
+	// The admin API is disabled if no token is set
+	Token string `toml:"token"`
+}
+
+// Enabled returns true if the admin API is enabled
+func (a *Admin) Enabled() bool {
+	return a != nil && a.Token != ""
+}
+
+// validateAdminConfig validates the admin API configuration
+func validateAdminConfig(config *Admin) error {
+	if config.Token != "" && len(config.Token) < MinAdminTokenLength {
+		return ErrInvalidAdminToken
+	}
+
+	return nil
+}
@@ -19,6 +19,18 @@ type Config struct {
 	// The associated CORS config, if any
 	CORSConfig *CORS `toml:"cors_config"`
 
+	// The associated cross-rate config, if any
+	CrossRatesConfig *CrossRates `toml:"cross_rates_config"`
+
+	// The associated admin API config, if any
+	AdminConfig *Admin `toml:"admin_config"`
+
+	// The associated SQL database pool config, if any
+	DatabaseConfig *Database `toml:"database_config"`
+
+	// The associated ingestion config, if any
+	IngestConfig *Ingest `toml:"ingest_config"`
+
 	// The address at which the server will be served.
 	// Format should be: <IP>:<PORT>
 	ListenAddress string `toml:"listen_address"`
@@ -27,8 +39,11 @@ type Config struct {
 // DefaultConfig returns the default server configuration
 func DefaultConfig() *Config {
 	return &Config{
-		ListenAddress: DefaultListenAddress,
-		CORSConfig:    DefaultCORSConfig(),
+		ListenAddress:    DefaultListenAddress,
+		CORSConfig:       DefaultCORSConfig(),
+		CrossRatesConfig: DefaultCrossRatesConfig(),
+		DatabaseConfig:   DefaultDatabaseConfig(),
+		IngestConfig:     DefaultIngestConfig(),
 	}
 }
 
@@ -39,6 +54,34 @@ func ValidateConfig(config *Config) error {
 		return ErrInvalidListenAddress
 	}
 
+	// Validate the cross-rate config, if any
+	if config.CrossRatesConfig != nil {
+		if err := validateCrossRatesConfig(config.CrossRatesConfig); err != nil {
+			return err
+		}
+	}
+
+	// Validate the admin API config, if any
+	if config.AdminConfig != nil {
+		if err := validateAdminConfig(config.AdminConfig); err != nil {
+			return err
+		}
+	}
+
+	// Validate the database config, if any
+	if config.DatabaseConfig != nil {
+		if err := validateDatabaseConfig(config.DatabaseConfig); err != nil {
+			return err
+		}
+	}
+
+	// Validate the ingestion config, if any
+	if config.IngestConfig != nil {
+		if err := validateIngestConfig(config.IngestConfig); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -50,12 +93,13 @@ func Read(path string) (*Config, error) {
 		return nil, err
 	}
 
-	// Parse it
-	var cfg Config
+	// Parse it over the defaults, so unset values
+	// (even in partially set tables) keep their default
+	cfg := DefaultConfig()
 
-	if err := toml.Unmarshal(content, &cfg); err != nil {
+	if err := toml.Unmarshal(content, cfg); err != nil {
 		return nil, err
 	}
 
-	return &cfg, nil
+	return cfg, nil
 }
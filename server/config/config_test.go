@@ -1,9 +1,12 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConfig_ValidateConfig(t *testing.T) {
@@ -18,9 +21,470 @@ func TestConfig_ValidateConfig(t *testing.T) {
 		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidListenAddress)
 	})
 
+	t.Run("invalid default pivot", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.CrossRatesConfig.DefaultPivot = "usd"
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidPivot)
+	})
+
+	t.Run("invalid source pivot", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.CrossRatesConfig.SourcePivots["BCV"] = "bolivar"
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidPivot)
+	})
+
+	t.Run("invalid max as-of skew", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.CrossRatesConfig.MaxAsOfSkew = "a day"
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidMaxAsOfSkew)
+	})
+
+	t.Run("short admin token", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.AdminConfig = &Admin{Token: "secret"}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidAdminToken)
+	})
+
+	t.Run("valid admin token", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.AdminConfig = &Admin{Token: "0123456789abcdef"}
+
+		assert.NoError(t, ValidateConfig(cfg))
+		assert.True(t, cfg.AdminConfig.Enabled())
+	})
+
+	t.Run("min conns exceed max conns", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.DatabaseConfig.MinConns = cfg.DatabaseConfig.MaxConns + 1
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidPoolSize)
+	})
+
+	t.Run("negative max conns", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.DatabaseConfig.MaxConns = -1
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidPoolSize)
+	})
+
+	t.Run("invalid DB duration", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.DatabaseConfig.HealthCheckPeriod = "every minute"
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidDatabaseDuration)
+	})
+
+	t.Run("unset DB durations", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.DatabaseConfig = &Database{MaxConns: 4}
+
+		assert.NoError(t, ValidateConfig(cfg))
+	})
+
+	t.Run("negative max rate deviation", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.ProviderMaxRateDeviation = map[string]float64{"BCV": -0.1}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidRateDeviation)
+	})
+
+	t.Run("negative rate deviation confirmations", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.RateDeviationConfirmations = -1
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidDeviationConfirmations)
+	})
+
+	t.Run("invalid max as-of lead", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.MaxAsOfLead = "a week"
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidMaxAsOfLead)
+	})
+
+	t.Run("missing Binance P2P source", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.BinanceP2P = []*P2PMarket{{Asset: "USDT", Fiat: "ARS"}}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidP2PMarket)
+	})
+
+	t.Run("duplicate Binance P2P source", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.BinanceP2P = []*P2PMarket{
+			{Source: "BinanceP2P-ARS", Asset: "USDT", Fiat: "ARS"},
+			{Source: "BinanceP2P-ARS", Asset: "USDT", Fiat: "COP"},
+		}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrDuplicateP2PSource)
+	})
+
+	t.Run("invalid Binance P2P fiat", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.BinanceP2P = []*P2PMarket{{Source: "BinanceP2P-ARS", Asset: "USDT", Fiat: "ars"}}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidP2PMarket)
+	})
+
+	t.Run("invalid Binance P2P aggregation", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.BinanceP2P = []*P2PMarket{
+			{Source: "BinanceP2P-ARS", Asset: "USDT", Fiat: "ARS", Aggregation: "mode"},
+		}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidP2PAggregation)
+	})
+
+	t.Run("invalid Binance P2P finish rate", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.BinanceP2P = []*P2PMarket{
+			{Source: "BinanceP2P-ARS", Asset: "USDT", Fiat: "ARS", MinFinishRate: 95},
+		}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidP2PFilter)
+	})
+
+	t.Run("valid Binance P2P markets", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.BinanceP2P = []*P2PMarket{
+			{Source: "BinanceP2P-ARS", Asset: "USDT", Fiat: "ARS", Aggregation: "trimmed_mean"},
+			{Source: "BinanceP2P-PagoMovil", Asset: "USDT", Fiat: "VES", PayTypes: []string{"PagoMovil"}},
+		}
+
+		assert.NoError(t, ValidateConfig(cfg))
+	})
+
+	t.Run("duplicate P2P source across exchanges", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.BinanceP2P = []*P2PMarket{{Source: "P2P-ARS", Asset: "USDT", Fiat: "ARS"}}
+		cfg.IngestConfig.OKXP2P = []*P2PMarket{{Source: "P2P-ARS", Asset: "USDT", Fiat: "ARS"}}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrDuplicateP2PSource)
+	})
+
+	t.Run("P2P source of a default market", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.OKXP2P = []*P2PMarket{{Source: "BinanceP2P", Asset: "USDT", Fiat: "ARS"}}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrDuplicateSource)
+	})
+
+	t.Run("duplicate derived P2P name", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.BinanceP2P = []*P2PMarket{
+			{Source: "BinanceP2P-ARS", Asset: "USDT", Fiat: "ARS", Aggregation: "median"},
+			{Source: "BinanceP2P-ARS-Depth", Asset: "USDT", Fiat: "ARS", Aggregation: "depth"},
+		}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrDuplicateProviderName)
+
+		// Named markets are distinct
+		cfg.IngestConfig.BinanceP2P[1].Name = "Binance P2P (USDT/ARS, depth)"
+
+		assert.NoError(t, ValidateConfig(cfg))
+	})
+
+	t.Run("P2P market named as a default market", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.BybitP2P = []*P2PMarket{{Source: "BybitP2P-VES", Asset: "USDT", Fiat: "VES"}}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrDuplicateProviderName)
+	})
+
+	t.Run("invalid Bybit P2P depth", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.BybitP2P = []*P2PMarket{{Source: "BybitP2P-ARS", Asset: "USDT", Fiat: "ARS", Pages: -1}}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidP2PFilter)
+	})
+
+	t.Run("valid Bybit and OKX P2P markets", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.BybitP2P = []*P2PMarket{
+			{Source: "BybitP2P-ARS", Asset: "USDT", Fiat: "ARS", Aggregation: "depth", Notional: 1000},
+		}
+		cfg.IngestConfig.OKXP2P = []*P2PMarket{
+			{Source: "OKXP2P-PagoMovil", Asset: "USDT", Fiat: "VES", PayTypes: []string{"Pago Movil"}},
+		}
+
+		assert.NoError(t, ValidateConfig(cfg))
+	})
+
+	t.Run("missing scrape rate selector", func(t *testing.T) {
+		t.Parallel()
+
+		source := newScrapeSource()
+		source.Selectors.Rate = ""
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.Scrape = []*ScrapeSource{source}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidScrapeSelector)
+	})
+
+	t.Run("invalid scrape selector", func(t *testing.T) {
+		t.Parallel()
+
+		source := newScrapeSource()
+		source.Selectors.Rows = "table.rates tr["
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.Scrape = []*ScrapeSource{source}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidScrapeSelector)
+	})
+
+	t.Run("missing scrape currency", func(t *testing.T) {
+		t.Parallel()
+
+		source := newScrapeSource()
+		source.Target = ""
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.Scrape = []*ScrapeSource{source}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidScrapeSelector)
+	})
+
+	t.Run("invalid scrape rate type", func(t *testing.T) {
+		t.Parallel()
+
+		source := newScrapeSource()
+		source.RateType = "buy"
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.Scrape = []*ScrapeSource{source}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidScrapeSource)
+	})
+
+	t.Run("invalid scrape locale", func(t *testing.T) {
+		t.Parallel()
+
+		source := newScrapeSource()
+		source.Locale = "es_VE"
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.Scrape = []*ScrapeSource{source}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidScrapeLocale)
+	})
+
+	t.Run("invalid scrape time zone", func(t *testing.T) {
+		t.Parallel()
+
+		source := newScrapeSource()
+		source.TimeZone = "Caracas"
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.Scrape = []*ScrapeSource{source}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidScrapeDate)
+	})
+
+	t.Run("missing scrape date format", func(t *testing.T) {
+		t.Parallel()
+
+		source := newScrapeSource()
+		source.DateFormat = ""
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.Scrape = []*ScrapeSource{source}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidScrapeDate)
+	})
+
+	t.Run("invalid scrape interval", func(t *testing.T) {
+		t.Parallel()
+
+		source := newScrapeSource()
+		source.Interval = "hourly"
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.Scrape = []*ScrapeSource{source}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrInvalidScrapeInterval)
+	})
+
+	t.Run("duplicate scrape source", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.Scrape = []*ScrapeSource{newScrapeSource(), newScrapeSource()}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrDuplicateScrapeSource)
+	})
+
+	t.Run("scrape source of a P2P market", func(t *testing.T) {
+		t.Parallel()
+
+		source := newScrapeSource()
+		source.Source = "BinanceP2P-ARS"
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.BinanceP2P = []*P2PMarket{{Source: "BinanceP2P-ARS", Asset: "USDT", Fiat: "ARS"}}
+		cfg.IngestConfig.Scrape = []*ScrapeSource{source}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrDuplicateSource)
+	})
+
+	t.Run("scrape source named as a default provider", func(t *testing.T) {
+		t.Parallel()
+
+		source := newScrapeSource()
+		source.Name = "ECB"
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.Scrape = []*ScrapeSource{source}
+
+		assert.ErrorIs(t, ValidateConfig(cfg), ErrDuplicateProviderName)
+	})
+
+	t.Run("valid scrape sources", func(t *testing.T) {
+		t.Parallel()
+
+		fixed := newScrapeSource()
+		fixed.Source = "Banco Ejemplo (SELL)"
+		fixed.RateType = "SELL"
+		fixed.Base = "USD"
+		fixed.Selectors.Base = ""
+		fixed.Selectors.Date = ""
+		fixed.DateFormat = ""
+		fixed.TimeZone = ""
+
+		cfg := DefaultConfig()
+		cfg.IngestConfig.Scrape = []*ScrapeSource{newScrapeSource(), fixed}
+
+		assert.NoError(t, ValidateConfig(cfg))
+	})
+
 	t.Run("valid configuration", func(t *testing.T) {
 		t.Parallel()
 
 		assert.NoError(t, ValidateConfig(DefaultConfig()))
 	})
 }
+
+func TestConfig_Read(t *testing.T) {
+	t.Parallel()
+
+	t.Run("partial tables keep the defaults", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "config.toml")
+
+		require.NoError(t, os.WriteFile(path, []byte(`
+[ingest_config]
+provider_max_rate_deviation = { "BCV" = 0.2 }
+
+[database_config]
+max_conns = 20
+`), 0o600))
+
+		cfg, err := Read(path)
+		require.NoError(t, err)
+
+		assert.Equal(t, DefaultListenAddress, cfg.ListenAddress)
+
+		// The unset ingestion checks are not disabled
+		assert.Equal(t, DefaultMaxRateDeviation, cfg.IngestConfig.MaxRateDeviation)
+		assert.Equal(t, DefaultMaxAsOfLead, cfg.IngestConfig.MaxAsOfLead)
+		assert.Equal(t, DefaultRateDeviationConfirmations, cfg.IngestConfig.RateDeviationConfirmations)
+		assert.Equal(t, map[string]float64{"BCV": 0.2}, cfg.IngestConfig.ProviderMaxRateDeviation)
+
+		assert.Equal(t, int32(20), cfg.DatabaseConfig.MaxConns)
+		assert.Equal(t, int32(DefaultMinConns), cfg.DatabaseConfig.MinConns)
+
+		assert.NoError(t, ValidateConfig(cfg))
+	})
+
+	t.Run("explicitly disabled checks", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "config.toml")
+
+		require.NoError(t, os.WriteFile(path, []byte(`
+[ingest_config]
+max_rate_deviation = 0.0
+max_as_of_lead = ""
+`), 0o600))
+
+		cfg, err := Read(path)
+		require.NoError(t, err)
+
+		assert.Zero(t, cfg.IngestConfig.MaxRateDeviation)
+		assert.Empty(t, cfg.IngestConfig.MaxAsOfLead)
+	})
+}
+
+// newScrapeSource creates a new valid scrape source, with a scraped base currency and date
+func newScrapeSource() *ScrapeSource {
+	return &ScrapeSource{
+		Selectors: ScrapeSelectors{
+			Rows: "table.rates tbody tr",
+			Rate: "td.buy",
+			Base: "td.currency",
+			Date: "span.date",
+		},
+		Source:     "Banco Ejemplo",
+		URL:        "https://www.bancoejemplo.com.ve/tasas",
+		Target:     "VES",
+		RateType:   "BUY",
+		Locale:     "comma",
+		DateFormat: "02/01/2006",
+		TimeZone:   "America/Caracas",
+		Interval:   "1h",
+	}
+}
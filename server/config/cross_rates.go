@@ -0,0 +1,75 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+const (
+	DefaultPivot       = "USD"
+	DefaultMaxAsOfSkew = "24h"
+)
+
+var (
+	ErrInvalidPivot       = errors.New("invalid pivot currency")
+	ErrInvalidMaxAsOfSkew = errors.New("invalid max as-of skew")
+)
+
+var currencyRegex = regexp.MustCompile(`^[A-Z]{3,4}$`)
+
+// CrossRates defines the cross-rate triangulation configuration
+type CrossRates struct {
+	// The pivot currency per source, for sources that only publish pairs against a single currency.
+	// Sources without an entry use the default pivot
+	SourcePivots map[string]string `toml:"source_pivots"`
+
+	// The pivot currency used for sources without a dedicated pivot
+	DefaultPivot string `toml:"default_pivot"`
+
+	// The maximum as-of distance between cross rate legs, as a Go duration (i.e.: 24h).
+	// Clients can explicitly allow a larger skew per request
+	MaxAsOfSkew string `toml:"max_as_of_skew"`
+}
+
+// DefaultCrossRatesConfig returns the default cross-rate configuration
+func DefaultCrossRatesConfig() *CrossRates {
+	return &CrossRates{
+		DefaultPivot: DefaultPivot,
+		SourcePivots: map[string]string{
+			"BCV": "VES",
+			"ECB": "EUR",
+		},
+		MaxAsOfSkew: DefaultMaxAsOfSkew,
+	}
+}
+
+// MaxAsOfSkewDuration returns the parsed max as-of skew
+func (c *CrossRates) MaxAsOfSkewDuration() (time.Duration, error) {
+	d, err := time.ParseDuration(c.MaxAsOfSkew)
+	if err != nil || d < 0 {
+		return 0, ErrInvalidMaxAsOfSkew
+	}
+
+	return d, nil
+}
+
+// validateCrossRatesConfig validates the cross-rate configuration
+func validateCrossRatesConfig(config *CrossRates) error {
+	if !currencyRegex.MatchString(config.DefaultPivot) {
+		return fmt.Errorf("%w: %q", ErrInvalidPivot, config.DefaultPivot)
+	}
+
+	for source, pivot := range config.SourcePivots {
+		if !currencyRegex.MatchString(pivot) {
+			return fmt.Errorf("%w: %q (source %q)", ErrInvalidPivot, pivot, source)
+		}
+	}
+
+	if _, err := config.MaxAsOfSkewDuration(); err != nil {
+		return err
+	}
+
+	return nil
+}
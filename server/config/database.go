@@ -0,0 +1,129 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultMaxConns          = 10
+	DefaultMinConns          = 1
+	DefaultMaxConnLifetime   = "1h"
+	DefaultMaxConnIdleTime   = "30m"
+	DefaultHealthCheckPeriod = "1m"
+	DefaultConnectTimeout    = "5s"
+	DefaultStartupTimeout    = "30s"
+)
+
+var (
+	ErrInvalidPoolSize         = errors.New("invalid DB pool size")
+	ErrInvalidDatabaseDuration = errors.New("invalid DB duration")
+)
+
+// Database defines the SQL database connection pool configuration.
+// Durations are Go durations (i.e.: 30s)
+type Database struct {
+	// The maximum lifetime of a pooled connection, after which it's replaced
+	MaxConnLifetime string `toml:"max_conn_lifetime"`
+
+	// The maximum time a pooled connection can stay idle, before it's closed
+	MaxConnIdleTime string `toml:"max_conn_idle_time"`
+
+	// The period at which idle connections are health-checked
+	HealthCheckPeriod string `toml:"health_check_period"`
+
+	// The timeout for establishing a single connection
+	ConnectTimeout string `toml:"connect_timeout"`
+
+	// How long to keep retrying to reach the DB on startup
+	StartupTimeout string `toml:"startup_timeout"`
+
+	// The maximum number of open connections
+	MaxConns int32 `toml:"max_conns"`
+
+	// The minimum number of open connections, kept warm
+	MinConns int32 `toml:"min_conns"`
+}
+
+// DefaultDatabaseConfig returns the default database configuration
+func DefaultDatabaseConfig() *Database {
+	return &Database{
+		MaxConns:          DefaultMaxConns,
+		MinConns:          DefaultMinConns,
+		MaxConnLifetime:   DefaultMaxConnLifetime,
+		MaxConnIdleTime:   DefaultMaxConnIdleTime,
+		HealthCheckPeriod: DefaultHealthCheckPeriod,
+		ConnectTimeout:    DefaultConnectTimeout,
+		StartupTimeout:    DefaultStartupTimeout,
+	}
+}
+
+// MaxConnLifetimeDuration returns the parsed max connection lifetime
+func (d *Database) MaxConnLifetimeDuration() (time.Duration, error) {
+	return parseDatabaseDuration("max_conn_lifetime", d.MaxConnLifetime)
+}
+
+// MaxConnIdleTimeDuration returns the parsed max connection idle time
+func (d *Database) MaxConnIdleTimeDuration() (time.Duration, error) {
+	return parseDatabaseDuration("max_conn_idle_time", d.MaxConnIdleTime)
+}
+
+// HealthCheckPeriodDuration returns the parsed health check period
+func (d *Database) HealthCheckPeriodDuration() (time.Duration, error) {
+	return parseDatabaseDuration("health_check_period", d.HealthCheckPeriod)
+}
+
+// ConnectTimeoutDuration returns the parsed connect timeout
+func (d *Database) ConnectTimeoutDuration() (time.Duration, error) {
+	return parseDatabaseDuration("connect_timeout", d.ConnectTimeout)
+}
+
+// StartupTimeoutDuration returns the parsed startup timeout
+func (d *Database) StartupTimeoutDuration() (time.Duration, error) {
+	return parseDatabaseDuration("startup_timeout", d.StartupTimeout)
+}
+
+// parseDatabaseDuration parses the duration. Unset durations are 0
+func parseDatabaseDuration(name, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%w: %s = %q", ErrInvalidDatabaseDuration, name, value)
+	}
+
+	return d, nil
+}
+
+// validateDatabaseConfig validates the database configuration
+func validateDatabaseConfig(config *Database) error {
+	if config.MaxConns < 0 || config.MinConns < 0 {
+		return fmt.Errorf("%w: connection counts can't be negative", ErrInvalidPoolSize)
+	}
+
+	if config.MaxConns > 0 && config.MinConns > config.MaxConns {
+		return fmt.Errorf(
+			"%w: min_conns (%d) exceeds max_conns (%d)",
+			ErrInvalidPoolSize,
+			config.MinConns,
+			config.MaxConns,
+		)
+	}
+
+	for _, parse := range []func() (time.Duration, error){
+		config.MaxConnLifetimeDuration,
+		config.MaxConnIdleTimeDuration,
+		config.HealthCheckPeriodDuration,
+		config.ConnectTimeoutDuration,
+		config.StartupTimeoutDuration,
+	} {
+		if _, err := parse(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sig-0/fxrates/provider/ecb"
+	"github.com/sig-0/fxrates/provider/p2p"
+	"github.com/sig-0/fxrates/provider/ves"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+const (
+	DefaultMaxRateDeviation           = 0.5
+	DefaultMaxAsOfLead                = "168h"
+	DefaultRateDeviationConfirmations = 3
+)
+
+var (
+	ErrInvalidRateDeviation = errors.New("invalid max rate deviation")
+	ErrInvalidMaxAsOfLead   = errors.New("invalid max as-of lead")
+
+	ErrInvalidDeviationConfirmations = errors.New("invalid rate deviation confirmations")
+
+	ErrDuplicateSource       = errors.New("duplicate provider source")
+	ErrDuplicateProviderName = errors.New("duplicate provider name")
+)
+
+// defaultProviders are the names and sources of the providers
+// registered by default, other than the P2P markets
+var defaultProviders = []struct {
+	name   string
+	source types.Source
+}{
+	{name: "BCV", source: ves.BCVSource},
+	{name: "BCV Banks"}, // sourced by bank
+	{name: "ECB", source: ecb.Source},
+}
+
+// Ingest defines the ingestion pipeline configuration.
+// Fetched rates failing validation are quarantined instead of saved
+type Ingest struct {
+	// The max rate deviation per provider name, for providers that need a different threshold
+	ProviderMaxRateDeviation map[string]float64 `toml:"provider_max_rate_deviation"`
+
+	// Additional Binance P2P markets, registered along the default USDT/VES one
+	BinanceP2P []*P2PMarket `toml:"binance_p2p"`
+
+	// Additional Bybit P2P markets, registered along the default USDT/VES one
+	BybitP2P []*P2PMarket `toml:"bybit_p2p"`
+
+	// Additional OKX P2P markets, registered along the default USDT/VES one
+	OKXP2P []*P2PMarket `toml:"okx_p2p"`
+
+	// Additional providers scraping simple HTML pages, defined by their CSS selectors
+	Scrape []*ScrapeSource `toml:"scrape"`
+
+	// How far ahead of its fetch time a rate can be effective, as a Go duration (i.e.: 168h).
+	// Empty disables the check
+	MaxAsOfLead string `toml:"max_as_of_lead"`
+
+	// The maximum relative deviation of a fetched rate from the previous stored rate
+	// of the same series (i.e.: 0.5 allows ±50%). 0 disables the check
+	MaxRateDeviation float64 `toml:"max_rate_deviation"`
+
+	// The number of consecutive fetches of a series, within the max rate deviation of each other,
+	// that confirm a move beyond it (i.e. a devaluation). 0 keeps such moves quarantined
+	RateDeviationConfirmations int `toml:"rate_deviation_confirmations"`
+}
+
+// DefaultIngestConfig returns the default ingestion configuration
+func DefaultIngestConfig() *Ingest {
+	return &Ingest{
+		MaxRateDeviation:           DefaultMaxRateDeviation,
+		MaxAsOfLead:                DefaultMaxAsOfLead,
+		RateDeviationConfirmations: DefaultRateDeviationConfirmations,
+	}
+}
+
+// MaxAsOfLeadDuration returns the parsed max as-of lead. Unset leads are 0
+func (c *Ingest) MaxAsOfLeadDuration() (time.Duration, error) {
+	if c.MaxAsOfLead == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(c.MaxAsOfLead)
+	if err != nil || d < 0 {
+		return 0, ErrInvalidMaxAsOfLead
+	}
+
+	return d, nil
+}
+
+// validateIngestConfig validates the ingestion configuration
+func validateIngestConfig(config *Ingest) error {
+	if config.MaxRateDeviation < 0 {
+		return fmt.Errorf("%w: %v", ErrInvalidRateDeviation, config.MaxRateDeviation)
+	}
+
+	for provider, deviation := range config.ProviderMaxRateDeviation {
+		if deviation < 0 {
+			return fmt.Errorf("%w: %v (provider %q)", ErrInvalidRateDeviation, deviation, provider)
+		}
+	}
+
+	if config.RateDeviationConfirmations < 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidDeviationConfirmations, config.RateDeviationConfirmations)
+	}
+
+	if _, err := config.MaxAsOfLeadDuration(); err != nil {
+		return err
+	}
+
+	if err := validateP2PMarkets(config.BinanceP2P, config.BybitP2P, config.OKXP2P); err != nil {
+		return err
+	}
+
+	if err := validateScrapeSources(config.Scrape); err != nil {
+		return err
+	}
+
+	return validateProviderIdentities(config)
+}
+
+// validateProviderIdentities validates that the configured providers,
+// along with the default ones, have distinct names and sources
+func validateProviderIdentities(config *Ingest) error {
+	var (
+		names   = make(map[string]struct{})
+		sources = make(map[types.Source]struct{})
+	)
+
+	// add registers the provider identity, if it's not taken
+	add := func(name string, source types.Source) error {
+		if _, ok := names[name]; ok {
+			return fmt.Errorf("%w: %q", ErrDuplicateProviderName, name)
+		}
+
+		names[name] = struct{}{}
+
+		if source == "" {
+			return nil
+		}
+
+		if _, ok := sources[source]; ok {
+			return fmt.Errorf("%w: %q", ErrDuplicateSource, source)
+		}
+
+		sources[source] = struct{}{}
+
+		return nil
+	}
+
+	for _, provider := range defaultProviders {
+		if err := add(provider.name, provider.source); err != nil {
+			return err
+		}
+	}
+
+	for _, exchange := range []struct {
+		name    string
+		source  types.Source
+		markets []*P2PMarket
+	}{
+		{ves.BinanceP2PExchange, ves.BinanceP2PSource, config.BinanceP2P},
+		{ves.BybitP2PExchange, ves.BybitP2PSource, config.BybitP2P},
+		{ves.OKXP2PExchange, ves.OKXP2PSource, config.OKXP2P},
+	} {
+		// The default market is always registered.
+		// The names are derived as by the providers
+		defaultMarket := p2p.NewConfig(exchange.name, "", exchange.source)
+		if err := add(defaultMarket.Name, defaultMarket.Source); err != nil {
+			return err
+		}
+
+		for _, market := range exchange.markets {
+			c := p2p.NewConfig(
+				exchange.name,
+				"",
+				types.Source(market.Source),
+				p2p.WithName(market.Name),
+				p2p.WithMarket(types.Currency(market.Asset), types.Currency(market.Fiat)),
+				p2p.WithPayTypes(market.PayTypes...),
+			)
+
+			if err := add(c.Name, c.Source); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, source := range config.Scrape {
+		name := source.Name
+		if name == "" {
+			name = source.Source
+		}
+
+		if err := add(name, types.Source(source.Source)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrInvalidP2PMarket      = errors.New("invalid P2P market")
+	ErrInvalidP2PAggregation = errors.New("invalid P2P aggregation")
+	ErrInvalidP2PFilter      = errors.New("invalid P2P filter")
+	ErrDuplicateP2PSource    = errors.New("duplicate P2P source")
+)
+
+// p2pAggregations are the supported P2P aggregation statistics
+var p2pAggregations = map[string]struct{}{
+	"median":          {},
+	"trimmed_mean":    {},
+	"volume_weighted": {},
+	"depth":           {},
+}
+
+// P2PMarket defines an additional P2P exchange market provider.
+// Unset values fall back to the provider defaults
+type P2PMarket struct {
+	// The offer payment methods, as identified by the exchange
+	// (i.e.: PagoMovil on Binance, 14 on Bybit, Pago Movil on OKX). Empty considers all methods
+	PayTypes []string `toml:"pay_types"`
+
+	// The provider name. Derived from the market and payment methods if unset
+	Name string `toml:"name"`
+
+	// The source of the fetched rates. Needs to be distinct per market
+	Source string `toml:"source"`
+
+	// The traded asset (i.e.: USDT)
+	Asset string `toml:"asset"`
+
+	// The fiat currency (i.e.: ARS)
+	Fiat string `toml:"fiat"`
+
+	// The aggregation statistic of the best offer prices (median, trimmed_mean or volume_weighted),
+	// or depth, the effective price to fill the notional amount
+	Aggregation string `toml:"aggregation"`
+
+	// The amount filled by the depth aggregation, in the asset
+	Notional float64 `toml:"notional"`
+
+	// The minimum monthly order completion rate [0, 1] of the advertisers
+	MinFinishRate float64 `toml:"min_finish_rate"`
+
+	// The minimum available amount of the offers, in the asset
+	MinAvailable float64 `toml:"min_available"`
+
+	// The typical transaction amount that needs to be within the offer limits
+	TypicalAmount float64 `toml:"typical_amount"`
+
+	// The minimum number of monthly orders of the advertisers
+	MinOrders int `toml:"min_orders"`
+
+	// The number of offer pages fetched per trade type
+	Pages int `toml:"pages"`
+
+	// The number of offers per page
+	Rows int `toml:"rows"`
+
+	// The number of best offers the rate is computed from
+	TopOffers int `toml:"top_offers"`
+}
+
+// validateP2PMarkets validates the P2P market configurations.
+// The sources need to be distinct across all exchanges
+func validateP2PMarkets(markets ...[]*P2PMarket) error {
+	sources := make(map[string]struct{})
+
+	for _, market := range slices.Concat(markets...) {
+		if market.Source == "" {
+			return fmt.Errorf("%w: missing source (%s/%s)", ErrInvalidP2PMarket, market.Asset, market.Fiat)
+		}
+
+		if _, ok := sources[market.Source]; ok {
+			return fmt.Errorf("%w: %q", ErrDuplicateP2PSource, market.Source)
+		}
+
+		sources[market.Source] = struct{}{}
+
+		if !currencyRegex.MatchString(market.Asset) || !currencyRegex.MatchString(market.Fiat) {
+			return fmt.Errorf(
+				"%w: %q/%q (source %q)",
+				ErrInvalidP2PMarket,
+				market.Asset,
+				market.Fiat,
+				market.Source,
+			)
+		}
+
+		if _, ok := p2pAggregations[market.Aggregation]; market.Aggregation != "" && !ok {
+			return fmt.Errorf("%w: %q (source %q)", ErrInvalidP2PAggregation, market.Aggregation, market.Source)
+		}
+
+		if market.MinFinishRate < 0 || market.MinFinishRate > 1 ||
+			market.MinAvailable < 0 ||
+			market.Notional < 0 ||
+			market.TypicalAmount < 0 ||
+			market.MinOrders < 0 ||
+			market.Pages < 0 ||
+			market.Rows < 0 ||
+			market.TopOffers < 0 {
+			return fmt.Errorf("%w: negative or out of range value (source %q)", ErrInvalidP2PFilter, market.Source)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,201 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/andybalholm/cascadia"
+)
+
+var (
+	ErrInvalidScrapeSource   = errors.New("invalid scrape source")
+	ErrInvalidScrapeSelector = errors.New("invalid scrape selector")
+	ErrInvalidScrapeLocale   = errors.New("invalid scrape number locale")
+	ErrInvalidScrapeDate     = errors.New("invalid scrape date format or time zone")
+	ErrInvalidScrapeInterval = errors.New("invalid scrape interval")
+	ErrDuplicateScrapeSource = errors.New("duplicate scrape source")
+)
+
+// scrapeLocales are the supported number locales of the scraped rates
+var scrapeLocales = map[string]struct{}{
+	"dot":   {},
+	"comma": {},
+}
+
+// scrapeRateTypes are the supported rate types of the scraped rates
+var scrapeRateTypes = map[string]struct{}{
+	"MID":  {},
+	"BUY":  {},
+	"SELL": {},
+}
+
+// ScrapeSelectors defines the CSS selectors of a scraped source.
+// Except for the rows, they are relative to a row, falling back to the whole page
+type ScrapeSelectors struct {
+	// The rate rows (i.e.: table.rates tbody tr). Each row yields a single rate
+	Rows string `toml:"rows"`
+
+	// The rate number (i.e.: td.buy)
+	Rate string `toml:"rate"`
+
+	// The base currency code. Empty uses the fixed base
+	Base string `toml:"base"`
+
+	// The target currency code. Empty uses the fixed target
+	Target string `toml:"target"`
+
+	// The effective date. Empty uses the fetch time
+	Date string `toml:"date"`
+}
+
+// ScrapeSource defines a generic HTML scraping provider
+type ScrapeSource struct {
+	// The CSS selectors of the scraped values
+	Selectors ScrapeSelectors `toml:"selectors"`
+
+	// The provider name. The source if unset
+	Name string `toml:"name"`
+
+	// The source of the fetched rates. Needs to be distinct per scraped source
+	Source string `toml:"source"`
+
+	// The scraped page URL
+	URL string `toml:"url"`
+
+	// The fixed base currency, if there is no base selector (i.e.: USD)
+	Base string `toml:"base"`
+
+	// The fixed target currency, if there is no target selector (i.e.: VES)
+	Target string `toml:"target"`
+
+	// The rate type of the scraped rates (MID, BUY or SELL)
+	RateType string `toml:"rate_type"`
+
+	// The number locale of the rates: dot (1,234.56, default) or comma (1.234,56) decimals
+	Locale string `toml:"locale"`
+
+	// The Go time layout of the dates (i.e.: 02/01/2006). Required with a date selector
+	DateFormat string `toml:"date_format"`
+
+	// The IANA time zone of the dates (i.e.: America/Caracas). Empty is UTC
+	TimeZone string `toml:"time_zone"`
+
+	// The fetch interval, as a Go duration (i.e.: 1h). Empty uses the provider default
+	Interval string `toml:"interval"`
+}
+
+// IntervalDuration returns the parsed fetch interval. Unset intervals are 0
+func (s *ScrapeSource) IntervalDuration() (time.Duration, error) {
+	if s.Interval == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(s.Interval)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("%w: %q (source %q)", ErrInvalidScrapeInterval, s.Interval, s.Source)
+	}
+
+	return d, nil
+}
+
+// Location returns the time zone of the dates. Unset time zones are UTC
+func (s *ScrapeSource) Location() (*time.Location, error) {
+	loc, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q (source %q)", ErrInvalidScrapeDate, s.TimeZone, s.Source)
+	}
+
+	return loc, nil
+}
+
+// validateScrapeSources validates the scraped source configurations
+func validateScrapeSources(sources []*ScrapeSource) error {
+	seen := make(map[string]struct{}, len(sources))
+
+	for _, source := range sources {
+		if source.Source == "" {
+			return fmt.Errorf("%w: missing source (%s)", ErrInvalidScrapeSource, source.URL)
+		}
+
+		if _, ok := seen[source.Source]; ok {
+			return fmt.Errorf("%w: %q", ErrDuplicateScrapeSource, source.Source)
+		}
+
+		seen[source.Source] = struct{}{}
+
+		if err := validateScrapeSource(source); err != nil {
+			return fmt.Errorf("%w (source %q)", err, source.Source)
+		}
+
+		if _, err := source.IntervalDuration(); err != nil {
+			return err
+		}
+
+		if _, err := source.Location(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateScrapeSource validates a single scraped source definition
+func validateScrapeSource(source *ScrapeSource) error {
+	u, err := url.Parse(source.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: invalid URL %q", ErrInvalidScrapeSource, source.URL)
+	}
+
+	if _, ok := scrapeRateTypes[source.RateType]; !ok {
+		return fmt.Errorf("%w: invalid rate type %q", ErrInvalidScrapeSource, source.RateType)
+	}
+
+	if _, ok := scrapeLocales[source.Locale]; source.Locale != "" && !ok {
+		return fmt.Errorf("%w: %q", ErrInvalidScrapeLocale, source.Locale)
+	}
+
+	sel := source.Selectors
+
+	if sel.Rows == "" || sel.Rate == "" {
+		return fmt.Errorf("%w: missing rows or rate selector", ErrInvalidScrapeSelector)
+	}
+
+	for _, selector := range []string{sel.Rows, sel.Rate, sel.Base, sel.Target, sel.Date} {
+		if selector == "" {
+			continue
+		}
+
+		if _, err := cascadia.Parse(selector); err != nil {
+			return fmt.Errorf("%w: %q: %w", ErrInvalidScrapeSelector, selector, err)
+		}
+	}
+
+	// Each currency is either scraped or fixed
+	for _, it := range []struct {
+		selector string
+		fixed    string
+	}{
+		{sel.Base, source.Base},
+		{sel.Target, source.Target},
+	} {
+		if it.selector != "" {
+			continue
+		}
+
+		if it.fixed == "" {
+			return fmt.Errorf("%w: missing currency selector or fixed currency", ErrInvalidScrapeSelector)
+		}
+
+		if !currencyRegex.MatchString(it.fixed) {
+			return fmt.Errorf("%w: invalid fixed currency %q", ErrInvalidScrapeSource, it.fixed)
+		}
+	}
+
+	if sel.Date != "" && source.DateFormat == "" {
+		return fmt.Errorf("%w: missing date format", ErrInvalidScrapeDate)
+	}
+
+	return nil
+}
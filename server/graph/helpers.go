@@ -2,9 +2,13 @@ package graph
 
 import (
 	"errors"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/sig-0/fxrates/fx"
+	"github.com/sig-0/fxrates/ingest"
 	"github.com/sig-0/fxrates/server/graph/model"
 	"github.com/sig-0/fxrates/storage/types"
 )
@@ -12,6 +16,8 @@ import (
 const (
 	defaultLimit = int32(100)
 	maxLimit     = int32(500)
+
+	defaultHistoryWindow = time.Hour * 24 * 30
 )
 
 var (
@@ -19,6 +25,9 @@ var (
 	errInvalidOffset = errors.New("invalid offset")
 	errInvalidType   = errors.New("invalid type")
 	errInvalidCcy    = errors.New("invalid currency (must be 3-4 letters A-Z)")
+
+	errInvalidRange    = errors.New("invalid range (from must be before to)")
+	errInvalidInterval = errors.New("invalid interval (must be a positive duration, e.g. 1h)")
 )
 
 func parseAsOf(asOf *model.Time) time.Time {
@@ -29,6 +38,52 @@ func parseAsOf(asOf *model.Time) time.Time {
 	return time.Time(*asOf).UTC()
 }
 
+func parseKnownAt(knownAt *model.Time) *time.Time {
+	if knownAt == nil {
+		return nil // latest revision
+	}
+
+	t := time.Time(*knownAt).UTC()
+
+	return &t
+}
+
+func parseTimeRange(from, to *model.Time) (time.Time, time.Time, error) {
+	end := time.Now().UTC()
+	if to != nil {
+		end = time.Time(*to).UTC()
+	}
+
+	start := end.Add(-defaultHistoryWindow)
+	if from != nil {
+		start = time.Time(*from).UTC()
+	}
+
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, errInvalidRange
+	}
+
+	return start, end, nil
+}
+
+func parseInterval(interval *string) (time.Duration, error) {
+	if interval == nil {
+		return 0, nil
+	}
+
+	v := strings.TrimSpace(*interval)
+	if v == "" {
+		return 0, nil // no downsampling
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, errInvalidInterval
+	}
+
+	return d, nil
+}
+
 func parseLimitOffset(limit, offset *int32) (int32, int64, error) {
 	lim := defaultLimit
 
@@ -111,7 +166,99 @@ func toModelExchangeRate(in *types.ExchangeRate) *model.ExchangeRate {
 		RateType:  model.RateType(in.RateType.String()),
 		Source:    in.Source.String(),
 		Rate:      in.Rate,
+		RateExact: model.Decimal(in.Exact()),
+		Metadata:  toModelRateMetadata(in.Metadata),
+	}
+}
+
+// toModelRateMetadata converts the rate metadata to key-value pairs, sorted by key
+func toModelRateMetadata(in map[string]string) []*model.RateMetadata {
+	out := make([]*model.RateMetadata, 0, len(in))
+
+	for _, key := range slices.Sorted(maps.Keys(in)) {
+		out = append(out, &model.RateMetadata{
+			Key:   key,
+			Value: in[key],
+		})
 	}
+
+	return out
+}
+
+func parsePivot(pivot *string) (*types.Currency, error) {
+	if pivot == nil || strings.TrimSpace(*pivot) == "" {
+		return nil, nil //nolint:nilnil // no pivot override
+	}
+
+	p, err := parseCurrencySymbol(*pivot)
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func parseFlag(flag *bool) bool {
+	return flag != nil && *flag
+}
+
+func toModelConversion(in *fx.Conversion) *model.Conversion {
+	return &model.Conversion{
+		AsOf:        model.Time(in.AsOf),
+		FetchedAt:   model.Time(in.FetchedAt),
+		From:        in.From.String(),
+		To:          in.To.String(),
+		RateType:    model.RateType(in.RateType.String()),
+		Source:      in.Source.String(),
+		Amount:      in.Amount,
+		Result:      in.Result,
+		ResultExact: model.Decimal(in.ResultExact),
+		Rate:        in.Rate,
+		RateExact:   model.Decimal(in.RateExact),
+		Inverted:    in.Inverted,
+		Derived:     in.Derived,
+		Pivot:       toModelPivot(in.Pivot),
+		Legs:        toModelLegs(in.Legs),
+	}
+}
+
+func toModelQuote(in *fx.Quote) *model.Quote {
+	return &model.Quote{
+		AsOf:      model.Time(in.AsOf),
+		FetchedAt: model.Time(in.FetchedAt),
+		Base:      in.Base.String(),
+		Target:    in.Target.String(),
+		RateType:  model.RateType(in.RateType.String()),
+		Source:    in.Source.String(),
+		Rate:      in.Rate,
+		RateExact: model.Decimal(in.RateExact),
+		Inverted:  in.Inverted,
+		Derived:   in.Derived,
+		Pivot:     toModelPivot(in.Pivot),
+		Legs:      toModelLegs(in.Legs),
+	}
+}
+
+func toModelPivot(in *types.Currency) *string {
+	if in == nil {
+		return nil
+	}
+
+	p := in.String()
+
+	return &p
+}
+
+func toModelLegs(in []*fx.Leg) []*model.QuoteLeg {
+	out := make([]*model.QuoteLeg, 0, len(in))
+	for _, leg := range in {
+		out = append(out, &model.QuoteLeg{
+			Rate:     toModelExchangeRate(leg.Rate),
+			Inverted: leg.Inverted,
+		})
+	}
+
+	return out
 }
 
 func clampTotalToInt32(total int64) int32 {
@@ -126,3 +273,42 @@ func clampTotalToInt32(total int64) int32 {
 
 	return int32(total)
 }
+
+func toModelProviderStatus(in *ingest.ProviderStatus) *model.ProviderStatus {
+	out := &model.ProviderStatus{
+		Name:                in.Name,
+		LastAttempt:         toModelTimePtr(in.LastAttempt),
+		LastSuccess:         toModelTimePtr(in.LastSuccess),
+		NextRun:             toModelTimePtr(in.NextRun),
+		ConsecutiveFailures: clampTotalToInt32(int64(in.ConsecutiveFailures)),
+		RatesSaved:          clampTotalToInt32(int64(in.RatesSaved)),
+		RatesRejected:       clampTotalToInt32(int64(in.RatesRejected)),
+		Paused:              in.Paused,
+		Quarantined:         make([]*model.QuarantinedRate, 0, len(in.Quarantined)),
+	}
+
+	for _, q := range in.Quarantined {
+		out.Quarantined = append(out.Quarantined, &model.QuarantinedRate{
+			RejectedAt: model.Time(q.RejectedAt),
+			Reason:     q.Reason,
+			Rate:       toModelExchangeRate(q.Rate),
+		})
+	}
+
+	if in.LastError != "" {
+		lastErr := in.LastError
+		out.LastError = &lastErr
+	}
+
+	return out
+}
+
+func toModelTimePtr(in *time.Time) *model.Time {
+	if in == nil {
+		return nil
+	}
+
+	t := model.Time(*in)
+
+	return &t
+}
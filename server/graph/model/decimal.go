@@ -0,0 +1,40 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/99designs/gqlgen/graphql"
+
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+type Decimal types.Decimal
+
+func MarshalDecimal(d Decimal) graphql.Marshaler {
+	return graphql.MarshalString(types.Decimal(d).String())
+}
+
+func UnmarshalDecimal(v any) (Decimal, error) {
+	var s string
+
+	switch value := v.(type) {
+	case string:
+		s = value
+	case json.Number:
+		s = value.String()
+	case int:
+		s = strconv.Itoa(value)
+	case int64:
+		s = strconv.FormatInt(value, 10)
+	case float64:
+		return Decimal(types.NewDecimalFromFloat(value)), nil
+	default:
+		return Decimal{}, fmt.Errorf("%T is not a decimal", v)
+	}
+
+	d, err := types.ParseDecimal(s)
+
+	return Decimal(d), err
+}
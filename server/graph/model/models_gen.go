@@ -9,6 +9,40 @@ import (
 	"strconv"
 )
 
+// The result of a currency conversion, along with the provenance of the rate used.
+type Conversion struct {
+	// Effective date/time of the rate used.
+	AsOf Time `json:"as_of"`
+	// Time when the rate used was fetched/observed by the system.
+	FetchedAt Time `json:"fetched_at"`
+	// Currency the amount was converted from.
+	From string `json:"from"`
+	// Currency the amount was converted to.
+	To string `json:"to"`
+	// Classification of the rate used (MID/BUY/SELL).
+	RateType RateType `json:"rate_type"`
+	// Provider/source identifier of the rate used, e.g. "BCV".
+	Source string `json:"source"`
+	// The requested amount, in the `from` currency.
+	Amount float64 `json:"amount"`
+	// The converted amount, in the `to` currency, as a float approximation. Prefer result_exact.
+	Result float64 `json:"result"`
+	// Exact converted amount, in the `to` currency, rounded to 18 decimal places.
+	ResultExact Decimal `json:"result_exact"`
+	// Effective rate applied from -> to, as a float approximation. Prefer rate_exact.
+	Rate float64 `json:"rate"`
+	// Exact effective rate applied from -> to.
+	RateExact Decimal `json:"rate_exact"`
+	// Set if the rate was derived by inverting the stored to -> from pair.
+	Inverted bool `json:"inverted"`
+	// Set if the rate was triangulated through a pivot currency.
+	Derived bool `json:"derived"`
+	// The pivot currency, if derived.
+	Pivot *string `json:"pivot,omitempty"`
+	// The stored rates used to resolve the conversion rate.
+	Legs []*QuoteLeg `json:"legs"`
+}
+
 // A single observed exchange rate data point.
 type ExchangeRate struct {
 	// Effective date/time for which this rate applies.
@@ -23,8 +57,12 @@ type ExchangeRate struct {
 	RateType RateType `json:"rate_type"`
 	// Provider/source identifier, e.g. "BCV".
 	Source string `json:"source"`
-	// Quoted rate from base -> target.
+	// Quoted rate from base -> target, as a float approximation. Prefer rate_exact.
 	Rate float64 `json:"rate"`
+	// Exact quoted rate from base -> target.
+	RateExact Decimal `json:"rate_exact"`
+	// Provider-specific details of the rate (e.g. the P2P order book depth), sorted by key.
+	Metadata []*RateMetadata `json:"metadata"`
 }
 
 // A paginated collection of exchange rates.
@@ -35,9 +73,90 @@ type ExchangeRatePage struct {
 	Total int32 `json:"total"`
 }
 
+// The run status of a registered ingestion provider.
+type ProviderStatus struct {
+	// Unique provider name, e.g. "BCV".
+	Name string `json:"name"`
+	// Start of the latest fetch, if any.
+	LastAttempt *Time `json:"last_attempt,omitempty"`
+	// End of the latest successful fetch, if any.
+	LastSuccess *Time `json:"last_success,omitempty"`
+	// Error of the latest failed fetch, if any.
+	LastError *string `json:"last_error,omitempty"`
+	// Number of consecutive failed fetches (reset on success).
+	ConsecutiveFailures int32 `json:"consecutive_failures"`
+	// Number of rates saved on the latest successful fetch.
+	RatesSaved int32 `json:"rates_saved"`
+	// Number of rates rejected by validation on the latest fetch.
+	RatesRejected int32 `json:"rates_rejected"`
+	// Latest rates rejected by validation, newest first.
+	Quarantined []*QuarantinedRate `json:"quarantined"`
+	// Set if the provider is paused by an operator.
+	Paused bool `json:"paused"`
+	// Next scheduled fetch. Omitted while a fetch is running, or the provider is paused.
+	NextRun *Time `json:"next_run,omitempty"`
+}
+
+// A fetched rate rejected by validation (i.e. a rate 1000x off the previous one), which was not saved.
+type QuarantinedRate struct {
+	// When the rate was rejected.
+	RejectedAt Time `json:"rejected_at"`
+	// Why the rate was rejected.
+	Reason string `json:"reason"`
+	// The rejected rate.
+	Rate *ExchangeRate `json:"rate"`
+}
+
 type Query struct {
 }
 
+// A resolved base -> target rate, along with the stored rates used to derive it.
+type Quote struct {
+	// Effective date/time of the quote (the oldest leg, if derived).
+	AsOf Time `json:"as_of"`
+	// Time when the quote's oldest leg was fetched/observed by the system.
+	FetchedAt Time `json:"fetched_at"`
+	// Base currency (ISO 4217 or 4-letter crypto/stablecoin), e.g. "EUR".
+	Base string `json:"base"`
+	// Target currency (ISO 4217 or 4-letter crypto/stablecoin), e.g. "USD".
+	Target string `json:"target"`
+	// Rate classification (MID/BUY/SELL).
+	RateType RateType `json:"rate_type"`
+	// Provider/source identifier, e.g. "BCV". Mixed-source quotes join the leg sources with "+".
+	Source string `json:"source"`
+	// Resolved rate from base -> target, as a float approximation. Prefer rate_exact.
+	Rate float64 `json:"rate"`
+	// Exact resolved rate from base -> target, rounded to 18 decimal places if inverted or derived.
+	RateExact Decimal `json:"rate_exact"`
+	// Set if the rate is the inverse of the stored target -> base pair.
+	Inverted bool `json:"inverted"`
+	// Set if the rate was triangulated through a pivot currency.
+	Derived bool `json:"derived"`
+	// The pivot currency, if derived.
+	Pivot *string `json:"pivot,omitempty"`
+	// The stored rates used to resolve the quote.
+	Legs []*QuoteLeg `json:"legs"`
+}
+
+// A single stored rate used to resolve a quote.
+type QuoteLeg struct {
+	// The stored rate.
+	Rate *ExchangeRate `json:"rate"`
+	// Set if the stored rate was inverted.
+	Inverted bool `json:"inverted"`
+}
+
+// A single provider-specific detail of a rate.
+type RateMetadata struct {
+	// Detail name, e.g. "depth".
+	Key string `json:"key"`
+	// Detail value, e.g. "8450.5".
+	Value string `json:"value"`
+}
+
+type Subscription struct {
+}
+
 // Classifies the kind of rate being reported.
 type RateType string
 
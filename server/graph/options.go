@@ -0,0 +1,32 @@
+package graph
+
+import (
+	"github.com/sig-0/fxrates/fx"
+	"github.com/sig-0/fxrates/ingest"
+)
+
+type Option func(r *Resolver)
+
+// WithConverter specifies the rate converter for the resolvers.
+// Defaults to a converter with the default cross-rate settings
+func WithConverter(c *fx.Converter) Option {
+	return func(r *Resolver) {
+		r.Converter = c
+	}
+}
+
+// WithProviders specifies the provider run status source for the resolvers.
+// If omitted, no providers are listed
+func WithProviders(p ingest.StatusReader) Option {
+	return func(r *Resolver) {
+		r.Providers = p
+	}
+}
+
+// WithEvents specifies the ingestion event source for the subscriptions.
+// If omitted, subscriptions are rejected
+func WithEvents(e ingest.EventSubscriber) Option {
+	return func(r *Resolver) {
+		r.Events = e
+	}
+}
@@ -9,12 +9,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/sig-0/fxrates/fx"
 	"github.com/sig-0/fxrates/server/graph/model"
 	"github.com/sig-0/fxrates/storage/types"
 )
 
 // Rates is the resolver for the rates field.
-func (r *queryResolver) Rates(ctx context.Context, base string, target *string, asOf *model.Time, source *string, typeArg *model.RateType, limit *int32, offset *int32) (*model.ExchangeRatePage, error) {
+func (r *queryResolver) Rates(ctx context.Context, base string, target *string, asOf *model.Time, knownAt *model.Time, source *string, typeArg *model.RateType, limit *int32, offset *int32) (*model.ExchangeRatePage, error) {
 	b, err := parseCurrencySymbol(base)
 	if err != nil {
 		return nil, err
@@ -40,6 +41,7 @@ func (r *queryResolver) Rates(ctx context.Context, base string, target *string,
 	}
 
 	q := &types.RateQuery{
+		KnownAt:  parseKnownAt(knownAt),
 		Base:     b,
 		Target:   tgt,
 		Source:   src,
@@ -64,6 +66,151 @@ func (r *queryResolver) Rates(ctx context.Context, base string, target *string,
 	}, nil
 }
 
+// History is the resolver for the history field.
+func (r *queryResolver) History(ctx context.Context, base string, target string, from *model.Time, to *model.Time, interval *string, source *string, typeArg *model.RateType, limit *int32, offset *int32) (*model.ExchangeRatePage, error) {
+	b, err := parseCurrencySymbol(base)
+	if err != nil {
+		return nil, err
+	}
+
+	tgt, err := parseCurrencySymbol(target)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := parseTimeRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	step, err := parseInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+
+	lim, off, err := parseLimitOffset(limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	src, rt, err := parseSourceAndType(source, typeArg)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &types.HistoryQuery{
+		Base:     b,
+		Target:   tgt,
+		Source:   src,
+		RateType: rt,
+		From:     start,
+		To:       end,
+		Interval: step,
+		Limit:    lim,
+		Offset:   off,
+	}
+
+	page, err := r.Resolver.Storage.RateHistory(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch rate history: %w", err)
+	}
+
+	out := make([]*model.ExchangeRate, 0, len(page.Results))
+	for _, it := range page.Results {
+		out = append(out, toModelExchangeRate(it))
+	}
+
+	return &model.ExchangeRatePage{
+		Results: out,
+		Total:   clampTotalToInt32(page.Total),
+	}, nil
+}
+
+// Convert is the resolver for the convert field.
+func (r *queryResolver) Convert(ctx context.Context, from string, to string, amount float64, asOf *model.Time, source *string, typeArg *model.RateType, pivot *string, allowMixedSources *bool, allowAsOfSkew *bool) (*model.Conversion, error) {
+	f, err := parseCurrencySymbol(from)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := parseCurrencySymbol(to)
+	if err != nil {
+		return nil, err
+	}
+
+	src, rt, err := parseSourceAndType(source, typeArg)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := parsePivot(pivot)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &fx.ConversionRequest{
+		RateRequest: fx.RateRequest{
+			Base:              f,
+			Target:            t,
+			AsOf:              parseAsOf(asOf),
+			Source:            src,
+			RateType:          rt,
+			Pivot:             p,
+			AllowMixedSources: parseFlag(allowMixedSources),
+			AllowAsOfSkew:     parseFlag(allowAsOfSkew),
+		},
+		Amount: amount,
+	}
+
+	conversion, err := r.Resolver.Converter.Convert(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert amount: %w", err)
+	}
+
+	return toModelConversion(conversion), nil
+}
+
+// CrossRate is the resolver for the crossRate field.
+func (r *queryResolver) CrossRate(ctx context.Context, base string, target string, asOf *model.Time, source *string, typeArg *model.RateType, pivot *string, allowMixedSources *bool, allowAsOfSkew *bool) (*model.Quote, error) {
+	b, err := parseCurrencySymbol(base)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := parseCurrencySymbol(target)
+	if err != nil {
+		return nil, err
+	}
+
+	src, rt, err := parseSourceAndType(source, typeArg)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := parsePivot(pivot)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &fx.RateRequest{
+		Base:              b,
+		Target:            t,
+		AsOf:              parseAsOf(asOf),
+		Source:            src,
+		RateType:          rt,
+		Pivot:             p,
+		AllowMixedSources: parseFlag(allowMixedSources),
+		AllowAsOfSkew:     parseFlag(allowAsOfSkew),
+	}
+
+	quote, err := r.Resolver.Converter.Rate(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve rate: %w", err)
+	}
+
+	return toModelQuote(quote), nil
+}
+
 // Sources is the resolver for the sources field.
 func (r *queryResolver) Sources(ctx context.Context) ([]string, error) {
 	items, err := r.Resolver.Storage.ListSources(ctx)
@@ -94,6 +241,22 @@ func (r *queryResolver) Currencies(ctx context.Context) ([]string, error) {
 	return out, nil
 }
 
+// Providers is the resolver for the providers field.
+func (r *queryResolver) Providers(ctx context.Context) ([]*model.ProviderStatus, error) {
+	if r.Resolver.Providers == nil {
+		return []*model.ProviderStatus{}, nil
+	}
+
+	items := r.Resolver.Providers.Providers()
+
+	out := make([]*model.ProviderStatus, 0, len(items))
+	for _, it := range items {
+		out = append(out, toModelProviderStatus(it))
+	}
+
+	return out, nil
+}
+
 // Query returns QueryResolver implementation.
 func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 
@@ -1,6 +1,10 @@
 package graph
 
-import "github.com/sig-0/fxrates/storage"
+import (
+	"github.com/sig-0/fxrates/fx"
+	"github.com/sig-0/fxrates/ingest"
+	"github.com/sig-0/fxrates/storage"
+)
 
 // This file will not be regenerated automatically.
 //
@@ -8,11 +12,15 @@ import "github.com/sig-0/fxrates/storage"
 // here.
 
 type Resolver struct {
-	Storage storage.Storage
+	Storage   storage.Storage
+	Converter *fx.Converter
+	Providers ingest.StatusReader
+	Events    ingest.EventSubscriber
 }
 
 func NewResolver(s storage.Storage) *Resolver {
 	return &Resolver{
-		Storage: s,
+		Storage:   s,
+		Converter: fx.NewConverter(s),
 	}
 }
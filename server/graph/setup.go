@@ -15,10 +15,17 @@ import (
 )
 
 // Setup sets up the GraphQL server on the given mux
-func Setup(storage storage.Storage, m *chi.Mux) *chi.Mux {
+func Setup(storage storage.Storage, m *chi.Mux, opts ...Option) *chi.Mux {
+	resolver := NewResolver(storage)
+
+	// Apply the options
+	for _, opt := range opts {
+		opt(resolver)
+	}
+
 	srv := handler.New(NewExecutableSchema(
 		Config{
-			Resolvers: NewResolver(storage),
+			Resolvers: resolver,
 		},
 	))
 
@@ -0,0 +1,61 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.86
+
+import (
+	"context"
+
+	"github.com/sig-0/fxrates/ingest"
+	"github.com/sig-0/fxrates/server/graph/model"
+)
+
+// RateUpdated is the resolver for the rateUpdated field.
+func (r *subscriptionResolver) RateUpdated(ctx context.Context, base *string, target *string, source *string, typeArg *model.RateType) (<-chan *model.ExchangeRate, error) {
+	if r.Resolver.Events == nil {
+		return nil, errSubscriptionsUnavailable
+	}
+
+	filter, err := parseRateFilter(base, target, source, typeArg)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan *model.ExchangeRate, subscriptionBufferSize)
+
+	unsubscribe := r.Resolver.Events.OnRateSaved(func(e *ingest.RateSavedEvent) {
+		if filter.matches(e.Rate) {
+			send(ctx, out, toModelExchangeRate(e.Rate))
+		}
+	})
+
+	unsubscribeOnDone(ctx, unsubscribe)
+
+	return out, nil
+}
+
+// ProviderStatusChanged is the resolver for the providerStatusChanged field.
+func (r *subscriptionResolver) ProviderStatusChanged(ctx context.Context, name *string) (<-chan *model.ProviderStatus, error) {
+	if r.Resolver.Events == nil {
+		return nil, errSubscriptionsUnavailable
+	}
+
+	out := make(chan *model.ProviderStatus, subscriptionBufferSize)
+
+	unsubscribe := r.Resolver.Events.OnProviderStatusChanged(func(e *ingest.ProviderStatusChangedEvent) {
+		if name == nil || *name == e.Status.Name {
+			send(ctx, out, toModelProviderStatus(e.Status))
+		}
+	})
+
+	unsubscribeOnDone(ctx, unsubscribe)
+
+	return out, nil
+}
+
+// Subscription returns SubscriptionResolver implementation.
+func (r *Resolver) Subscription() SubscriptionResolver { return &subscriptionResolver{r} }
+
+type subscriptionResolver struct{ *Resolver }
@@ -0,0 +1,82 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sig-0/fxrates/server/graph/model"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+// subscriptionBufferSize is the number of updates buffered per subscription,
+// before the subscription starts missing events
+const subscriptionBufferSize = 16
+
+var errSubscriptionsUnavailable = errors.New("subscriptions are not available")
+
+// rateFilter matches saved rates against the rate subscription arguments
+type rateFilter struct {
+	base     *types.Currency
+	target   *types.Currency
+	source   *types.Source
+	rateType *types.RateType
+}
+
+func parseRateFilter(base, target, source *string, rt *model.RateType) (*rateFilter, error) {
+	f := &rateFilter{}
+
+	for _, it := range []struct {
+		in  *string
+		out **types.Currency
+	}{
+		{base, &f.base},
+		{target, &f.target},
+	} {
+		if it.in == nil {
+			continue
+		}
+
+		c, err := parseCurrencySymbol(*it.in)
+		if err != nil {
+			return nil, err
+		}
+
+		*it.out = &c
+	}
+
+	src, outRT, err := parseSourceAndType(source, rt)
+	if err != nil {
+		return nil, err
+	}
+
+	f.source, f.rateType = src, outRT
+
+	return f, nil
+}
+
+// matches returns true if the rate matches the filter
+func (f *rateFilter) matches(rate *types.ExchangeRate) bool {
+	return (f.base == nil || *f.base == rate.Base) &&
+		(f.target == nil || *f.target == rate.Target) &&
+		(f.source == nil || *f.source == rate.Source) &&
+		(f.rateType == nil || *f.rateType == rate.RateType)
+}
+
+// send delivers the update to the subscription, unless the subscription is done
+func send[T any](ctx context.Context, ch chan<- T, update T) {
+	select {
+	case <-ctx.Done():
+	case ch <- update:
+	}
+}
+
+// unsubscribeOnDone unsubscribes the handlers once the subscription is done
+func unsubscribeOnDone(ctx context.Context, unsubscribes ...func()) {
+	go func() {
+		<-ctx.Done()
+
+		for _, unsubscribe := range unsubscribes {
+			unsubscribe()
+		}
+	}()
+}
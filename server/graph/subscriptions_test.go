@@ -0,0 +1,225 @@
+package graph
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/ingest"
+	"github.com/sig-0/fxrates/provider/currencies"
+	"github.com/sig-0/fxrates/storage/mock"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+// mockEvents is an ingest.EventSubscriber with manually published events
+type mockEvents struct {
+	rateSaved  []func(*ingest.RateSavedEvent)
+	statuses   []func(*ingest.ProviderStatusChangedEvent)
+	subscribed chan struct{} // signaled on every handler subscription
+	mux        sync.Mutex
+}
+
+func newMockEvents() *mockEvents {
+	return &mockEvents{
+		subscribed: make(chan struct{}, 10),
+	}
+}
+
+func (m *mockEvents) OnRateSaved(handler func(*ingest.RateSavedEvent)) func() {
+	m.mux.Lock()
+	m.rateSaved = append(m.rateSaved, handler)
+	m.mux.Unlock()
+
+	m.subscribed <- struct{}{}
+
+	return func() {}
+}
+
+func (m *mockEvents) OnRatesSaved(func(*ingest.RatesSavedEvent)) func() {
+	return func() {}
+}
+
+func (m *mockEvents) OnProviderError(func(*ingest.ProviderErrorEvent)) func() {
+	return func() {}
+}
+
+func (m *mockEvents) OnProviderRecovered(func(*ingest.ProviderRecoveredEvent)) func() {
+	return func() {}
+}
+
+func (m *mockEvents) OnProviderStatusChanged(handler func(*ingest.ProviderStatusChangedEvent)) func() {
+	m.mux.Lock()
+	m.statuses = append(m.statuses, handler)
+	m.mux.Unlock()
+
+	m.subscribed <- struct{}{}
+
+	return func() {}
+}
+
+// publishRate publishes a rate saved event to all subscribers
+func (m *mockEvents) publishRate(provider string, rate *types.ExchangeRate) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	for _, handler := range m.rateSaved {
+		handler(&ingest.RateSavedEvent{Rate: rate, Provider: provider})
+	}
+}
+
+// publishStatus publishes a provider status change to all subscribers
+func (m *mockEvents) publishStatus(status *ingest.ProviderStatus) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	for _, handler := range m.statuses {
+		handler(&ingest.ProviderStatusChangedEvent{Status: status})
+	}
+}
+
+// waitSubscribed waits for the given number of handlers to be subscribed
+func (m *mockEvents) waitSubscribed(t *testing.T, handlers int) {
+	t.Helper()
+
+	for range handlers {
+		select {
+		case <-m.subscribed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("subscription not set up in time")
+		}
+	}
+}
+
+// newSubscriptionClient creates a new GraphQL client with the given subscription sources
+func newSubscriptionClient(events ingest.EventSubscriber, providers ingest.StatusReader) *client.Client {
+	mux := Setup(
+		&mock.Storage{},
+		chi.NewMux(),
+		WithEvents(events),
+		WithProviders(providers),
+	)
+
+	return client.New(mux, client.Path("/graphql/query"))
+}
+
+func TestSubscription_RateUpdated(t *testing.T) {
+	t.Parallel()
+
+	t.Run("filtered rates streamed", func(t *testing.T) {
+		t.Parallel()
+
+		events := newMockEvents()
+
+		sub := newSubscriptionClient(events, nil).Websocket(
+			`subscription { rateUpdated(base: "usd", source: "Binance P2P") { base target source rate_exact metadata { key value } } }`,
+		)
+		defer sub.Close()
+
+		events.waitSubscribed(t, 1)
+
+		events.publishRate("BCV", &types.ExchangeRate{
+			Base:      currencies.USD,
+			Target:    currencies.VES,
+			Source:    "BCV",
+			RateExact: types.MustParseDecimal("341.7412"),
+		})
+		events.publishRate("Binance P2P", &types.ExchangeRate{
+			Base:      currencies.USD,
+			Target:    currencies.VES,
+			Source:    "Binance P2P",
+			RateExact: types.MustParseDecimal("512.3"),
+			Metadata:  map[string]string{"offers": "12", "depth": "8450.5"},
+		})
+
+		var resp struct {
+			RateUpdated struct {
+				Base      string `json:"base"`
+				Target    string `json:"target"`
+				Source    string `json:"source"`
+				RateExact string `json:"rate_exact"`
+				Metadata  []struct {
+					Key   string `json:"key"`
+					Value string `json:"value"`
+				} `json:"metadata"`
+			} `json:"rateUpdated"`
+		}
+
+		require.NoError(t, sub.Next(&resp))
+
+		assert.Equal(t, "USD", resp.RateUpdated.Base)
+		assert.Equal(t, "VES", resp.RateUpdated.Target)
+		assert.Equal(t, "Binance P2P", resp.RateUpdated.Source)
+		assert.Equal(t, "512.3", resp.RateUpdated.RateExact)
+
+		// Metadata is sorted by key
+		require.Len(t, resp.RateUpdated.Metadata, 2)
+		assert.Equal(t, "depth", resp.RateUpdated.Metadata[0].Key)
+		assert.Equal(t, "8450.5", resp.RateUpdated.Metadata[0].Value)
+		assert.Equal(t, "offers", resp.RateUpdated.Metadata[1].Key)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		t.Parallel()
+
+		sub := newSubscriptionClient(newMockEvents(), nil).Websocket(
+			`subscription { rateUpdated(target: "V3S") { base } }`,
+		)
+		defer sub.Close()
+
+		var resp map[string]any
+
+		assert.ErrorContains(t, sub.Next(&resp), errInvalidCcy.Error())
+	})
+
+	t.Run("subscriptions unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		sub := newSubscriptionClient(nil, nil).Websocket(`subscription { rateUpdated { base } }`)
+		defer sub.Close()
+
+		var resp map[string]any
+
+		assert.ErrorContains(t, sub.Next(&resp), errSubscriptionsUnavailable.Error())
+	})
+}
+
+func TestSubscription_ProviderStatusChanged(t *testing.T) {
+	t.Parallel()
+
+	events := newMockEvents()
+
+	sub := newSubscriptionClient(events, nil).Websocket(
+		`subscription { providerStatusChanged(name: "BCV") { name paused consecutive_failures } }`,
+	)
+	defer sub.Close()
+
+	events.waitSubscribed(t, 1)
+
+	var resp struct {
+		ProviderStatusChanged struct {
+			Name                string `json:"name"`
+			Paused              bool   `json:"paused"`
+			ConsecutiveFailures int    `json:"consecutive_failures"`
+		} `json:"providerStatusChanged"`
+	}
+
+	events.publishStatus(&ingest.ProviderStatus{Name: "Binance P2P", Paused: true}) // filtered out
+	events.publishStatus(&ingest.ProviderStatus{Name: "BCV", Paused: true})
+
+	require.NoError(t, sub.Next(&resp))
+
+	assert.Equal(t, "BCV", resp.ProviderStatusChanged.Name)
+	assert.True(t, resp.ProviderStatusChanged.Paused)
+
+	events.publishStatus(&ingest.ProviderStatus{Name: "BCV", ConsecutiveFailures: 1})
+
+	require.NoError(t, sub.Next(&resp))
+
+	assert.False(t, resp.ProviderStatusChanged.Paused)
+	assert.Equal(t, 1, resp.ProviderStatusChanged.ConsecutiveFailures)
+}
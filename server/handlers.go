@@ -3,6 +3,7 @@ package server
 import (
 	"encoding/json"
 	"errors"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
@@ -10,22 +11,39 @@ import (
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/sig-0/fxrates/fx"
+	"github.com/sig-0/fxrates/ingest"
 	"github.com/sig-0/fxrates/storage/types"
 )
 
 const (
 	defaultLimit = int32(100)
 	maxLimit     = int32(500)
+
+	defaultHistoryWindow = time.Hour * 24 * 30
 )
 
 var (
 	errUnableToFetchRates      = errors.New("unable to fetch rates")
 	errUnableToFetchCurrencies = errors.New("unable to fetch currencies")
 	errUnableToFetchSources    = errors.New("unable to fetch sources")
+	errUnableToConvert         = errors.New("unable to convert amount")
 
 	errInvalidLimit  = errors.New("invalid limit")
 	errInvalidOffset = errors.New("invalid offset")
 	errInvalidType   = errors.New("invalid type")
+
+	errInvalidFrom     = errors.New("invalid from (must be RFC3339 UTC)")
+	errInvalidTo       = errors.New("invalid to (must be RFC3339 UTC)")
+	errInvalidRange    = errors.New("invalid range (from must be before to)")
+	errInvalidInterval = errors.New("invalid interval (must be a positive duration, e.g. 1h)")
+
+	errInvalidKnownAt = errors.New("invalid known_at (must be RFC3339 UTC)")
+
+	errMissingAmount = errors.New("missing amount")
+
+	errInvalidAllowMixedSources = errors.New("invalid allow_mixed_sources (must be a boolean)")
+	errInvalidAllowAsOfSkew     = errors.New("invalid allow_as_of_skew (must be a boolean)")
 )
 
 func (s *Server) RatesForPair(w http.ResponseWriter, r *http.Request) {
@@ -33,9 +51,10 @@ func (s *Server) RatesForPair(w http.ResponseWriter, r *http.Request) {
 		baseParam   = chi.URLParam(r, "base")
 		targetParam = chi.URLParam(r, "target")
 
-		asOfParam   = r.URL.Query().Get("as_of")
-		limitParam  = r.URL.Query().Get("limit")
-		offsetParam = r.URL.Query().Get("offset")
+		asOfParam    = r.URL.Query().Get("as_of")
+		knownAtParam = r.URL.Query().Get("known_at")
+		limitParam   = r.URL.Query().Get("limit")
+		offsetParam  = r.URL.Query().Get("offset")
 
 		sourceParam = r.URL.Query().Get("source")
 		typeParam   = r.URL.Query().Get("type")
@@ -65,6 +84,14 @@ func (s *Server) RatesForPair(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Parse the revision cutoff (optional)
+	knownAt, err := parseKnownAt(knownAtParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
 	// Parse the pagination settings
 	limit, offset, err := parseLimitOffset(limitParam, offsetParam)
 	if err != nil {
@@ -82,6 +109,7 @@ func (s *Server) RatesForPair(w http.ResponseWriter, r *http.Request) {
 	}
 
 	q := &types.RateQuery{
+		KnownAt:  knownAt,
 		Base:     base,
 		Target:   &target,
 		Source:   source,
@@ -113,9 +141,10 @@ func (s *Server) RatesForBase(w http.ResponseWriter, r *http.Request) {
 	var (
 		baseParam = chi.URLParam(r, "base")
 
-		asOfParam   = r.URL.Query().Get("as_of")
-		limitParam  = r.URL.Query().Get("limit")
-		offsetParam = r.URL.Query().Get("offset")
+		asOfParam    = r.URL.Query().Get("as_of")
+		knownAtParam = r.URL.Query().Get("known_at")
+		limitParam   = r.URL.Query().Get("limit")
+		offsetParam  = r.URL.Query().Get("offset")
 
 		sourceParam = r.URL.Query().Get("source")
 		typeParam   = r.URL.Query().Get("type")
@@ -137,6 +166,14 @@ func (s *Server) RatesForBase(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Parse the revision cutoff (optional)
+	knownAt, err := parseKnownAt(knownAtParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
 	// Parse the pagination settings
 	limit, offset, err := parseLimitOffset(limitParam, offsetParam)
 	if err != nil {
@@ -154,6 +191,7 @@ func (s *Server) RatesForBase(w http.ResponseWriter, r *http.Request) {
 	}
 
 	q := &types.RateQuery{
+		KnownAt:  knownAt,
 		Base:     base,
 		Target:   nil,
 		Source:   source,
@@ -181,6 +219,295 @@ func (s *Server) RatesForBase(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, page)
 }
 
+func (s *Server) RateHistory(w http.ResponseWriter, r *http.Request) {
+	var (
+		baseParam   = chi.URLParam(r, "base")
+		targetParam = chi.URLParam(r, "target")
+
+		fromParam     = r.URL.Query().Get("from")
+		toParam       = r.URL.Query().Get("to")
+		intervalParam = r.URL.Query().Get("interval")
+		limitParam    = r.URL.Query().Get("limit")
+		offsetParam   = r.URL.Query().Get("offset")
+
+		sourceParam = r.URL.Query().Get("source")
+		typeParam   = r.URL.Query().Get("type")
+	)
+
+	// Parse the base currency
+	base, err := parseCurrencySymbol(baseParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	// Parse the target currency
+	target, err := parseCurrencySymbol(targetParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	// Parse the time range (defaults to the last 30 days)
+	from, to, err := parseTimeRange(fromParam, toParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	// Parse the downsampling interval (optional)
+	interval, err := parseInterval(intervalParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	// Parse the pagination settings
+	limit, offset, err := parseLimitOffset(limitParam, offsetParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	// Parse the source and rate type (optional)
+	source, rateType, err := parseSourceAndType(sourceParam, typeParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	q := &types.HistoryQuery{
+		Base:     base,
+		Target:   target,
+		Source:   source,
+		RateType: rateType,
+		From:     from,
+		To:       to,
+		Interval: interval,
+		Limit:    limit,
+		Offset:   offset,
+	}
+
+	page, err := s.storage.RateHistory(r.Context(), q)
+	if err != nil {
+		s.logger.Debug(
+			"unable to fetch rate history",
+			"err", err,
+		)
+
+		writeError(
+			w,
+			http.StatusInternalServerError,
+			errUnableToFetchRates,
+		)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (s *Server) Convert(w http.ResponseWriter, r *http.Request) {
+	var (
+		fromParam   = r.URL.Query().Get("from")
+		toParam     = r.URL.Query().Get("to")
+		amountParam = r.URL.Query().Get("amount")
+		asOfParam   = r.URL.Query().Get("as_of")
+
+		sourceParam = r.URL.Query().Get("source")
+		typeParam   = r.URL.Query().Get("type")
+
+		pivotParam             = r.URL.Query().Get("pivot")
+		allowMixedSourcesParam = r.URL.Query().Get("allow_mixed_sources")
+		allowAsOfSkewParam     = r.URL.Query().Get("allow_as_of_skew")
+	)
+
+	// Parse the from currency
+	from, err := parseCurrencySymbol(fromParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	// Parse the to currency
+	to, err := parseCurrencySymbol(toParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	// Parse the amount
+	amount, err := parseAmount(amountParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	// Parse the effective date (defaults to now)
+	asOf, err := parseAsOf(asOfParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	// Parse the source and rate type (optional)
+	source, rateType, err := parseSourceAndType(sourceParam, typeParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	// Parse the cross-rate settings (optional)
+	pivot, allowMixedSources, allowAsOfSkew, err := parseCrossRateSettings(
+		pivotParam,
+		allowMixedSourcesParam,
+		allowAsOfSkewParam,
+	)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	req := &fx.ConversionRequest{
+		RateRequest: fx.RateRequest{
+			Base:              from,
+			Target:            to,
+			AsOf:              asOf,
+			Source:            source,
+			RateType:          rateType,
+			Pivot:             pivot,
+			AllowMixedSources: allowMixedSources,
+			AllowAsOfSkew:     allowAsOfSkew,
+		},
+		Amount: amount,
+	}
+
+	conversion, err := s.converter.Convert(r.Context(), req)
+	if err != nil {
+		s.writeResolveError(w, err, errUnableToConvert)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, conversion)
+}
+
+func (s *Server) CrossRate(w http.ResponseWriter, r *http.Request) {
+	var (
+		baseParam   = chi.URLParam(r, "base")
+		targetParam = chi.URLParam(r, "target")
+
+		asOfParam   = r.URL.Query().Get("as_of")
+		sourceParam = r.URL.Query().Get("source")
+		typeParam   = r.URL.Query().Get("type")
+
+		pivotParam             = r.URL.Query().Get("pivot")
+		allowMixedSourcesParam = r.URL.Query().Get("allow_mixed_sources")
+		allowAsOfSkewParam     = r.URL.Query().Get("allow_as_of_skew")
+	)
+
+	// Parse the base currency
+	base, err := parseCurrencySymbol(baseParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	// Parse the target currency
+	target, err := parseCurrencySymbol(targetParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	// Parse the effective date (defaults to now)
+	asOf, err := parseAsOf(asOfParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	// Parse the source and rate type (optional)
+	source, rateType, err := parseSourceAndType(sourceParam, typeParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	// Parse the cross-rate settings (optional)
+	pivot, allowMixedSources, allowAsOfSkew, err := parseCrossRateSettings(
+		pivotParam,
+		allowMixedSourcesParam,
+		allowAsOfSkewParam,
+	)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	req := &fx.RateRequest{
+		Base:              base,
+		Target:            target,
+		AsOf:              asOf,
+		Source:            source,
+		RateType:          rateType,
+		Pivot:             pivot,
+		AllowMixedSources: allowMixedSources,
+		AllowAsOfSkew:     allowAsOfSkew,
+	}
+
+	quote, err := s.converter.Rate(r.Context(), req)
+	if err != nil {
+		s.writeResolveError(w, err, errUnableToFetchRates)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, quote)
+}
+
+// writeResolveError writes the appropriate response for a rate resolution error
+func (s *Server) writeResolveError(w http.ResponseWriter, err, fallback error) {
+	switch {
+	case errors.Is(err, fx.ErrRateNotFound):
+		writeError(w, http.StatusNotFound, fx.ErrRateNotFound)
+	case errors.Is(err, fx.ErrAmbiguousRate),
+		errors.Is(err, fx.ErrSameCurrency),
+		errors.Is(err, fx.ErrInvalidAmount),
+		errors.Is(err, fx.ErrMixedSources),
+		errors.Is(err, fx.ErrAsOfSkew):
+		writeError(w, http.StatusBadRequest, err)
+	default:
+		s.logger.Debug(
+			"unable to resolve rate",
+			"err", err,
+		)
+
+		writeError(
+			w,
+			http.StatusInternalServerError,
+			fallback,
+		)
+	}
+}
+
 func (s *Server) Sources(w http.ResponseWriter, r *http.Request) {
 	items, err := s.storage.ListSources(r.Context())
 	if err != nil {
@@ -229,6 +556,20 @@ func (s *Server) Currencies(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, resp)
 }
 
+func (s *Server) Providers(w http.ResponseWriter, _ *http.Request) {
+	items := make([]*ingest.ProviderStatus, 0)
+
+	if s.providers != nil {
+		items = s.providers.Providers()
+	}
+
+	resp := &ProvidersResponse{
+		Results: items,
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
 func parseAsOf(asOfRaw string) (time.Time, error) {
 	v := strings.TrimSpace(asOfRaw)
 	if v == "" {
@@ -243,6 +584,119 @@ func parseAsOf(asOfRaw string) (time.Time, error) {
 	return t.UTC(), nil
 }
 
+func parseKnownAt(knownAtRaw string) (*time.Time, error) {
+	v := strings.TrimSpace(knownAtRaw)
+	if v == "" {
+		return nil, nil // default is the latest revision
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, errInvalidKnownAt
+	}
+
+	t = t.UTC()
+
+	return &t, nil
+}
+
+func parseTimeRange(fromRaw, toRaw string) (time.Time, time.Time, error) {
+	to := time.Now().UTC()
+
+	if v := strings.TrimSpace(toRaw); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, errInvalidTo
+		}
+
+		to = t.UTC()
+	}
+
+	from := to.Add(-defaultHistoryWindow)
+
+	if v := strings.TrimSpace(fromRaw); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, errInvalidFrom
+		}
+
+		from = t.UTC()
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, errInvalidRange
+	}
+
+	return from, to, nil
+}
+
+func parseInterval(intervalRaw string) (time.Duration, error) {
+	v := strings.TrimSpace(intervalRaw)
+	if v == "" {
+		return 0, nil // no downsampling
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, errInvalidInterval
+	}
+
+	return d, nil
+}
+
+func parseAmount(amountRaw string) (float64, error) {
+	v := strings.TrimSpace(amountRaw)
+	if v == "" {
+		return 0, errMissingAmount
+	}
+
+	amount, err := strconv.ParseFloat(v, 64)
+	if err != nil || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return 0, fx.ErrInvalidAmount
+	}
+
+	return amount, nil
+}
+
+func parseCrossRateSettings(
+	pivotRaw,
+	allowMixedSourcesRaw,
+	allowAsOfSkewRaw string,
+) (*types.Currency, bool, bool, error) {
+	var pivot *types.Currency
+
+	if v := strings.TrimSpace(pivotRaw); v != "" {
+		p, err := parseCurrencySymbol(v)
+		if err != nil {
+			return nil, false, false, err
+		}
+
+		pivot = &p
+	}
+
+	var allowMixedSources, allowAsOfSkew bool
+
+	if v := strings.TrimSpace(allowMixedSourcesRaw); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, false, false, errInvalidAllowMixedSources
+		}
+
+		allowMixedSources = b
+	}
+
+	if v := strings.TrimSpace(allowAsOfSkewRaw); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, false, false, errInvalidAllowAsOfSkew
+		}
+
+		allowAsOfSkew = b
+	}
+
+	return pivot, allowMixedSources, allowAsOfSkew, nil
+}
+
 func parseLimitOffset(limitRaw, offsetRaw string) (int32, int64, error) {
 	limit := defaultLimit
 
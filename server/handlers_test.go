@@ -13,6 +13,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/sig-0/fxrates/fx"
+	"github.com/sig-0/fxrates/ingest"
 	"github.com/sig-0/fxrates/storage/mock"
 
 	"github.com/sig-0/fxrates/provider/currencies"
@@ -157,6 +159,70 @@ func TestHandlers_RatesForPair(t *testing.T) {
 		assert.Equal(t, int32(200), capturedQuery.Limit)
 		assert.Equal(t, int64(2), capturedQuery.Offset)
 		assert.Equal(t, expectedAsOf, capturedAsOf)
+		assert.Nil(t, capturedQuery.KnownAt)
+	})
+
+	t.Run("known at", func(t *testing.T) {
+		t.Parallel()
+
+		var capturedQuery *types.RateQuery
+
+		storage := &mock.Storage{
+			RateAsOfFn: func(
+				_ context.Context,
+				query *types.RateQuery,
+				_ time.Time,
+			) (*types.Page[*types.ExchangeRate], error) {
+				capturedQuery = query
+
+				return &types.Page[*types.ExchangeRate]{}, nil
+			},
+		}
+
+		s := &Server{
+			storage: storage,
+			logger:  noopLogger,
+		}
+
+		url := "/v1/rates/USD/VES?known_at=2026-01-09T12:00:00Z"
+		req := httptest.NewRequest(http.MethodGet, url, http.NoBody)
+		req = withRouteParams(t, req, map[string]string{
+			"base":   currencies.USD.String(),
+			"target": currencies.VES.String(),
+		})
+
+		w := httptest.NewRecorder()
+		s.RatesForPair(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		require.NotNil(t, capturedQuery)
+		require.NotNil(t, capturedQuery.KnownAt)
+		assert.Equal(
+			t,
+			time.Date(2026, time.January, 9, 12, 0, 0, 0, time.UTC),
+			*capturedQuery.KnownAt,
+		)
+	})
+
+	t.Run("invalid known at", func(t *testing.T) {
+		t.Parallel()
+
+		s := &Server{
+			storage: &mock.Storage{},
+			logger:  noopLogger,
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/rates/USD/VES?known_at=yesterday", http.NoBody)
+		req = withRouteParams(t, req, map[string]string{
+			"base":   currencies.USD.String(),
+			"target": currencies.VES.String(),
+		})
+
+		w := httptest.NewRecorder()
+		s.RatesForPair(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 }
 
@@ -255,6 +321,410 @@ func TestHandlers_RatesForBase(t *testing.T) {
 	})
 }
 
+func TestHandlers_RateHistory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid range", func(t *testing.T) {
+		t.Parallel()
+
+		var called bool
+
+		storage := &mock.Storage{
+			RateHistoryFn: func(
+				_ context.Context,
+				_ *types.HistoryQuery,
+			) (*types.Page[*types.ExchangeRate], error) {
+				called = true
+
+				return nil, nil
+			},
+		}
+
+		s := &Server{
+			storage: storage,
+			logger:  noopLogger,
+		}
+
+		url := "/v1/rates/USD/VES/history?from=2026-01-10T00:00:00Z&to=2026-01-01T00:00:00Z"
+		req := httptest.NewRequest(http.MethodGet, url, http.NoBody)
+		req = withRouteParams(t, req, map[string]string{
+			"base":   currencies.USD.String(),
+			"target": currencies.VES.String(),
+		})
+
+		w := httptest.NewRecorder()
+		s.RateHistory(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.False(t, called)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		t.Parallel()
+
+		storage := &mock.Storage{
+			RateHistoryFn: func(
+				_ context.Context,
+				_ *types.HistoryQuery,
+			) (*types.Page[*types.ExchangeRate], error) {
+				return nil, errors.New("boom")
+			},
+		}
+
+		s := &Server{
+			storage: storage,
+			logger:  noopLogger,
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/rates/USD/VES/history", http.NoBody)
+		req = withRouteParams(t, req, map[string]string{
+			"base":   currencies.USD.String(),
+			"target": currencies.VES.String(),
+		})
+
+		w := httptest.NewRecorder()
+		s.RateHistory(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			capturedQuery *types.HistoryQuery
+
+			expectedFrom = time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+			expectedTo   = time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)
+		)
+
+		storage := &mock.Storage{
+			RateHistoryFn: func(
+				_ context.Context,
+				query *types.HistoryQuery,
+			) (*types.Page[*types.ExchangeRate], error) {
+				capturedQuery = query
+
+				return &types.Page[*types.ExchangeRate]{
+					Results: []*types.ExchangeRate{
+						{
+							Base:   currencies.USD,
+							Target: currencies.VES,
+							Rate:   300,
+						},
+						{
+							Base:   currencies.USD,
+							Target: currencies.VES,
+							Rate:   310,
+						},
+					},
+					Total: 2,
+				}, nil
+			},
+		}
+
+		s := &Server{
+			storage: storage,
+			logger:  noopLogger,
+		}
+
+		url := "/v1/rates/USD/VES/history?from=2026-01-01T00:00:00Z" +
+			"&to=2026-02-01T00:00:00Z&interval=24h&source=BCV&type=mid&limit=31"
+		req := httptest.NewRequest(http.MethodGet, url, http.NoBody)
+		req = withRouteParams(t, req, map[string]string{
+			"base":   currencies.USD.String(),
+			"target": currencies.VES.String(),
+		})
+
+		w := httptest.NewRecorder()
+		s.RateHistory(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var page types.Page[*types.ExchangeRate]
+
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+		require.Len(t, page.Results, 2)
+		assert.Equal(t, int64(2), page.Total)
+
+		require.NotNil(t, capturedQuery)
+		assert.Equal(t, currencies.USD, capturedQuery.Base)
+		assert.Equal(t, currencies.VES, capturedQuery.Target)
+		assert.Equal(t, expectedFrom, capturedQuery.From)
+		assert.Equal(t, expectedTo, capturedQuery.To)
+		assert.Equal(t, time.Hour*24, capturedQuery.Interval)
+
+		require.NotNil(t, capturedQuery.Source)
+		assert.Equal(t, ves.BCVSource, *capturedQuery.Source)
+
+		require.NotNil(t, capturedQuery.RateType)
+		assert.Equal(t, types.RateTypeMID, *capturedQuery.RateType)
+
+		assert.Equal(t, int32(31), capturedQuery.Limit)
+		assert.Equal(t, int64(0), capturedQuery.Offset)
+	})
+}
+
+func TestHandlers_Convert(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing amount", func(t *testing.T) {
+		t.Parallel()
+
+		storage := &mock.Storage{}
+
+		s := &Server{
+			storage:   storage,
+			converter: fx.NewConverter(storage),
+			logger:    noopLogger,
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/convert?from=USD&to=VES", http.NoBody)
+		w := httptest.NewRecorder()
+
+		s.Convert(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("rate not found", func(t *testing.T) {
+		t.Parallel()
+
+		storage := &mock.Storage{
+			RateAsOfFn: func(
+				_ context.Context,
+				_ *types.RateQuery,
+				_ time.Time,
+			) (*types.Page[*types.ExchangeRate], error) {
+				return &types.Page[*types.ExchangeRate]{}, nil
+			},
+		}
+
+		s := &Server{
+			storage:   storage,
+			converter: fx.NewConverter(storage),
+			logger:    noopLogger,
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/convert?from=USD&to=VES&amount=10", http.NoBody)
+		w := httptest.NewRecorder()
+
+		s.Convert(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		t.Parallel()
+
+		storage := &mock.Storage{
+			RateAsOfFn: func(
+				_ context.Context,
+				_ *types.RateQuery,
+				_ time.Time,
+			) (*types.Page[*types.ExchangeRate], error) {
+				return nil, errors.New("boom")
+			},
+		}
+
+		s := &Server{
+			storage:   storage,
+			converter: fx.NewConverter(storage),
+			logger:    noopLogger,
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/convert?from=USD&to=VES&amount=10", http.NoBody)
+		w := httptest.NewRecorder()
+
+		s.Convert(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			capturedQuery *types.RateQuery
+			capturedAsOf  time.Time
+		)
+
+		expectedAsOf := time.Date(2026, time.January, 10, 0, 0, 0, 0, time.UTC)
+
+		storage := &mock.Storage{
+			RateAsOfFn: func(
+				_ context.Context,
+				query *types.RateQuery,
+				asOf time.Time,
+			) (*types.Page[*types.ExchangeRate], error) {
+				capturedQuery = query
+				capturedAsOf = asOf
+
+				return &types.Page[*types.ExchangeRate]{
+					Results: []*types.ExchangeRate{{
+						AsOf:     expectedAsOf,
+						Base:     currencies.USD,
+						Target:   currencies.VES,
+						RateType: types.RateTypeSELL,
+						Source:   ves.BCVSource,
+						Rate:     300,
+					}},
+					Total: 1,
+				}, nil
+			},
+		}
+
+		s := &Server{
+			storage:   storage,
+			converter: fx.NewConverter(storage),
+			logger:    noopLogger,
+		}
+
+		url := "/v1/convert?from=USD&to=VES&amount=125.50" +
+			"&as_of=2026-01-10T00:00:00Z&source=BCV&type=SELL"
+		req := httptest.NewRequest(http.MethodGet, url, http.NoBody)
+		w := httptest.NewRecorder()
+
+		s.Convert(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var conversion fx.Conversion
+
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&conversion))
+		assert.InDelta(t, 37650.0, conversion.Result, 1e-9)
+		assert.Equal(t, 300.0, conversion.Rate)
+		assert.Equal(t, ves.BCVSource, conversion.Source)
+		assert.Equal(t, expectedAsOf, conversion.AsOf)
+		assert.False(t, conversion.Inverted)
+
+		require.NotNil(t, capturedQuery)
+		assert.Equal(t, currencies.USD, capturedQuery.Base)
+
+		require.NotNil(t, capturedQuery.Target)
+		assert.Equal(t, currencies.VES, *capturedQuery.Target)
+
+		require.NotNil(t, capturedQuery.RateType)
+		assert.Equal(t, types.RateTypeSELL, *capturedQuery.RateType)
+		assert.Equal(t, expectedAsOf, capturedAsOf)
+	})
+}
+
+func TestHandlers_CrossRate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asOf = time.Date(2026, time.January, 13, 0, 0, 0, 0, time.UTC)
+
+		storage = &mock.Storage{
+			RateAsOfFn: func(
+				_ context.Context,
+				query *types.RateQuery,
+				_ time.Time,
+			) (*types.Page[*types.ExchangeRate], error) {
+				if query.Target == nil || *query.Target != currencies.VES {
+					return &types.Page[*types.ExchangeRate]{}, nil
+				}
+
+				rates := map[types.Currency]float64{
+					currencies.EUR: 350,
+					currencies.USD: 320,
+				}
+
+				rate, ok := rates[query.Base]
+				if !ok {
+					return &types.Page[*types.ExchangeRate]{}, nil
+				}
+
+				return &types.Page[*types.ExchangeRate]{
+					Results: []*types.ExchangeRate{{
+						AsOf:     asOf,
+						Base:     query.Base,
+						Target:   currencies.VES,
+						RateType: types.RateTypeMID,
+						Source:   ves.BCVSource,
+						Rate:     rate,
+					}},
+					Total: 1,
+				}, nil
+			},
+		}
+	)
+
+	t.Run("invalid flag", func(t *testing.T) {
+		t.Parallel()
+
+		s := &Server{
+			storage:   storage,
+			converter: fx.NewConverter(storage),
+			logger:    noopLogger,
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/rates/EUR/USD/cross?allow_mixed_sources=maybe", http.NoBody)
+		req = withRouteParams(t, req, map[string]string{
+			"base":   currencies.EUR.String(),
+			"target": currencies.USD.String(),
+		})
+
+		w := httptest.NewRecorder()
+		s.CrossRate(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("rate not found", func(t *testing.T) {
+		t.Parallel()
+
+		s := &Server{
+			storage:   storage,
+			converter: fx.NewConverter(storage),
+			logger:    noopLogger,
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/rates/EUR/CNY/cross", http.NoBody)
+		req = withRouteParams(t, req, map[string]string{
+			"base":   currencies.EUR.String(),
+			"target": currencies.CNY.String(),
+		})
+
+		w := httptest.NewRecorder()
+		s.CrossRate(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		s := &Server{
+			storage:   storage,
+			converter: fx.NewConverter(storage),
+			logger:    noopLogger,
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/rates/EUR/USD/cross?source=BCV", http.NoBody)
+		req = withRouteParams(t, req, map[string]string{
+			"base":   currencies.EUR.String(),
+			"target": currencies.USD.String(),
+		})
+
+		w := httptest.NewRecorder()
+		s.CrossRate(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var quote fx.Quote
+
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&quote))
+		assert.True(t, quote.Derived)
+		assert.InDelta(t, 350.0/320.0, quote.Rate, 1e-12)
+		assert.Len(t, quote.Legs, 2)
+
+		require.NotNil(t, quote.Pivot)
+		assert.Equal(t, currencies.VES, *quote.Pivot)
+	})
+}
+
 func TestHandlers_ListEndpoints(t *testing.T) {
 	t.Parallel()
 
@@ -322,6 +792,82 @@ func TestHandlers_ListEndpoints(t *testing.T) {
 	}
 }
 
+// statusReaderFn is a provider status source backed by a function
+type statusReaderFn func() []*ingest.ProviderStatus
+
+func (f statusReaderFn) Providers() []*ingest.ProviderStatus {
+	return f()
+}
+
+func TestHandlers_Providers(t *testing.T) {
+	t.Parallel()
+
+	decodeResponse := func(t *testing.T, w *httptest.ResponseRecorder) ProvidersResponse {
+		t.Helper()
+
+		var resp ProvidersResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+
+		return resp
+	}
+
+	t.Run("no status source", func(t *testing.T) {
+		t.Parallel()
+
+		s := &Server{
+			logger: noopLogger,
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/providers", http.NoBody)
+		w := httptest.NewRecorder()
+
+		s.Providers(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, decodeResponse(t, w).Results)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			lastSuccess = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+			nextRun     = lastSuccess.Add(time.Hour)
+
+			expected = []*ingest.ProviderStatus{
+				{
+					Name:        "BCV",
+					LastAttempt: &lastSuccess,
+					LastSuccess: &lastSuccess,
+					NextRun:     &nextRun,
+					RatesSaved:  5,
+				},
+				{
+					Name:                "Binance P2P (USDT)",
+					LastAttempt:         &lastSuccess,
+					LastError:           "fetch error",
+					ConsecutiveFailures: 3,
+				},
+			}
+
+			s = &Server{
+				logger: noopLogger,
+				providers: statusReaderFn(func() []*ingest.ProviderStatus {
+					return expected
+				}),
+			}
+		)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/providers", http.NoBody)
+		w := httptest.NewRecorder()
+
+		s.Providers(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, expected, decodeResponse(t, w).Results)
+	})
+}
+
 func TestUtils_ParseAsOf(t *testing.T) {
 	t.Parallel()
 
@@ -345,6 +891,123 @@ func TestUtils_ParseAsOf(t *testing.T) {
 	})
 }
 
+func TestUtils_ParseKnownAt(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		value, err := parseKnownAt("")
+
+		require.NoError(t, err)
+		assert.Nil(t, value)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		expected := time.Date(2026, time.January, 12, 4, 0, 0, 0, time.UTC)
+
+		value, err := parseKnownAt("2026-01-12T00:00:00-04:00")
+
+		require.NoError(t, err)
+		require.NotNil(t, value)
+		assert.Equal(t, expected, *value)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parseKnownAt("nope")
+
+		assert.ErrorIs(t, err, errInvalidKnownAt)
+	})
+}
+
+func TestUtils_ParseTimeRange(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		from, to, err := parseTimeRange("", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, defaultHistoryWindow, to.Sub(from))
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			expectedFrom = time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+			expectedTo   = time.Date(2026, time.January, 12, 0, 0, 0, 0, time.UTC)
+		)
+
+		from, to, err := parseTimeRange("2026-01-01T00:00:00Z", "2026-01-12T00:00:00Z")
+
+		require.NoError(t, err)
+		assert.Equal(t, expectedFrom, from)
+		assert.Equal(t, expectedTo, to)
+	})
+
+	t.Run("invalid from", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := parseTimeRange("nope", "")
+
+		assert.ErrorIs(t, err, errInvalidFrom)
+	})
+
+	t.Run("invalid to", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := parseTimeRange("", "nope")
+
+		assert.ErrorIs(t, err, errInvalidTo)
+	})
+
+	t.Run("inverted range", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := parseTimeRange("2026-01-12T00:00:00Z", "2026-01-01T00:00:00Z")
+
+		assert.ErrorIs(t, err, errInvalidRange)
+	})
+}
+
+func TestUtils_ParseInterval(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		interval, err := parseInterval("")
+
+		require.NoError(t, err)
+		assert.Zero(t, interval)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		interval, err := parseInterval("6h")
+
+		require.NoError(t, err)
+		assert.Equal(t, time.Hour*6, interval)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		for _, value := range []string{"nope", "-1h", "0s"} {
+			_, err := parseInterval(value)
+
+			assert.ErrorIs(t, err, errInvalidInterval)
+		}
+	})
+}
+
 func TestUtils_ParseLimitOffset(t *testing.T) {
 	t.Parallel()
 
@@ -413,6 +1076,43 @@ func TestUtils_ParseSourceAndType(t *testing.T) {
 	})
 }
 
+func TestUtils_ParseCrossRateSettings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		pivot, allowMixedSources, allowAsOfSkew, err := parseCrossRateSettings("", "", "")
+
+		require.NoError(t, err)
+		assert.Nil(t, pivot)
+		assert.False(t, allowMixedSources)
+		assert.False(t, allowAsOfSkew)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+
+		pivot, allowMixedSources, allowAsOfSkew, err := parseCrossRateSettings("ves", "true", "1")
+
+		require.NoError(t, err)
+		require.NotNil(t, pivot)
+		assert.Equal(t, currencies.VES, *pivot)
+		assert.True(t, allowMixedSources)
+		assert.True(t, allowAsOfSkew)
+	})
+
+	t.Run("invalid flags", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, _, err := parseCrossRateSettings("", "nope", "")
+		assert.ErrorIs(t, err, errInvalidAllowMixedSources)
+
+		_, _, _, err = parseCrossRateSettings("", "", "nope")
+		assert.ErrorIs(t, err, errInvalidAllowAsOfSkew)
+	})
+}
+
 func TestUtils_ParseCurrencySymbol(t *testing.T) {
 	t.Parallel()
 
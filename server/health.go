@@ -0,0 +1,65 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+const (
+	healthStatusOK          = "ok"
+	healthStatusUnavailable = "unavailable"
+
+	// healthCheckTimeout is the timeout of a single dependency health check
+	healthCheckTimeout = 2 * time.Second
+)
+
+// HealthCheck checks the health of a service dependency (i.e. the DB).
+// It returns details reported in the health output (i.e. pool stats),
+// and an error if the dependency is unhealthy
+type HealthCheck func(ctx context.Context) (any, error)
+
+// Health reports the service health, along with the health of its dependencies.
+// Returns 503 if any of the dependencies is unhealthy
+func (s *Server) Health(w http.ResponseWriter, r *http.Request) {
+	resp := &HealthResponse{
+		Status: healthStatusOK,
+	}
+
+	if len(s.healthChecks) > 0 {
+		resp.Checks = make(map[string]*HealthCheckResult, len(s.healthChecks))
+	}
+
+	for name, check := range s.healthChecks {
+		ctx, cancelFn := context.WithTimeout(r.Context(), healthCheckTimeout)
+		details, err := check(ctx)
+
+		cancelFn()
+
+		result := &HealthCheckResult{
+			Status:  healthStatusOK,
+			Details: details,
+		}
+
+		if err != nil {
+			s.logger.Warn(
+				"health check failed",
+				"check", name,
+				"err", err,
+			)
+
+			result.Status = healthStatusUnavailable
+			result.Error = err.Error()
+			resp.Status = healthStatusUnavailable
+		}
+
+		resp.Checks[name] = result
+	}
+
+	status := http.StatusOK
+	if resp.Status != healthStatusOK {
+		status = http.StatusServiceUnavailable
+	}
+
+	writeJSON(w, status, resp)
+}
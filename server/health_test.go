@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/storage/mock"
+)
+
+// healthRequest executes a health request against the server
+func healthRequest(t *testing.T, s *Server) (int, map[string]any) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/health", http.NoBody)
+	w := httptest.NewRecorder()
+
+	s.mux.ServeHTTP(w, req)
+
+	var resp map[string]any
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+
+	return w.Code, resp
+}
+
+func TestHealth(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no checks", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := New(&mock.Storage{})
+		require.NoError(t, err)
+
+		code, resp := healthRequest(t, s)
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, healthStatusOK, resp["status"])
+		assert.NotContains(t, resp, "checks")
+	})
+
+	t.Run("healthy dependency", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := New(
+			&mock.Storage{},
+			WithHealthCheck("db", func(context.Context) (any, error) {
+				return map[string]int{"total_conns": 3}, nil
+			}),
+		)
+		require.NoError(t, err)
+
+		code, resp := healthRequest(t, s)
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, healthStatusOK, resp["status"])
+		assert.Equal(
+			t,
+			map[string]any{
+				"db": map[string]any{
+					"status":  healthStatusOK,
+					"details": map[string]any{"total_conns": float64(3)},
+				},
+			},
+			resp["checks"],
+		)
+	})
+
+	t.Run("unhealthy dependency", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := New(
+			&mock.Storage{},
+			WithHealthCheck("db", func(context.Context) (any, error) {
+				return nil, errors.New("connection refused")
+			}),
+			WithHealthCheck("cache", func(context.Context) (any, error) {
+				return nil, nil
+			}),
+		)
+		require.NoError(t, err)
+
+		code, resp := healthRequest(t, s)
+
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, healthStatusUnavailable, resp["status"])
+
+		checks, ok := resp["checks"].(map[string]any)
+		require.True(t, ok)
+
+		assert.Equal(
+			t,
+			map[string]any{
+				"status": healthStatusUnavailable,
+				"error":  "connection refused",
+			},
+			checks["db"],
+		)
+		assert.Equal(t, map[string]any{"status": healthStatusOK}, checks["cache"])
+	})
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"log/slog"
 
+	"github.com/sig-0/fxrates/ingest"
 	"github.com/sig-0/fxrates/server/config"
 )
 
@@ -21,3 +22,39 @@ func WithConfig(c *config.Config) Option {
 		s.config = c
 	}
 }
+
+// WithProviders specifies the provider run status source for the server,
+// usually the ingest orchestrator. If omitted, no providers are listed
+func WithProviders(p ingest.StatusReader) Option {
+	return func(s *Server) {
+		s.providers = p
+	}
+}
+
+// WithProviderController specifies the provider controller for the admin API,
+// usually the ingest orchestrator. The admin API is only served if an admin token is configured
+func WithProviderController(c ingest.Controller) Option {
+	return func(s *Server) {
+		s.controller = c
+	}
+}
+
+// WithEvents specifies the ingestion event source for the rate stream and the GraphQL subscriptions,
+// usually the ingest orchestrator. If omitted, streams and subscriptions are rejected
+func WithEvents(e ingest.EventSubscriber) Option {
+	return func(s *Server) {
+		s.events = e
+	}
+}
+
+// WithHealthCheck registers a named dependency health check,
+// reported by the health endpoint (i.e. the DB reachability)
+func WithHealthCheck(name string, check HealthCheck) Option {
+	return func(s *Server) {
+		if s.healthChecks == nil {
+			s.healthChecks = make(map[string]HealthCheck)
+		}
+
+		s.healthChecks[name] = check
+	}
+}
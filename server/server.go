@@ -15,9 +15,12 @@ import (
 	"github.com/rs/cors"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/sig-0/fxrates/fx"
+	"github.com/sig-0/fxrates/ingest"
 	graph "github.com/sig-0/fxrates/server/graph"
 
 	"github.com/sig-0/fxrates/storage"
+	"github.com/sig-0/fxrates/storage/types"
 
 	"github.com/sig-0/fxrates/server/config"
 )
@@ -31,7 +34,14 @@ type Server struct {
 	logger *slog.Logger
 	config *config.Config
 
-	storage storage.Storage
+	storage    storage.Storage
+	converter  *fx.Converter
+	providers  ingest.StatusReader
+	controller ingest.Controller
+	events     ingest.EventSubscriber
+	rateLog    *rateLog
+
+	healthChecks map[string]HealthCheck
 
 	mux *chi.Mux
 }
@@ -55,6 +65,24 @@ func New(storage storage.Storage, opts ...Option) (*Server, error) {
 		return nil, fmt.Errorf("invalid configuration, %w", err)
 	}
 
+	// Set up the rate converter
+	converterOpts, err := converterOptions(s.config.CrossRatesConfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cross-rate configuration, %w", err)
+	}
+
+	s.converter = fx.NewConverter(s.storage, converterOpts...)
+
+	// Log the saved rates for the rate streams, if enabled
+	if s.events != nil {
+		s.rateLog = newRateLog(streamLogSize)
+
+		// Each run's rates are a single event, so large runs don't overflow the subscription buffer
+		s.events.OnRatesSaved(func(e *ingest.RatesSavedEvent) {
+			s.rateLog.append(e.Rates...)
+		})
+	}
+
 	// Set up the CORS middleware
 	if s.config.CORSConfig != nil {
 		corsMiddleware := cors.New(cors.Options{
@@ -76,9 +104,7 @@ func New(storage storage.Storage, opts ...Option) (*Server, error) {
 	}))
 
 	// Register the health check handler
-	s.mux.Get("/health", func(writer http.ResponseWriter, _ *http.Request) {
-		writer.WriteHeader(http.StatusOK)
-	})
+	s.mux.Get("/health", s.Health)
 
 	// Register the OpenAPI spec
 	s.mux.Get("/openapi.yaml", s.OpenAPI)
@@ -87,17 +113,62 @@ func New(storage storage.Storage, opts ...Option) (*Server, error) {
 	// Register the default routes
 	s.mux.Route("/v1", func(r chi.Router) {
 		r.Get("/rates/{base}/{target}", s.RatesForPair)
+		r.Get("/rates/{base}/{target}/history", s.RateHistory)
+		r.Get("/rates/{base}/{target}/cross", s.CrossRate)
 		r.Get("/rates/{base}", s.RatesForBase)
+		r.Get("/convert", s.Convert)
 		r.Get("/sources", s.Sources)
 		r.Get("/currencies", s.Currencies)
+		r.Get("/providers", s.Providers)
+		r.Get("/stream/rates", s.StreamRates)
 	})
 
+	// Register the admin routes, if enabled
+	if s.config.AdminConfig.Enabled() && s.controller != nil {
+		s.mux.Route("/admin", func(r chi.Router) {
+			r.Use(s.requireAdminToken)
+
+			r.Post("/providers/{name}/run", s.RunProvider)
+			r.Post("/providers/{name}/pause", s.PauseProvider)
+			r.Post("/providers/{name}/resume", s.ResumeProvider)
+		})
+	}
+
 	// Register GraphQL
-	graph.Setup(s.storage, s.mux)
+	graph.Setup(
+		s.storage,
+		s.mux,
+		graph.WithConverter(s.converter),
+		graph.WithProviders(s.providers),
+		graph.WithEvents(s.events),
+	)
 
 	return s, nil
 }
 
+// converterOptions converts the cross-rate config to converter options
+func converterOptions(cfg *config.CrossRates) ([]fx.Option, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	maxSkew, err := cfg.MaxAsOfSkewDuration()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []fx.Option{
+		fx.WithDefaultPivot(types.Currency(cfg.DefaultPivot)),
+		fx.WithMaxAsOfSkew(maxSkew),
+	}
+
+	for source, pivot := range cfg.SourcePivots {
+		opts = append(opts, fx.WithSourcePivot(types.Source(source), types.Currency(pivot)))
+	}
+
+	return opts, nil
+}
+
 // Routes calls fn with the server mux so callers can add endpoints
 func (s *Server) Routes(fn RoutesFn) {
 	if fn == nil {
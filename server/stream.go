@@ -0,0 +1,428 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+const (
+	// streamHeartbeatInterval is the interval of the stream keep-alive comments
+	streamHeartbeatInterval = 15 * time.Second
+
+	// streamLogSize is the number of latest saved rates kept for the streams.
+	// Streams that fall further behind are closed, and replay the stored rates on reconnect
+	streamLogSize = 1024
+
+	// streamResumeHorizon is how far ahead of the resume time the replayed rates can be effective
+	// (i.e. BCV publishes the next business day's rates)
+	streamResumeHorizon = time.Hour * 24 * 7
+)
+
+var (
+	errStreamingUnavailable = errors.New("streaming is not available")
+	errInvalidLastEventID   = errors.New("invalid Last-Event-ID (must be a rate event ID)")
+)
+
+// rateStreamFilter matches saved rates against the stream query
+type rateStreamFilter struct {
+	target   *types.Currency
+	source   *types.Source
+	rateType *types.RateType
+	base     types.Currency
+}
+
+// matches returns true if the rate matches the filter
+func (f *rateStreamFilter) matches(rate *types.ExchangeRate) bool {
+	return f.base == rate.Base &&
+		(f.target == nil || *f.target == rate.Target) &&
+		(f.source == nil || *f.source == rate.Source) &&
+		(f.rateType == nil || *f.rateType == rate.RateType)
+}
+
+// StreamRates streams newly saved rates as Server-Sent Events. Each event is a single rate,
+// identified by its position in the order the rates were saved. A stream resumed with Last-Event-ID
+// first replays every matching rate saved after the given event. If those rates are no longer logged,
+// the latest stored rate of each matching series is replayed instead
+func (s *Server) StreamRates(w http.ResponseWriter, r *http.Request) {
+	var (
+		baseParam   = r.URL.Query().Get("base")
+		targetParam = r.URL.Query().Get("target")
+
+		sourceParam = r.URL.Query().Get("source")
+		typeParam   = r.URL.Query().Get("type")
+
+		lastEventIDParam = r.Header.Get("Last-Event-ID")
+	)
+
+	if s.rateLog == nil {
+		writeError(w, http.StatusServiceUnavailable, errStreamingUnavailable)
+
+		return
+	}
+
+	// Parse the base currency
+	base, err := parseCurrencySymbol(baseParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	filter := &rateStreamFilter{
+		base: base,
+	}
+
+	// Parse the target currency (optional)
+	if targetParam != "" {
+		target, err := parseCurrencySymbol(targetParam)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+
+			return
+		}
+
+		filter.target = &target
+	}
+
+	// Parse the source and rate type (optional)
+	filter.source, filter.rateType, err = parseSourceAndType(sourceParam, typeParam)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	// Parse the resume point (optional)
+	var cursor *rateEventID
+
+	if lastEventIDParam != "" {
+		cursor, err = parseRateEventID(strings.TrimSpace(lastEventIDParam))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errInvalidLastEventID)
+
+			return
+		}
+	}
+
+	// Follow the log before the replay, so no rates are missed in between
+	var (
+		ctx = r.Context()
+
+		follower = s.rateLog.follow(filter, cursor)
+		replay   []*rateLogEntry
+	)
+	defer s.rateLog.unfollow(follower)
+
+	if follower.resumed {
+		replay, _ = s.rateLog.next(follower)
+	}
+
+	// The missed rates are no longer logged (or were logged by a previous server run),
+	// so the latest stored rate of each series is replayed instead
+	if cursor != nil && !follower.resumed {
+		stored, err := s.storedRates(ctx, filter)
+		if err != nil {
+			s.logger.Debug(
+				"unable to fetch rates for stream replay",
+				"err", err,
+			)
+
+			writeError(
+				w,
+				http.StatusInternalServerError,
+				errUnableToFetchRates,
+			)
+
+			return
+		}
+
+		replay = make([]*rateLogEntry, 0, len(stored))
+
+		for _, rate := range stored {
+			replay = append(replay, &rateLogEntry{
+				rate: rate,
+				id:   follower.since,
+			})
+		}
+	}
+
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // disable proxy buffering
+	w.WriteHeader(http.StatusOK)
+
+	if err := rc.Flush(); err != nil {
+		s.logger.Debug(
+			"streaming not supported",
+			"err", err,
+		)
+
+		return
+	}
+
+	for _, entry := range replay {
+		if err := writeRateEvent(w, rc, entry); err != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		case <-follower.notify:
+			entries, ok := s.rateLog.next(follower)
+			if !ok {
+				// The stream fell behind the log, the client resumes it on reconnect
+				return
+			}
+
+			for _, entry := range entries {
+				if err := writeRateEvent(w, rc, entry); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+// storedRates fetches the latest stored rate of each series matching the filter, ordered by fetch time
+func (s *Server) storedRates(
+	ctx context.Context,
+	filter *rateStreamFilter,
+) ([]*types.ExchangeRate, error) {
+	var (
+		rates = make([]*types.ExchangeRate, 0)
+		asOf  = time.Now().UTC().Add(streamResumeHorizon)
+
+		q = &types.RateQuery{
+			Base:     filter.base,
+			Target:   filter.target,
+			Source:   filter.source,
+			RateType: filter.rateType,
+			Limit:    types.MaxPageLimit,
+		}
+	)
+
+	for {
+		page, err := s.storage.RateAsOf(ctx, q, asOf)
+		if err != nil {
+			return nil, err
+		}
+
+		rates = append(rates, page.Results...)
+
+		q.Offset += int64(len(page.Results))
+
+		if len(page.Results) == 0 || q.Offset >= page.Total {
+			break
+		}
+	}
+
+	slices.SortStableFunc(rates, func(a, b *types.ExchangeRate) int {
+		return a.FetchedAt.Compare(b.FetchedAt)
+	})
+
+	return rates, nil
+}
+
+// writeRateEvent writes the logged rate as a single event
+func writeRateEvent(w http.ResponseWriter, rc *http.ResponseController, entry *rateLogEntry) error {
+	data, err := json.Marshal(entry.rate)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(
+		w,
+		"id: %s\nevent: rate\ndata: %s\n\n",
+		entry.id,
+		data,
+	); err != nil {
+		return err
+	}
+
+	return rc.Flush()
+}
+
+// rateEventID identifies a logged rate: its sequence number
+// in the order the rates were saved, within a single server run
+type rateEventID struct {
+	run string
+	seq uint64
+}
+
+// parseRateEventID parses a <run>-<seq> rate event ID
+func parseRateEventID(id string) (*rateEventID, error) {
+	run, rawSeq, ok := strings.Cut(id, "-")
+	if !ok || run == "" {
+		return nil, errInvalidLastEventID
+	}
+
+	seq, err := strconv.ParseUint(rawSeq, 10, 64)
+	if err != nil {
+		return nil, errInvalidLastEventID
+	}
+
+	return &rateEventID{
+		run: run,
+		seq: seq,
+	}, nil
+}
+
+// String returns the <run>-<seq> event ID
+func (id *rateEventID) String() string {
+	return fmt.Sprintf("%s-%d", id.run, id.seq)
+}
+
+// rateLogEntry is a single logged rate
+type rateLogEntry struct {
+	rate *types.ExchangeRate
+	id   string
+	seq  uint64
+}
+
+// rateFollower is a stream following the rate log
+type rateFollower struct {
+	filter  *rateStreamFilter
+	notify  chan struct{} // signaled when rates are logged
+	since   string        // the ID of the latest logged rate when the stream started following
+	last    uint64        // the sequence number of the latest rate handed to the stream
+	resumed bool          // whether the rates after the stream's cursor are all logged
+}
+
+// rateLog numbers the saved rates in the order they were saved, and keeps the latest ones,
+// so resumed streams replay exactly the rates saved after their last event.
+// Following streams read the logged rates after their latest one when notified
+type rateLog struct {
+	followers map[*rateFollower]struct{}
+	run       string          // distinguishes the event IDs of different server runs
+	entries   []*rateLogEntry // the latest saved rates, oldest first
+	size      int
+	seq       uint64 // the sequence number of the latest saved rate
+	mux       sync.Mutex
+}
+
+// newRateLog creates a new rate log, keeping the given number of saved rates
+func newRateLog(size int) *rateLog {
+	return &rateLog{
+		followers: make(map[*rateFollower]struct{}),
+		run:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		entries:   make([]*rateLogEntry, 0, size),
+		size:      size,
+	}
+}
+
+// append logs the saved rates, and notifies the followers
+func (l *rateLog) append(rates ...*types.ExchangeRate) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	for _, rate := range rates {
+		l.seq++
+
+		if len(l.entries) == l.size {
+			l.entries = slices.Delete(l.entries, 0, 1)
+		}
+
+		l.entries = append(l.entries, &rateLogEntry{
+			rate: rate,
+			id:   (&rateEventID{run: l.run, seq: l.seq}).String(),
+			seq:  l.seq,
+		})
+	}
+
+	for f := range l.followers {
+		select {
+		case f.notify <- struct{}{}:
+		default: // already notified
+		}
+	}
+}
+
+// follow starts notifying a new follower of the saved rates.
+// If the cursor is set, and all rates saved after it are still logged,
+// the follower is resumed from it
+func (l *rateLog) follow(filter *rateStreamFilter, cursor *rateEventID) *rateFollower {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	f := &rateFollower{
+		filter: filter,
+		notify: make(chan struct{}, 1),
+		since:  (&rateEventID{run: l.run, seq: l.seq}).String(),
+		last:   l.seq,
+	}
+
+	// The rates after the cursor are logged if the cursor is from this run,
+	// and no later rate was evicted
+	if cursor != nil && cursor.run == l.run && l.covers(cursor.seq) {
+		f.resumed = true
+		f.last = cursor.seq
+	}
+
+	l.followers[f] = struct{}{}
+
+	return f
+}
+
+// next returns the logged rates matching the follower's filter, saved after its latest one.
+// It returns false if some of them are no longer logged (the follower fell behind)
+func (l *rateLog) next(f *rateFollower) ([]*rateLogEntry, bool) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if !l.covers(f.last) {
+		return nil, false
+	}
+
+	var entries []*rateLogEntry
+
+	for _, entry := range l.entries {
+		if entry.seq > f.last && f.filter.matches(entry.rate) {
+			entries = append(entries, entry)
+		}
+	}
+
+	f.last = l.seq
+
+	return entries, true
+}
+
+// covers returns true if all rates saved after the given one are logged.
+// Must be called with the lock held
+func (l *rateLog) covers(seq uint64) bool {
+	return seq <= l.seq && seq+uint64(len(l.entries)) >= l.seq
+}
+
+// unfollow stops notifying the follower
+func (l *rateLog) unfollow(f *rateFollower) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	delete(l.followers, f)
+}
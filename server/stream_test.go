@@ -0,0 +1,503 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/ingest"
+	"github.com/sig-0/fxrates/provider/currencies"
+	"github.com/sig-0/fxrates/storage/memory"
+	"github.com/sig-0/fxrates/storage/mock"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+// mockEvents is an ingest.EventSubscriber with manually published rates
+type mockEvents struct {
+	handlers      []func(*ingest.RateSavedEvent)
+	batchHandlers []func(*ingest.RatesSavedEvent)
+	mux           sync.Mutex
+}
+
+func newMockEvents() *mockEvents {
+	return &mockEvents{}
+}
+
+func (m *mockEvents) OnRateSaved(handler func(*ingest.RateSavedEvent)) func() {
+	m.mux.Lock()
+	m.handlers = append(m.handlers, handler)
+	m.mux.Unlock()
+
+	return func() {}
+}
+
+func (m *mockEvents) OnRatesSaved(handler func(*ingest.RatesSavedEvent)) func() {
+	m.mux.Lock()
+	m.batchHandlers = append(m.batchHandlers, handler)
+	m.mux.Unlock()
+
+	return func() {}
+}
+
+func (m *mockEvents) OnProviderError(func(*ingest.ProviderErrorEvent)) func() {
+	return func() {}
+}
+
+func (m *mockEvents) OnProviderRecovered(func(*ingest.ProviderRecoveredEvent)) func() {
+	return func() {}
+}
+
+func (m *mockEvents) OnProviderStatusChanged(func(*ingest.ProviderStatusChangedEvent)) func() {
+	return func() {}
+}
+
+// publish publishes a rate saved event to all subscribers
+func (m *mockEvents) publish(rate *types.ExchangeRate) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	for _, handler := range m.handlers {
+		handler(&ingest.RateSavedEvent{Rate: rate})
+	}
+
+	for _, handler := range m.batchHandlers {
+		handler(&ingest.RatesSavedEvent{Rates: []*types.ExchangeRate{rate}})
+	}
+}
+
+// streamEvent is a single received Server-Sent Event
+type streamEvent struct {
+	rate *types.ExchangeRate
+	id   string
+	name string
+}
+
+// openStream opens a rate stream against the server
+func openStream(
+	t *testing.T,
+	s *Server,
+	query string,
+	lastEventID string,
+) (*http.Response, func() streamEvent) {
+	t.Helper()
+
+	srv := httptest.NewServer(s.mux)
+	t.Cleanup(srv.Close)
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	t.Cleanup(cancelFn)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/v1/stream/rates?"+query, http.NoBody)
+	require.NoError(t, err)
+
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = resp.Body.Close()
+	})
+
+	reader := bufio.NewReader(resp.Body)
+
+	next := func() streamEvent {
+		t.Helper()
+
+		var event streamEvent
+
+		for {
+			line, err := reader.ReadString('\n')
+			require.NoError(t, err)
+
+			line = strings.TrimSuffix(line, "\n")
+
+			switch {
+			case line == "":
+				if event.rate != nil {
+					return event
+				}
+			case strings.HasPrefix(line, "id: "):
+				event.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				event.name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				event.rate = &types.ExchangeRate{}
+				require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), event.rate))
+			}
+		}
+	}
+
+	return resp, next
+}
+
+// newStreamRate creates a new USD rate, fetched at the given time
+func newStreamRate(target types.Currency, source types.Source, fetchedAt time.Time) *types.ExchangeRate {
+	return &types.ExchangeRate{
+		AsOf:      fetchedAt,
+		FetchedAt: fetchedAt,
+		Base:      currencies.USD,
+		Target:    target,
+		RateType:  types.RateTypeMID,
+		Source:    source,
+		Rate:      341.7412,
+		RateExact: types.MustParseDecimal("341.7412"),
+	}
+}
+
+func TestStreamRates(t *testing.T) {
+	t.Parallel()
+
+	fetchedAt := time.Date(2026, time.January, 7, 12, 0, 0, 0, time.UTC)
+
+	t.Run("streaming unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := New(&mock.Storage{})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/stream/rates?base=USD", http.NoBody)
+		w := httptest.NewRecorder()
+
+		s.mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := New(&mock.Storage{}, WithEvents(newMockEvents()))
+		require.NoError(t, err)
+
+		for _, query := range []string{
+			"",                       // missing base
+			"base=USD&target=V3S",    // invalid target
+			"base=USD&type=WHATEVER", // invalid type
+		} {
+			req := httptest.NewRequest(http.MethodGet, "/v1/stream/rates?"+query, http.NoBody)
+			w := httptest.NewRecorder()
+
+			s.mux.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/stream/rates?base=USD", http.NoBody)
+		req.Header.Set("Last-Event-ID", "yesterday")
+
+		w := httptest.NewRecorder()
+
+		s.mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("filtered rates streamed", func(t *testing.T) {
+		t.Parallel()
+
+		events := newMockEvents()
+
+		s, err := New(&mock.Storage{}, WithEvents(events))
+		require.NoError(t, err)
+
+		resp, next := openStream(t, s, "base=USD&target=VES&source=BCV", "")
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		var (
+			otherSource = newStreamRate(currencies.VES, "Binance P2P", fetchedAt)
+			otherTarget = newStreamRate(currencies.EUR, types.SourceBCV, fetchedAt)
+			expected    = newStreamRate(currencies.VES, types.SourceBCV, fetchedAt)
+		)
+
+		events.publish(otherSource)
+		events.publish(otherTarget)
+		events.publish(expected)
+
+		event := next()
+
+		assert.Equal(t, "rate", event.name)
+		assert.Equal(t, expected, event.rate)
+
+		// Events are numbered in the order the rates were saved
+		id, err := parseRateEventID(event.id)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(3), id.seq)
+	})
+
+	t.Run("stream resumed without duplicates", func(t *testing.T) {
+		t.Parallel()
+
+		events := newMockEvents()
+
+		s, err := New(&mock.Storage{}, WithEvents(events))
+		require.NoError(t, err)
+
+		_, next := openStream(t, s, "base=USD&target=VES", "")
+
+		var (
+			first    = newStreamRate(currencies.VES, types.SourceBCV, fetchedAt)
+			second   = newStreamRate(currencies.VES, "Binance P2P", fetchedAt)
+			revision = newStreamRate(currencies.VES, types.SourceBCV, fetchedAt.Add(time.Minute))
+			live     = newStreamRate(currencies.VES, types.SourceBCV, fetchedAt.Add(time.Hour))
+		)
+
+		// A correction of the first rate, for the same as-of
+		revision.AsOf = first.AsOf
+		revision.Rate = 342.1
+		revision.RateExact = types.MustParseDecimal("342.1")
+
+		events.publish(first)
+		events.publish(second)
+		events.publish(revision)
+
+		var ids []string
+
+		for range 3 {
+			ids = append(ids, next().id)
+		}
+
+		// Reconnect after the first event, sharing its fetch time with the second one
+		_, resumed := openStream(t, s, "base=USD&target=VES", ids[0])
+
+		// Only the later rates are replayed, including the revision
+		replayed := resumed()
+		assert.Equal(t, ids[1], replayed.id)
+		assert.Equal(t, second, replayed.rate)
+
+		replayed = resumed()
+		assert.Equal(t, ids[2], replayed.id)
+		assert.Equal(t, revision, replayed.rate)
+
+		events.publish(live)
+
+		event := resumed()
+		assert.Equal(t, live, event.rate)
+		assert.NotContains(t, ids, event.id)
+	})
+
+	t.Run("stream resumed from an unknown event", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			events = newMockEvents()
+
+			late  = newStreamRate(currencies.EUR, types.SourceBCV, fetchedAt.Add(time.Hour*2))
+			early = newStreamRate(currencies.VES, types.SourceBCV, fetchedAt.Add(time.Hour))
+			live  = newStreamRate(currencies.VES, types.SourceBCV, fetchedAt.Add(time.Hour*3))
+
+			storage = &mock.Storage{
+				RateAsOfFn: func(
+					_ context.Context,
+					query *types.RateQuery,
+					_ time.Time,
+				) (*types.Page[*types.ExchangeRate], error) {
+					assert.Equal(t, currencies.USD, query.Base)
+
+					results := []*types.ExchangeRate{late, early}
+
+					return &types.Page[*types.ExchangeRate]{
+						Results: results,
+						Total:   int64(len(results)),
+					}, nil
+				},
+			}
+		)
+
+		s, err := New(storage, WithEvents(events))
+		require.NoError(t, err)
+
+		// i.e. an event from a previous server run
+		_, next := openStream(t, s, "base=USD", "previous-42")
+
+		// The latest stored rates are replayed in fetch order
+		assert.Equal(t, early, next().rate)
+		assert.Equal(t, late, next().rate)
+
+		events.publish(live)
+
+		assert.Equal(t, live, next().rate)
+	})
+}
+
+// staticProvider is an ingest.Provider fetching the same rates on every run
+type staticProvider struct {
+	rates []*types.ExchangeRate
+}
+
+func (p *staticProvider) Name() string {
+	return "static"
+}
+
+func (p *staticProvider) Interval() time.Duration {
+	return time.Hour
+}
+
+func (p *staticProvider) Fetch(context.Context) ([]*types.ExchangeRate, error) {
+	return p.rates, nil
+}
+
+func TestStreamRates_LargeRun(t *testing.T) {
+	t.Parallel()
+
+	// More rates than the event subscription buffer, saved in a single run (i.e. a history backfill)
+	var (
+		count     = 250
+		fetchedAt = time.Now().UTC().Truncate(time.Second)
+		rates     = make([]*types.ExchangeRate, 0, count)
+	)
+
+	for i := range count {
+		rate := newStreamRate(currencies.VES, types.SourceBCV, fetchedAt)
+		rate.AsOf = fetchedAt.AddDate(0, 0, -i)
+
+		rates = append(rates, rate)
+	}
+
+	store, err := memory.NewStorage()
+	require.NoError(t, err)
+
+	orchestrator := ingest.New(store, ingest.WithQueryInterval(time.Millisecond*10))
+	require.NoError(t, orchestrator.Register(&staticProvider{rates: rates}))
+
+	s, err := New(store, WithEvents(orchestrator))
+	require.NoError(t, err)
+
+	_, next := openStream(t, s, "base=USD&target=VES", "")
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	t.Cleanup(cancelFn)
+
+	go func() {
+		_ = orchestrator.Start(ctx)
+	}()
+
+	// Every rate of the run is streamed, in save order
+	ids := make([]string, 0, count)
+
+	for i := range count {
+		event := next()
+		assert.True(t, rates[i].AsOf.Equal(event.rate.AsOf))
+
+		ids = append(ids, event.id)
+	}
+
+	// Resuming from the middle of the run replays the rest of it, without gaps
+	_, resumed := openStream(t, s, "base=USD&target=VES", ids[count/2-1])
+
+	for i := count / 2; i < count; i++ {
+		event := resumed()
+
+		assert.Equal(t, ids[i], event.id)
+		assert.True(t, rates[i].AsOf.Equal(event.rate.AsOf))
+	}
+}
+
+func TestRateLog(t *testing.T) {
+	t.Parallel()
+
+	var (
+		filter    = &rateStreamFilter{base: currencies.USD}
+		fetchedAt = time.Date(2026, time.January, 7, 12, 0, 0, 0, time.UTC)
+	)
+
+	// appendRates logs the given number of rates
+	appendRates := func(l *rateLog, count int) {
+		for i := range count {
+			l.append(newStreamRate(currencies.VES, types.SourceBCV, fetchedAt.Add(time.Duration(i)*time.Minute)))
+		}
+	}
+
+	t.Run("logged rates replayed after the cursor", func(t *testing.T) {
+		t.Parallel()
+
+		l := newRateLog(3)
+		appendRates(l, 3)
+
+		f := l.follow(filter, &rateEventID{run: l.run, seq: 1})
+		defer l.unfollow(f)
+
+		require.True(t, f.resumed)
+
+		entries, ok := l.next(f)
+		require.True(t, ok)
+		require.Len(t, entries, 2)
+
+		assert.Equal(t, uint64(2), entries[0].seq)
+		assert.Equal(t, uint64(3), entries[1].seq)
+	})
+
+	t.Run("evicted rates not replayed", func(t *testing.T) {
+		t.Parallel()
+
+		l := newRateLog(2)
+		appendRates(l, 4)
+
+		// The rate after the cursor was evicted
+		f := l.follow(filter, &rateEventID{run: l.run, seq: 1})
+		defer l.unfollow(f)
+
+		assert.False(t, f.resumed)
+
+		// The oldest logged rate directly follows the cursor
+		f = l.follow(filter, &rateEventID{run: l.run, seq: 2})
+		defer l.unfollow(f)
+
+		assert.True(t, f.resumed)
+
+		entries, ok := l.next(f)
+		require.True(t, ok)
+		assert.Len(t, entries, 2)
+	})
+
+	t.Run("followers notified of batches", func(t *testing.T) {
+		t.Parallel()
+
+		l := newRateLog(streamLogSize)
+
+		f := l.follow(filter, nil)
+		defer l.unfollow(f)
+
+		// A batch larger than any buffer is a single notification
+		appendRates(l, 200)
+
+		<-f.notify
+
+		entries, ok := l.next(f)
+		require.True(t, ok)
+		assert.Len(t, entries, 200)
+
+		// Nothing new
+		entries, ok = l.next(f)
+		require.True(t, ok)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("followers behind the log dropped", func(t *testing.T) {
+		t.Parallel()
+
+		l := newRateLog(2)
+
+		f := l.follow(filter, nil)
+		defer l.unfollow(f)
+
+		appendRates(l, 3)
+
+		_, ok := l.next(f)
+		assert.False(t, ok)
+	})
+}
@@ -1,6 +1,9 @@
 package server
 
-import "github.com/sig-0/fxrates/storage/types"
+import (
+	"github.com/sig-0/fxrates/ingest"
+	"github.com/sig-0/fxrates/storage/types"
+)
 
 type SourcesResponse struct {
 	Results []types.Source `json:"results"`
@@ -10,6 +13,21 @@ type CurrenciesResponse struct {
 	Results []types.Currency `json:"results"`
 }
 
+type ProvidersResponse struct {
+	Results []*ingest.ProviderStatus `json:"results"`
+}
+
+type HealthResponse struct {
+	Checks map[string]*HealthCheckResult `json:"checks,omitempty"`
+	Status string                        `json:"status"`
+}
+
+type HealthCheckResult struct {
+	Details any    `json:"details,omitempty"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
 type ErrorResponse struct {
 	Error error `json:"error"`
 }
@@ -2,6 +2,12 @@ package memory
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+	"slices"
 	"sort"
 	"sync"
 	"time"
@@ -9,41 +15,178 @@ import (
 	"github.com/sig-0/fxrates/storage/types"
 )
 
+// defaultSnapshotInterval is the default interval between snapshots
+const defaultSnapshotInterval = 5 * time.Minute
+
 type key struct {
 	base, target, source, rateType string
 	asOf                           int64 // unix nanos
 }
 
+// keyOf returns the storage key of the (normalized) rate
+func keyOf(r *types.ExchangeRate) key {
+	return key{
+		base:     r.Base.String(),
+		target:   r.Target.String(),
+		source:   r.Source.String(),
+		rateType: r.RateType.String(),
+		asOf:     r.AsOf.UnixNano(),
+	}
+}
+
 type Storage struct {
-	data map[key]types.ExchangeRate
+	logger *slog.Logger
+
+	data map[key][]types.ExchangeRate // rate revisions, sorted by fetched_at
 
-	mu sync.RWMutex
+	wal     *os.File // write-ahead log, if persistence is enabled
+	walSize int64    // bytes written to the write-ahead log
+
+	dataDir          string
+	snapshotInterval time.Duration
+
+	mu         sync.RWMutex
+	snapshotMu sync.Mutex
 }
 
-func NewStorage() *Storage {
-	return &Storage{
-		data: make(map[key]types.ExchangeRate),
+// NewStorage creates a new in-memory storage.
+// If persistence is enabled, the previous snapshot and write-ahead log are restored
+func NewStorage(opts ...Option) (*Storage, error) {
+	s := &Storage{
+		logger:           slog.New(slog.NewTextHandler(io.Discard, nil)),
+		data:             make(map[key][]types.ExchangeRate),
+		snapshotInterval: defaultSnapshotInterval,
 	}
+
+	// Apply the options
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.dataDir == "" {
+		return s, nil
+	}
+
+	if err := s.restore(); err != nil {
+		return nil, fmt.Errorf("unable to restore storage: %w", err)
+	}
+
+	return s, nil
 }
 
 func (s *Storage) SaveExchangeRate(_ context.Context, r *types.ExchangeRate) error {
-	k := key{
-		base:     r.Base.String(),
-		target:   r.Target.String(),
-		source:   r.Source.String(),
-		rateType: r.RateType.String(),
-		asOf:     r.AsOf.UTC().UnixNano(),
+	elem := normalizeRate(r)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := keyOf(&elem)
+
+	if s.revisionStatus(k, &elem) != types.SaveStatusSaved {
+		return nil
 	}
 
+	// Log the revision before applying it
+	if err := s.appendWAL(&elem); err != nil {
+		return fmt.Errorf("unable to save exchange rate: %w", err)
+	}
+
+	s.insertRevision(k, elem)
+
+	return nil
+}
+
+// SaveExchangeRates saves the rates under a single lock.
+// The saved revisions are logged in a single write, and rolled back if it fails
+func (s *Storage) SaveExchangeRates(
+	_ context.Context,
+	rates []*types.ExchangeRate,
+) ([]types.SaveStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		statuses = make([]types.SaveStatus, len(rates))
+		saved    = make([]*types.ExchangeRate, 0, len(rates))
+		backup   = make(map[key][]types.ExchangeRate)
+	)
+
+	for i, r := range rates {
+		elem := normalizeRate(r)
+		k := keyOf(&elem)
+
+		statuses[i] = s.revisionStatus(k, &elem)
+		if statuses[i] != types.SaveStatusSaved {
+			continue
+		}
+
+		// Keep the original revisions, in case the batch is rolled back
+		if _, ok := backup[k]; !ok {
+			backup[k] = slices.Clone(s.data[k])
+		}
+
+		s.insertRevision(k, elem)
+		saved = append(saved, &elem)
+	}
+
+	if err := s.appendWAL(saved...); err != nil {
+		for k, revisions := range backup {
+			if len(revisions) == 0 {
+				delete(s.data, k)
+
+				continue
+			}
+
+			s.data[k] = revisions
+		}
+
+		return nil, fmt.Errorf("unable to save exchange rates: %w", err)
+	}
+
+	return statuses, nil
+}
+
+// normalizeRate returns a copy of the rate, as it's stored
+func normalizeRate(r *types.ExchangeRate) types.ExchangeRate {
 	elem := *r
 	elem.AsOf = elem.AsOf.UTC()
 	elem.FetchedAt = elem.FetchedAt.UTC()
+	elem.RateExact = r.Exact().Round(types.MaxRateScale)
+	elem.Rate = elem.RateExact.Float64()
+	elem.Metadata = maps.Clone(r.Metadata)
 
-	s.mu.Lock()
-	s.data[k] = elem // key is unique
-	s.mu.Unlock()
+	return elem
+}
 
-	return nil
+// revisionStatus checks if the rate is a new revision of its key.
+// Only corrections of the latest revision are stored.
+// Must be called with the lock held
+func (s *Storage) revisionStatus(k key, elem *types.ExchangeRate) types.SaveStatus {
+	revisions := s.data[k]
+
+	if n := len(revisions); n > 0 && revisions[n-1].RateExact.Equal(elem.RateExact) {
+		return types.SaveStatusUnchanged
+	}
+
+	for _, revision := range revisions {
+		if revision.FetchedAt.Equal(elem.FetchedAt) {
+			return types.SaveStatusConflict // revision is unique
+		}
+	}
+
+	return types.SaveStatusSaved
+}
+
+// insertRevision inserts the rate revision, keeping the revisions sorted.
+// Must be called with the lock held
+func (s *Storage) insertRevision(k key, elem types.ExchangeRate) {
+	revisions := append(s.data[k], elem)
+
+	sort.SliceStable(revisions, func(i, j int) bool {
+		return revisions[i].FetchedAt.Before(revisions[j].FetchedAt)
+	})
+
+	s.data[k] = revisions
 }
 
 func (s *Storage) RateAsOf(
@@ -82,7 +225,12 @@ func (s *Storage) RateAsOf(
 
 	bestByBucket := make(map[bucket]types.ExchangeRate)
 
-	for _, v := range s.data {
+	for _, revisions := range s.data {
+		v, ok := revisionAt(revisions, query.KnownAt)
+		if !ok {
+			continue
+		}
+
 		if v.Base.String() != base {
 			continue
 		}
@@ -137,42 +285,103 @@ func (s *Storage) RateAsOf(
 		return out[i].RateType.String() < out[j].RateType.String()
 	})
 
-	total := int64(len(out))
-	if total == 0 {
-		return &types.Page[*types.ExchangeRate]{
-			Results: nil,
-			Total:   0,
-		}, nil
+	return paginate(out, query.Limit, query.Offset), nil
+}
+
+func (s *Storage) RateHistory(
+	_ context.Context,
+	query *types.HistoryQuery,
+) (*types.Page[*types.ExchangeRate], error) {
+	var (
+		from     = query.From.UTC()
+		to       = query.To.UTC()
+		base     = query.Base.String()
+		target   = query.Target.String()
+		interval = query.Interval
+
+		source, rateType   string
+		hasSource, hasType bool
+	)
+
+	if query.Source != nil {
+		source = query.Source.String()
+		hasSource = true
 	}
 
-	lim := query.Limit
-	if lim == 0 {
-		lim = 100
+	if query.RateType != nil {
+		rateType = query.RateType.String()
+		hasType = true
 	}
 
-	if lim > 500 {
-		lim = 500
+	type bucket struct {
+		source, rateType string
+		at               int64 // unix nanos of the bucket start
 	}
 
-	off := query.Offset
-	if off > total {
-		return &types.Page[*types.ExchangeRate]{
-			Results: nil,
-			Total:   total,
-		}, nil
+	s.mu.RLock()
+
+	bestByBucket := make(map[bucket]types.ExchangeRate)
+
+	for _, revisions := range s.data {
+		v, _ := revisionAt(revisions, nil)
+
+		if v.Base.String() != base || v.Target.String() != target {
+			continue
+		}
+
+		if hasSource && v.Source.String() != source {
+			continue
+		}
+
+		if hasType && v.RateType.String() != rateType {
+			continue
+		}
+
+		if v.AsOf.Before(from) || v.AsOf.After(to) {
+			continue
+		}
+
+		// Without an interval, every point is its own bucket
+		at := v.AsOf.UnixNano()
+		if interval > 0 {
+			at = from.Add(v.AsOf.Sub(from) / interval * interval).UnixNano()
+		}
+
+		b := bucket{
+			source:   v.Source.String(),
+			rateType: v.RateType.String(),
+			at:       at,
+		}
+
+		cur, ok := bestByBucket[b]
+		if !ok ||
+			v.AsOf.After(cur.AsOf) ||
+			(v.AsOf.Equal(cur.AsOf) && v.FetchedAt.After(cur.FetchedAt)) {
+			bestByBucket[b] = v
+		}
 	}
 
-	start := int(off)
-	end := start + int(lim)
+	s.mu.RUnlock()
 
-	if end > len(out) {
-		end = len(out)
+	out := make([]*types.ExchangeRate, 0, len(bestByBucket))
+	for _, v := range bestByBucket {
+		cp := v
+		out = append(out, &cp)
 	}
 
-	return &types.Page[*types.ExchangeRate]{
-		Results: out[start:end],
-		Total:   total,
-	}, nil
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].AsOf.Equal(out[j].AsOf) {
+			return out[i].AsOf.Before(out[j].AsOf)
+		}
+
+		if out[i].Source != out[j].Source {
+			return out[i].Source.String() < out[j].Source.String()
+		}
+
+		return out[i].RateType.String() < out[j].RateType.String()
+	})
+
+	return paginate(out, query.Limit, query.Offset), nil
 }
 
 func (s *Storage) ListSources(_ context.Context) ([]types.Source, error) {
@@ -223,3 +432,51 @@ func (s *Storage) ListCurrencies(_ context.Context) ([]types.Currency, error) {
 
 	return out, nil
 }
+
+// revisionAt returns the latest rate revision known at the given time,
+// or the latest revision if no time is given
+func revisionAt(revisions []types.ExchangeRate, knownAt *time.Time) (types.ExchangeRate, bool) {
+	for i := len(revisions) - 1; i >= 0; i-- {
+		if knownAt == nil || !revisions[i].FetchedAt.After(*knownAt) {
+			return revisions[i], true
+		}
+	}
+
+	return types.ExchangeRate{}, false
+}
+
+// paginate returns the requested page of the sorted results
+func paginate(
+	out []*types.ExchangeRate,
+	limit int32,
+	offset int64,
+) *types.Page[*types.ExchangeRate] {
+	total := int64(len(out))
+	if total == 0 {
+		return &types.Page[*types.ExchangeRate]{
+			Results: nil,
+			Total:   0,
+		}
+	}
+
+	lim := types.PageLimit(limit)
+
+	if offset >= total {
+		return &types.Page[*types.ExchangeRate]{
+			Results: nil,
+			Total:   total,
+		}
+	}
+
+	start := int(offset)
+	end := start + int(lim)
+
+	if end > len(out) {
+		end = len(out)
+	}
+
+	return &types.Page[*types.ExchangeRate]{
+		Results: out[start:end],
+		Total:   total,
+	}
+}
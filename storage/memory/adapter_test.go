@@ -0,0 +1,146 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/provider/currencies"
+	"github.com/sig-0/fxrates/storage"
+	"github.com/sig-0/fxrates/storage/storagetest"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+func TestStorage_Conformance(t *testing.T) {
+	t.Parallel()
+
+	t.Run("in-memory", func(t *testing.T) {
+		t.Parallel()
+
+		storagetest.Run(t, func(t *testing.T) storage.Storage {
+			t.Helper()
+
+			s, err := NewStorage()
+			require.NoError(t, err)
+
+			return s
+		})
+	})
+
+	t.Run("persistent", func(t *testing.T) {
+		t.Parallel()
+
+		storagetest.Run(t, func(t *testing.T) storage.Storage {
+			t.Helper()
+
+			s, err := NewStorage(WithDataDir(t.TempDir()))
+			require.NoError(t, err)
+
+			t.Cleanup(func() {
+				_ = s.Close()
+			})
+
+			return s
+		})
+	})
+}
+
+func TestStorage_Revisions(t *testing.T) {
+	t.Parallel()
+
+	var (
+		asOf      = time.Date(2026, time.January, 7, 0, 0, 0, 0, time.UTC)
+		published = asOf.Add(-time.Hour * 8)
+		corrected = asOf.Add(-time.Hour * 4)
+
+		newRate = func(rate float64, fetchedAt time.Time) *types.ExchangeRate {
+			return &types.ExchangeRate{
+				AsOf:      asOf,
+				FetchedAt: fetchedAt,
+				Base:      currencies.USD,
+				Target:    currencies.VES,
+				RateType:  types.RateTypeMID,
+				Source:    "BCV",
+				Rate:      rate,
+			}
+		}
+
+		query = func(knownAt *time.Time) *types.RateQuery {
+			target := currencies.VES
+
+			return &types.RateQuery{
+				Base:    currencies.USD,
+				Target:  &target,
+				KnownAt: knownAt,
+			}
+		}
+	)
+
+	s, err := NewStorage()
+	require.NoError(t, err)
+
+	require.NoError(t, s.SaveExchangeRate(context.Background(), newRate(300, published)))
+	require.NoError(t, s.SaveExchangeRate(context.Background(), newRate(301, corrected)))
+
+	// Unchanged refetches are not stored as revisions
+	require.NoError(t, s.SaveExchangeRate(context.Background(), newRate(301, corrected.Add(time.Hour))))
+
+	t.Run("stored revisions", func(t *testing.T) {
+		t.Parallel()
+
+		for _, revisions := range s.data {
+			assert.Len(t, revisions, 2)
+		}
+	})
+
+	t.Run("latest revision", func(t *testing.T) {
+		t.Parallel()
+
+		page, err := s.RateAsOf(context.Background(), query(nil), asOf)
+		require.NoError(t, err)
+
+		require.Len(t, page.Results, 1)
+		assert.Equal(t, 301.0, page.Results[0].Rate)
+	})
+
+	t.Run("known at", func(t *testing.T) {
+		t.Parallel()
+
+		knownAt := corrected.Add(-time.Minute)
+
+		page, err := s.RateAsOf(context.Background(), query(&knownAt), asOf)
+		require.NoError(t, err)
+
+		require.Len(t, page.Results, 1)
+		assert.Equal(t, 300.0, page.Results[0].Rate)
+	})
+
+	t.Run("known before publication", func(t *testing.T) {
+		t.Parallel()
+
+		knownAt := published.Add(-time.Minute)
+
+		page, err := s.RateAsOf(context.Background(), query(&knownAt), asOf)
+		require.NoError(t, err)
+
+		assert.Empty(t, page.Results)
+	})
+
+	t.Run("history uses the latest revision", func(t *testing.T) {
+		t.Parallel()
+
+		page, err := s.RateHistory(context.Background(), &types.HistoryQuery{
+			From:   asOf.Add(-time.Hour),
+			To:     asOf.Add(time.Hour),
+			Base:   currencies.USD,
+			Target: currencies.VES,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, page.Results, 1)
+		assert.Equal(t, 301.0, page.Results[0].Rate)
+	})
+}
@@ -0,0 +1,32 @@
+package memory
+
+import (
+	"log/slog"
+	"time"
+)
+
+type Option func(s *Storage)
+
+// WithDataDir enables persistence to the given directory.
+// Every saved rate is appended to a write-ahead log, which is periodically
+// compacted into a snapshot. Both are restored when the storage is created
+func WithDataDir(dir string) Option {
+	return func(s *Storage) {
+		s.dataDir = dir
+	}
+}
+
+// WithSnapshotInterval specifies the interval between snapshots, when persistence is enabled.
+// Defaults to 5m
+func WithSnapshotInterval(interval time.Duration) Option {
+	return func(s *Storage) {
+		s.snapshotInterval = interval
+	}
+}
+
+// WithLogger specifies the logger for the storage
+func WithLogger(l *slog.Logger) Option {
+	return func(s *Storage) {
+		s.logger = l
+	}
+}
@@ -0,0 +1,429 @@
+package memory
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+const (
+	// snapshotFile is the name of the snapshot file in the data dir
+	snapshotFile = "snapshot.json"
+
+	// walFile is the name of the write-ahead log file in the data dir
+	walFile = "wal.jsonl"
+
+	// snapshotVersion is the current snapshot format version
+	snapshotVersion = 1
+)
+
+var (
+	// ErrClosed is returned when saving rates after the storage was closed,
+	// since they can no longer be persisted
+	ErrClosed = errors.New("storage is closed")
+
+	errUnsupportedSnapshot = errors.New("unsupported snapshot version")
+)
+
+// snapshot is the on-disk format of the storage snapshot
+type snapshot struct {
+	TakenAt time.Time             `json:"taken_at"`
+	Rates   []*types.ExchangeRate `json:"rates"`
+	Version int                   `json:"version"`
+}
+
+// Start periodically snapshots the storage, until the context is cancelled.
+// A final snapshot is taken on shutdown, and the write-ahead log is closed.
+// It returns immediately if persistence is not enabled
+func (s *Storage) Start(ctx context.Context) error {
+	if s.dataDir == "" {
+		return nil
+	}
+
+	ticker := time.NewTicker(s.snapshotInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if err := s.Snapshot(); err != nil {
+				s.logger.Error("unable to take final snapshot", "err", err)
+			}
+
+			return s.Close()
+		case <-ticker.C:
+			if err := s.Snapshot(); err != nil {
+				s.logger.Error("unable to take snapshot", "err", err)
+			}
+		}
+	}
+}
+
+// Snapshot writes the storage snapshot to the data dir, and compacts the write-ahead log.
+// It's a no-op if persistence is not enabled
+func (s *Storage) Snapshot() error {
+	if s.dataDir == "" {
+		return nil
+	}
+
+	s.snapshotMu.Lock()
+	defer s.snapshotMu.Unlock()
+
+	// Copy the rates, noting how much of the log they cover
+	s.mu.RLock()
+
+	var (
+		snap = &snapshot{
+			Version: snapshotVersion,
+			TakenAt: time.Now().UTC(),
+			Rates:   make([]*types.ExchangeRate, 0, len(s.data)),
+		}
+		covered = s.walSize
+	)
+
+	for _, revisions := range s.data {
+		for i := range revisions {
+			rate := revisions[i]
+			snap.Rates = append(snap.Rates, &rate)
+		}
+	}
+
+	s.mu.RUnlock()
+
+	if err := writeSnapshot(filepath.Join(s.dataDir, snapshotFile), snap); err != nil {
+		return err
+	}
+
+	// Drop the snapshotted part of the log. If this fails,
+	// replaying the whole log over the snapshot is still safe
+	if err := s.compactWAL(covered); err != nil {
+		return fmt.Errorf("unable to compact write-ahead log: %w", err)
+	}
+
+	s.logger.Info(
+		"storage snapshot taken",
+		"rates", len(snap.Rates),
+	)
+
+	return nil
+}
+
+// Close flushes and closes the write-ahead log, if any.
+// Saving rates after closing a persisted storage fails with ErrClosed
+func (s *Storage) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.wal == nil {
+		return nil
+	}
+
+	var (
+		syncErr  = s.wal.Sync()
+		closeErr = s.wal.Close()
+	)
+
+	s.wal = nil
+
+	return errors.Join(syncErr, closeErr)
+}
+
+// restore loads the snapshot and replays the write-ahead log
+// from the data dir, and opens the log for appending
+func (s *Storage) restore() error {
+	if err := os.MkdirAll(s.dataDir, 0o750); err != nil {
+		return fmt.Errorf("unable to create data dir: %w", err)
+	}
+
+	snapshotRates, err := s.loadSnapshot()
+	if err != nil {
+		return err
+	}
+
+	replayed, err := s.replayWAL()
+	if err != nil {
+		return err
+	}
+
+	wal, err := os.OpenFile(
+		filepath.Join(s.dataDir, walFile),
+		os.O_CREATE|os.O_WRONLY|os.O_APPEND,
+		0o600,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to open write-ahead log: %w", err)
+	}
+
+	info, err := wal.Stat()
+	if err != nil {
+		_ = wal.Close()
+
+		return fmt.Errorf("unable to stat write-ahead log: %w", err)
+	}
+
+	s.wal = wal
+	s.walSize = info.Size()
+
+	s.logger.Info(
+		"storage restored",
+		"snapshot_rates", snapshotRates,
+		"log_entries", replayed,
+	)
+
+	return nil
+}
+
+// loadSnapshot loads the snapshot from the data dir, if any.
+// The revisions are restored as they were saved
+func (s *Storage) loadSnapshot() (int, error) {
+	raw, err := os.ReadFile(filepath.Join(s.dataDir, snapshotFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("unable to read snapshot: %w", err)
+	}
+
+	var snap snapshot
+	if err = json.Unmarshal(raw, &snap); err != nil {
+		return 0, fmt.Errorf("unable to decode snapshot: %w", err)
+	}
+
+	if snap.Version != snapshotVersion {
+		return 0, fmt.Errorf("%w: %d", errUnsupportedSnapshot, snap.Version)
+	}
+
+	for _, rate := range snap.Rates {
+		s.insertRevision(keyOf(rate), *rate)
+	}
+
+	return len(snap.Rates), nil
+}
+
+// replayWAL saves the write-ahead log entries, in order.
+// Entries already covered by the snapshot are skipped as duplicates.
+// A torn entry at the end of the log (from a crash mid-write) is truncated
+func (s *Storage) replayWAL() (int, error) {
+	path := filepath.Join(s.dataDir, walFile)
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("unable to open write-ahead log: %w", err)
+	}
+
+	var (
+		reader = bufio.NewReader(f)
+
+		replayed int
+		valid    int64 // offset of the end of the last valid entry
+	)
+
+	for {
+		line, readErr := reader.ReadBytes('\n')
+
+		// Only complete entries are valid
+		if readErr == nil {
+			var rate types.ExchangeRate
+			if err = json.Unmarshal(line, &rate); err != nil {
+				break
+			}
+
+			if k := keyOf(&rate); s.revisionStatus(k, &rate) == types.SaveStatusSaved {
+				s.insertRevision(k, rate)
+			}
+
+			replayed++
+			valid += int64(len(line))
+
+			continue
+		}
+
+		if !errors.Is(readErr, io.EOF) {
+			_ = f.Close()
+
+			return 0, fmt.Errorf("unable to read write-ahead log: %w", readErr)
+		}
+
+		if len(bytes.TrimSpace(line)) == 0 {
+			valid += int64(len(line))
+		}
+
+		break
+	}
+
+	info, err := f.Stat()
+
+	_ = f.Close()
+
+	if err != nil {
+		return 0, fmt.Errorf("unable to stat write-ahead log: %w", err)
+	}
+
+	if valid < info.Size() {
+		s.logger.Warn(
+			"truncating invalid write-ahead log entries",
+			"valid_bytes", valid,
+			"total_bytes", info.Size(),
+		)
+
+		if err = os.Truncate(path, valid); err != nil {
+			return 0, fmt.Errorf("unable to truncate write-ahead log: %w", err)
+		}
+	}
+
+	return replayed, nil
+}
+
+// appendWAL appends the rates to the write-ahead log in a single write, if enabled,
+// and syncs it to disk before returning. Must be called with the lock held
+func (s *Storage) appendWAL(rates ...*types.ExchangeRate) error {
+	if s.dataDir == "" || len(rates) == 0 {
+		return nil
+	}
+
+	if s.wal == nil {
+		return ErrClosed
+	}
+
+	var entries []byte
+
+	for _, rate := range rates {
+		entry, err := json.Marshal(rate)
+		if err != nil {
+			return fmt.Errorf("unable to encode log entry: %w", err)
+		}
+
+		entries = append(append(entries, entry...), '\n')
+	}
+
+	if _, err := s.wal.Write(entries); err != nil {
+		// Drop the partially written entries, so they're not replayed
+		if truncErr := s.wal.Truncate(s.walSize); truncErr != nil {
+			err = errors.Join(err, truncErr)
+		}
+
+		return fmt.Errorf("unable to append log entries: %w", err)
+	}
+
+	// The saves are only acknowledged once they're on disk
+	if err := s.wal.Sync(); err != nil {
+		if truncErr := s.wal.Truncate(s.walSize); truncErr != nil {
+			err = errors.Join(err, truncErr)
+		}
+
+		return fmt.Errorf("unable to sync log entries: %w", err)
+	}
+
+	s.walSize += int64(len(entries))
+
+	return nil
+}
+
+// compactWAL drops the first covered bytes of the write-ahead log,
+// keeping the entries appended after the snapshot was taken
+func (s *Storage) compactWAL(covered int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.wal == nil {
+		return nil
+	}
+
+	var (
+		path    = filepath.Join(s.dataDir, walFile)
+		tmpPath = path + ".tmp"
+	)
+
+	current, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer current.Close()
+
+	if _, err = current.Seek(covered, io.SeekStart); err != nil {
+		return err
+	}
+
+	tail, err := io.ReadAll(current)
+	if err != nil {
+		return err
+	}
+
+	if err = writeFileSync(tmpPath, tail); err != nil {
+		return err
+	}
+
+	// Swap the log, and reopen it for appending
+	if err = os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+
+	wal, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+
+	_ = s.wal.Close()
+
+	s.wal = wal
+	s.walSize = int64(len(tail))
+
+	return nil
+}
+
+// writeSnapshot atomically writes the snapshot to the given path
+func writeSnapshot(path string, snap *snapshot) error {
+	raw, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("unable to encode snapshot: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+
+	if err = writeFileSync(tmpPath, raw); err != nil {
+		return fmt.Errorf("unable to write snapshot: %w", err)
+	}
+
+	if err = os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("unable to replace snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// writeFileSync writes the file, and syncs it to disk
+func writeFileSync(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err = f.Write(data); err != nil {
+		_ = f.Close()
+
+		return err
+	}
+
+	if err = f.Sync(); err != nil {
+		_ = f.Close()
+
+		return err
+	}
+
+	return f.Close()
+}
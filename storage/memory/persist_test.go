@@ -0,0 +1,323 @@
+package memory
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/provider/currencies"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+// newPersistedRate creates a new USD/VES rate, fetched at the given time
+func newPersistedRate(rate string, source types.Source, fetchedAt time.Time) *types.ExchangeRate {
+	exact := types.MustParseDecimal(rate)
+
+	return &types.ExchangeRate{
+		AsOf:      time.Date(2026, time.January, 7, 0, 0, 0, 0, time.UTC),
+		FetchedAt: fetchedAt,
+		Base:      currencies.USD,
+		Target:    currencies.VES,
+		RateType:  types.RateTypeMID,
+		Source:    source,
+		Rate:      exact.Float64(),
+		RateExact: exact,
+	}
+}
+
+// latestRates returns all latest USD rates in the storage
+func latestRates(t *testing.T, s *Storage) []*types.ExchangeRate {
+	t.Helper()
+
+	page, err := s.RateAsOf(
+		context.Background(),
+		&types.RateQuery{Base: currencies.USD},
+		time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC),
+	)
+	require.NoError(t, err)
+
+	return page.Results
+}
+
+// revisionCount returns the number of stored revisions
+func revisionCount(s *Storage) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	count := 0
+	for _, revisions := range s.data {
+		count += len(revisions)
+	}
+
+	return count
+}
+
+func TestStorage_Persistence(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx       = context.Background()
+		fetchedAt = time.Date(2026, time.January, 7, 9, 0, 0, 0, time.UTC)
+	)
+
+	t.Run("restored from the write-ahead log", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		s, err := NewStorage(WithDataDir(dir))
+		require.NoError(t, err)
+
+		require.NoError(t, s.SaveExchangeRate(ctx, newPersistedRate("341.74123456", "BCV", fetchedAt)))
+		require.NoError(t, s.SaveExchangeRate(ctx, newPersistedRate("341.8", "BCV", fetchedAt.Add(time.Hour))))
+
+		// Simulate a crash, without a snapshot
+		require.NoError(t, s.Close())
+
+		restored, err := NewStorage(WithDataDir(dir))
+		require.NoError(t, err)
+
+		defer restored.Close()
+
+		rates := latestRates(t, restored)
+
+		require.Len(t, rates, 1)
+		assert.Equal(t, "341.8", rates[0].RateExact.String())
+		assert.Equal(t, 2, revisionCount(restored))
+	})
+
+	t.Run("batch restored from the write-ahead log", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		s, err := NewStorage(WithDataDir(dir))
+		require.NoError(t, err)
+
+		statuses, err := s.SaveExchangeRates(ctx, []*types.ExchangeRate{
+			newPersistedRate("341.7412", "BCV", fetchedAt),
+			newPersistedRate("341.7412", "BCV", fetchedAt.Add(time.Hour)),
+			newPersistedRate("500.5", "Binance", fetchedAt),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(
+			t,
+			[]types.SaveStatus{types.SaveStatusSaved, types.SaveStatusUnchanged, types.SaveStatusSaved},
+			statuses,
+		)
+
+		require.NoError(t, s.Close())
+
+		restored, err := NewStorage(WithDataDir(dir))
+		require.NoError(t, err)
+
+		defer restored.Close()
+
+		assert.Len(t, latestRates(t, restored), 2)
+		assert.Equal(t, 2, revisionCount(restored))
+	})
+
+	t.Run("restored from the snapshot and log", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		s, err := NewStorage(WithDataDir(dir))
+		require.NoError(t, err)
+
+		require.NoError(t, s.SaveExchangeRate(ctx, newPersistedRate("341.7412", "BCV", fetchedAt)))
+		require.NoError(t, s.Snapshot())
+
+		// The snapshotted entries are compacted out of the log
+		info, err := os.Stat(filepath.Join(dir, walFile))
+		require.NoError(t, err)
+		assert.Zero(t, info.Size())
+
+		require.NoError(t, s.SaveExchangeRate(ctx, newPersistedRate("500.5", "Binance", fetchedAt)))
+		require.NoError(t, s.Close())
+
+		restored, err := NewStorage(WithDataDir(dir))
+		require.NoError(t, err)
+
+		defer restored.Close()
+
+		rates := latestRates(t, restored)
+
+		require.Len(t, rates, 2)
+		assert.Equal(t, types.Source("BCV"), rates[0].Source)
+		assert.Equal(t, "341.7412", rates[0].RateExact.String())
+		assert.Equal(t, types.Source("Binance"), rates[1].Source)
+		assert.Equal(t, "500.5", rates[1].RateExact.String())
+	})
+
+	t.Run("log replay over the snapshot is idempotent", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		s, err := NewStorage(WithDataDir(dir))
+		require.NoError(t, err)
+
+		require.NoError(t, s.SaveExchangeRate(ctx, newPersistedRate("341", "BCV", fetchedAt)))
+		require.NoError(t, s.SaveExchangeRate(ctx, newPersistedRate("342", "BCV", fetchedAt.Add(time.Hour))))
+		require.NoError(t, s.SaveExchangeRate(ctx, newPersistedRate("341", "BCV", fetchedAt.Add(2*time.Hour))))
+
+		log, err := os.ReadFile(filepath.Join(dir, walFile))
+		require.NoError(t, err)
+
+		require.NoError(t, s.Snapshot())
+		require.NoError(t, s.Close())
+
+		// Simulate a crash before the log was compacted
+		require.NoError(t, os.WriteFile(filepath.Join(dir, walFile), log, 0o600))
+
+		restored, err := NewStorage(WithDataDir(dir))
+		require.NoError(t, err)
+
+		defer restored.Close()
+
+		assert.Equal(t, 3, revisionCount(restored))
+	})
+
+	t.Run("torn log entry is truncated", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		s, err := NewStorage(WithDataDir(dir))
+		require.NoError(t, err)
+
+		require.NoError(t, s.SaveExchangeRate(ctx, newPersistedRate("341.7412", "BCV", fetchedAt)))
+		require.NoError(t, s.Close())
+
+		path := filepath.Join(dir, walFile)
+
+		valid, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		// Simulate a crash mid-write
+		torn := append(append([]byte{}, valid...), []byte(`{"as_of":"2026-01-07T00:00:00Z","ba`)...)
+		require.NoError(t, os.WriteFile(path, torn, 0o600))
+
+		restored, err := NewStorage(WithDataDir(dir))
+		require.NoError(t, err)
+
+		assert.Len(t, latestRates(t, restored), 1)
+
+		// New entries are appended after the last valid one
+		require.NoError(t, restored.SaveExchangeRate(ctx, newPersistedRate("500.5", "Binance", fetchedAt)))
+		require.NoError(t, restored.Close())
+
+		reopened, err := NewStorage(WithDataDir(dir))
+		require.NoError(t, err)
+
+		defer reopened.Close()
+
+		assert.Len(t, latestRates(t, reopened), 2)
+	})
+
+	t.Run("unsupported snapshot", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		require.NoError(
+			t,
+			os.WriteFile(filepath.Join(dir, snapshotFile), []byte(`{"version":999,"rates":[]}`), 0o600),
+		)
+
+		_, err := NewStorage(WithDataDir(dir))
+		assert.ErrorIs(t, err, errUnsupportedSnapshot)
+	})
+
+	t.Run("final snapshot on shutdown", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		s, err := NewStorage(WithDataDir(dir), WithSnapshotInterval(time.Hour))
+		require.NoError(t, err)
+
+		require.NoError(t, s.SaveExchangeRate(ctx, newPersistedRate("341.7412", "BCV", fetchedAt)))
+
+		runCtx, cancelFn := context.WithCancel(ctx)
+
+		done := make(chan error, 1)
+
+		go func() {
+			done <- s.Start(runCtx)
+		}()
+
+		cancelFn()
+
+		select {
+		case err = <-done:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("storage did not shut down")
+		}
+
+		// The snapshot holds everything, and the log is empty
+		info, err := os.Stat(filepath.Join(dir, walFile))
+		require.NoError(t, err)
+		assert.Zero(t, info.Size())
+
+		_, err = os.Stat(filepath.Join(dir, snapshotFile))
+		require.NoError(t, err)
+	})
+
+	t.Run("saves fail after close", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+
+		s, err := NewStorage(WithDataDir(dir))
+		require.NoError(t, err)
+
+		require.NoError(t, s.SaveExchangeRate(ctx, newPersistedRate("341.7412", "BCV", fetchedAt)))
+		require.NoError(t, s.Close())
+
+		// Rates saved after closing can't be persisted, so they're rejected
+		assert.ErrorIs(
+			t,
+			s.SaveExchangeRate(ctx, newPersistedRate("342.1", "BCV", fetchedAt.Add(time.Hour))),
+			ErrClosed,
+		)
+
+		_, err = s.SaveExchangeRates(ctx, []*types.ExchangeRate{
+			newPersistedRate("350", "Binance P2P", fetchedAt.Add(time.Hour)),
+		})
+		assert.ErrorIs(t, err, ErrClosed)
+
+		assert.Equal(t, 1, revisionCount(s))
+
+		// Only the rate saved before closing is restored
+		restored, err := NewStorage(WithDataDir(dir))
+		require.NoError(t, err)
+
+		defer restored.Close()
+
+		rates := latestRates(t, restored)
+		require.Len(t, rates, 1)
+
+		assert.Equal(t, "341.7412", rates[0].RateExact.String())
+	})
+
+	t.Run("persistence disabled", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := NewStorage()
+		require.NoError(t, err)
+
+		// Start returns right away, and snapshots are no-ops
+		require.NoError(t, s.Start(ctx))
+		require.NoError(t, s.Snapshot())
+		require.NoError(t, s.Close())
+	})
+}
@@ -10,13 +10,17 @@ import (
 type (
 	SaveExchangeRateDelegate func(context.Context, *types.ExchangeRate) error
 	RateAsOfDelegate         func(context.Context, *types.RateQuery, time.Time) (*types.Page[*types.ExchangeRate], error)
+	RateHistoryDelegate      func(context.Context, *types.HistoryQuery) (*types.Page[*types.ExchangeRate], error)
 	ListSourcesDelegate      func(context.Context) ([]types.Source, error)
 	ListCurrenciesDelegate   func(context.Context) ([]types.Currency, error)
+
+	SaveExchangeRatesDelegate func(context.Context, []*types.ExchangeRate) ([]types.SaveStatus, error)
 )
 
 type Storage struct {
 	SaveExchangeRateFn SaveExchangeRateDelegate
 	RateAsOfFn         RateAsOfDelegate
+	RateHistoryFn      RateHistoryDelegate
 	ListSourcesFn      ListSourcesDelegate
 	ListCurrenciesFn   ListCurrenciesDelegate
 }
@@ -41,6 +45,17 @@ func (m *Storage) RateAsOf(
 	return nil, nil
 }
 
+func (m *Storage) RateHistory(
+	ctx context.Context,
+	query *types.HistoryQuery,
+) (*types.Page[*types.ExchangeRate], error) {
+	if m.RateHistoryFn != nil {
+		return m.RateHistoryFn(ctx, query)
+	}
+
+	return nil, nil
+}
+
 func (m *Storage) ListSources(ctx context.Context) ([]types.Source, error) {
 	if m.ListSourcesFn != nil {
 		return m.ListSourcesFn(ctx)
@@ -56,3 +71,21 @@ func (m *Storage) ListCurrencies(ctx context.Context) ([]types.Currency, error)
 
 	return nil, nil
 }
+
+// BatchStorage is a mock storage that also implements storage.BatchSaver
+type BatchStorage struct {
+	Storage
+
+	SaveExchangeRatesFn SaveExchangeRatesDelegate
+}
+
+func (m *BatchStorage) SaveExchangeRates(
+	ctx context.Context,
+	rates []*types.ExchangeRate,
+) ([]types.SaveStatus, error) {
+	if m.SaveExchangeRatesFn != nil {
+		return m.SaveExchangeRatesFn(ctx, rates)
+	}
+
+	return nil, nil
+}
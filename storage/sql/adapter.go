@@ -2,10 +2,9 @@ package sql
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"math"
-	"math/big"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -15,13 +14,23 @@ import (
 	"github.com/sig-0/fxrates/storage/types"
 )
 
+// DB is the database the storage runs on.
+// Both pgx.Conn and pgxpool.Pool satisfy it
+type DB interface {
+	pgStorage.DBTX
+
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
 type Storage struct {
+	db      DB
 	queries *pgStorage.Queries
 }
 
-func NewStorage(queries *pgStorage.Queries) *Storage {
+func NewStorage(db DB) *Storage {
 	return &Storage{
-		queries: queries,
+		db:      db,
+		queries: pgStorage.New(db),
 	}
 }
 
@@ -32,11 +41,12 @@ func (s *Storage) SaveExchangeRate(
 	arg := pgStorage.SaveExchangeRateParams{
 		Base:      rate.Base.String(),
 		Target:    rate.Target.String(),
-		Rate:      floatToNumeric(rate.Rate),
+		Rate:      decimalToNumeric(rate.Exact().Round(types.MaxRateScale)),
 		RateType:  rate.RateType.String(),
 		Source:    rate.Source.String(),
 		AsOf:      timeToTimestampz(rate.AsOf),
 		FetchedAt: timeToTimestampz(rate.FetchedAt),
+		Metadata:  metadataToJSON(rate.Metadata),
 	}
 
 	if err := s.queries.SaveExchangeRate(ctx, arg); err != nil {
@@ -46,6 +56,71 @@ func (s *Storage) SaveExchangeRate(
 	return nil
 }
 
+// SaveExchangeRates saves the rates in a single transaction,
+// sending all the inserts in a single batch
+func (s *Storage) SaveExchangeRates(
+	ctx context.Context,
+	rates []*types.ExchangeRate,
+) ([]types.SaveStatus, error) {
+	args := make([]pgStorage.SaveExchangeRatesParams, 0, len(rates))
+
+	for _, rate := range rates {
+		args = append(args, pgStorage.SaveExchangeRatesParams{
+			Base:      rate.Base.String(),
+			Target:    rate.Target.String(),
+			Rate:      decimalToNumeric(rate.Exact().Round(types.MaxRateScale)),
+			RateType:  rate.RateType.String(),
+			Source:    rate.Source.String(),
+			AsOf:      timeToTimestampz(rate.AsOf),
+			FetchedAt: timeToTimestampz(rate.FetchedAt),
+			Metadata:  metadataToJSON(rate.Metadata),
+		})
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin transaction: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+	}()
+
+	var (
+		statuses = make([]types.SaveStatus, len(rates))
+		batchErr error
+	)
+
+	s.queries.WithTx(tx).SaveExchangeRates(ctx, args).QueryRow(
+		func(i int, row pgStorage.SaveExchangeRatesRow, err error) {
+			switch {
+			case err != nil:
+				// Once a row fails, the transaction is aborted,
+				// so only the first error is relevant
+				if batchErr == nil {
+					batchErr = err
+				}
+			case row.Saved:
+				statuses[i] = types.SaveStatusSaved
+			case row.Unchanged:
+				statuses[i] = types.SaveStatusUnchanged
+			default:
+				statuses[i] = types.SaveStatusConflict
+			}
+		},
+	)
+
+	if batchErr != nil {
+		return nil, fmt.Errorf("unable to save exchange rates: %w", batchErr)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("unable to commit exchange rates: %w", err)
+	}
+
+	return statuses, nil
+}
+
 func (s *Storage) RateAsOf(
 	ctx context.Context,
 	query *types.RateQuery,
@@ -54,7 +129,7 @@ func (s *Storage) RateAsOf(
 	arg := pgStorage.RateAsOfParams{
 		Base:   query.Base.String(),
 		AsOf:   timeToTimestampz(t),
-		Limit:  query.Limit,
+		Limit:  types.PageLimit(query.Limit),
 		Offset: query.Offset,
 
 		Target:   stringArgToText(query.Target),
@@ -62,6 +137,10 @@ func (s *Storage) RateAsOf(
 		RateType: stringArgToText(query.RateType),
 	}
 
+	if query.KnownAt != nil {
+		arg.KnownAt = timeToTimestampz(*query.KnownAt)
+	}
+
 	rows, err := s.queries.RateAsOf(ctx, arg)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -75,9 +154,100 @@ func (s *Storage) RateAsOf(
 	}
 
 	if len(rows) == 0 {
+		var total int64
+
+		// The offset can be past the last row, so fetch the total separately
+		if arg.Offset > 0 {
+			arg.Offset, arg.Limit = 0, 1
+
+			first, err := s.queries.RateAsOf(ctx, arg)
+			if err != nil {
+				return nil, fmt.Errorf("unable to fetch rates total: %w", err)
+			}
+
+			if len(first) > 0 {
+				total = first[0].Total
+			}
+		}
+
+		return &types.Page[*types.ExchangeRate]{
+			Results: nil,
+			Total:   total,
+		}, nil // valid case
+	}
+
+	out := make([]*types.ExchangeRate, 0, len(rows))
+	for i := range rows {
+		pgRate := pgStorage.ExchangeRate{
+			ID:        rows[i].ID,
+			Base:      rows[i].Base,
+			Target:    rows[i].Target,
+			Rate:      rows[i].Rate,
+			RateType:  rows[i].RateType,
+			Source:    rows[i].Source,
+			AsOf:      rows[i].AsOf,
+			FetchedAt: rows[i].FetchedAt,
+			Metadata:  rows[i].Metadata,
+		}
+
+		out = append(out, parseExchangeRate(pgRate))
+	}
+
+	return &types.Page[*types.ExchangeRate]{
+		Results: out,
+		Total:   rows[0].Total,
+	}, nil
+}
+
+func (s *Storage) RateHistory(
+	ctx context.Context,
+	query *types.HistoryQuery,
+) (*types.Page[*types.ExchangeRate], error) {
+	arg := pgStorage.RateHistoryParams{
+		Base:           query.Base.String(),
+		Target:         query.Target.String(),
+		FromTime:       timeToTimestampz(query.From),
+		ToTime:         timeToTimestampz(query.To),
+		BucketInterval: durationToInterval(query.Interval),
+		Limit:          types.PageLimit(query.Limit),
+		Offset:         query.Offset,
+
+		Source:   stringArgToText(query.Source),
+		RateType: stringArgToText(query.RateType),
+	}
+
+	rows, err := s.queries.RateHistory(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &types.Page[*types.ExchangeRate]{
+				Results: nil,
+				Total:   0,
+			}, nil // valid case
+		}
+
+		return nil, fmt.Errorf("unable to fetch rate history: %w", err)
+	}
+
+	if len(rows) == 0 {
+		var total int64
+
+		// The offset can be past the last row, so fetch the total separately
+		if arg.Offset > 0 {
+			arg.Offset, arg.Limit = 0, 1
+
+			first, err := s.queries.RateHistory(ctx, arg)
+			if err != nil {
+				return nil, fmt.Errorf("unable to fetch rate history total: %w", err)
+			}
+
+			if len(first) > 0 {
+				total = first[0].Total
+			}
+		}
+
 		return &types.Page[*types.ExchangeRate]{
 			Results: nil,
-			Total:   0,
+			Total:   total,
 		}, nil // valid case
 	}
 
@@ -92,6 +262,7 @@ func (s *Storage) RateAsOf(
 			Source:    rows[i].Source,
 			AsOf:      rows[i].AsOf,
 			FetchedAt: rows[i].FetchedAt,
+			Metadata:  rows[i].Metadata,
 		}
 
 		out = append(out, parseExchangeRate(pgRate))
@@ -155,40 +326,59 @@ func parseExchangeRate(pgRate pgStorage.ExchangeRate) *types.ExchangeRate {
 		return nil
 	}
 
+	rate := numericToDecimal(pgRate.Rate)
+
 	return &types.ExchangeRate{
 		Base:      types.Currency(pgRate.Base),
 		Target:    types.Currency(pgRate.Target),
-		Rate:      numericToFloat(pgRate.Rate),
+		Rate:      rate.Float64(),
+		RateExact: rate,
 		RateType:  types.RateType(pgRate.RateType),
 		Source:    types.Source(pgRate.Source),
 		AsOf:      timestampzToTime(pgRate.AsOf),
 		FetchedAt: timestampzToTime(pgRate.FetchedAt),
+		Metadata:  jsonToMetadata(pgRate.Metadata),
 	}
 }
 
-// floatToNumeric converts the float value to postgres numeric
-func floatToNumeric(value float64) pgtype.Numeric {
-	// round to 4dp and store as integer with exponent -4
-	i := int64(math.Round(value * 1e4))
-
-	return pgtype.Numeric{
-		Int:   big.NewInt(i),
-		Exp:   -4,
-		Valid: true,
+// metadataToJSON converts the rate metadata to a postgres JSON object, or NULL if there is none
+func metadataToJSON(metadata map[string]string) []byte {
+	if len(metadata) == 0 {
+		return nil
 	}
+
+	raw, _ := json.Marshal(metadata) //nolint:errcheck // string maps always marshal
+
+	return raw
 }
 
-// numericToFloat converts the postgres value to float
-func numericToFloat(value pgtype.Numeric) float64 {
-	f, _ := new(big.Rat).SetInt(value.Int).Float64()
+// jsonToMetadata converts the postgres JSON object to the rate metadata.
+// Values not written by the adapter are dropped
+func jsonToMetadata(raw []byte) map[string]string {
+	if len(raw) == 0 {
+		return nil
+	}
 
-	if value.Exp > 0 {
-		f *= math.Pow10(int(value.Exp))
-	} else if value.Exp < 0 {
-		f /= math.Pow10(int(-value.Exp))
+	var metadata map[string]string
+	if err := json.Unmarshal(raw, &metadata); err != nil {
+		return nil
 	}
 
-	return f
+	return metadata
+}
+
+// decimalToNumeric converts the decimal value to postgres numeric
+func decimalToNumeric(value types.Decimal) pgtype.Numeric {
+	return pgtype.Numeric{
+		Int:   value.Coefficient(),
+		Exp:   value.Exponent(),
+		Valid: true,
+	}
+}
+
+// numericToDecimal converts the postgres value to decimal
+func numericToDecimal(value pgtype.Numeric) types.Decimal {
+	return types.NewDecimal(value.Int, value.Exp)
 }
 
 // timeToTimestampz converts the time value to postgres timestamp
@@ -208,6 +398,14 @@ func timestampzToTime(ts pgtype.Timestamptz) time.Time {
 	return ts.Time
 }
 
+// durationToInterval converts the duration value to postgres interval
+func durationToInterval(d time.Duration) pgtype.Interval {
+	return pgtype.Interval{
+		Microseconds: d.Microseconds(),
+		Valid:        true,
+	}
+}
+
 // stringArgToText converts the given string value to postgres text
 func stringArgToText[T ~string](p *T) pgtype.Text {
 	if p == nil {
@@ -0,0 +1,79 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/storage"
+	"github.com/sig-0/fxrates/storage/storagetest"
+)
+
+// testDatabaseURLEnv is the env var with the DSN of the Postgres
+// instance used for the adapter tests. The tests are skipped if it's not set
+const testDatabaseURLEnv = "FXRATES_TEST_DATABASE_URL"
+
+func TestStorage_Conformance(t *testing.T) {
+	t.Parallel()
+
+	conn := newTestConn(t)
+
+	storagetest.Run(t, func(t *testing.T) storage.Storage {
+		t.Helper()
+
+		_, err := conn.Exec(context.Background(), "TRUNCATE exchange_rates")
+		require.NoError(t, err)
+
+		return NewStorage(conn)
+	})
+}
+
+// newTestConn connects to the test database, with a fresh migrated schema
+func newTestConn(t *testing.T) *pgx.Conn {
+	t.Helper()
+
+	conn := newTestSchemaConn(t)
+
+	migrator, err := NewMigrator(conn, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, migrator.Up(context.Background()))
+
+	return conn
+}
+
+// newTestSchemaConn connects to the test database, using a fresh empty schema
+// that is dropped once the test is done
+func newTestSchemaConn(t *testing.T) *pgx.Conn {
+	t.Helper()
+
+	dsn := os.Getenv(testDatabaseURLEnv)
+	if dsn == "" {
+		t.Skipf("%s not set", testDatabaseURLEnv)
+	}
+
+	ctx := context.Background()
+
+	conn, err := pgx.Connect(ctx, dsn)
+	require.NoError(t, err)
+
+	schema := pgx.Identifier{fmt.Sprintf("fxrates_test_%s", xid.New().String())}.Sanitize()
+
+	t.Cleanup(func() {
+		_, _ = conn.Exec(context.Background(), fmt.Sprintf("DROP SCHEMA IF EXISTS %s CASCADE", schema))
+		_ = conn.Close(context.Background())
+	})
+
+	_, err = conn.Exec(ctx, fmt.Sprintf("CREATE SCHEMA %s", schema))
+	require.NoError(t, err)
+
+	_, err = conn.Exec(ctx, fmt.Sprintf("SET search_path TO %s", schema))
+	require.NoError(t, err)
+
+	return conn
+}
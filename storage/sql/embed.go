@@ -2,7 +2,14 @@ package sql
 
 import "embed"
 
-// SchemaFS contains all SQL migration files under db/sql/schema/
+// SchemaFS contains all SQL migration files under schema/,
+// as <version>_<name>.up.sql and <version>_<name>.down.sql pairs
 //
 //go:embed schema/*.sql
 var SchemaFS embed.FS
+
+// MaintenanceFS contains the maintenance scripts under maintenance/.
+// These are destructive, and never run as part of the migrations
+//
+//go:embed maintenance/*.sql
+var MaintenanceFS embed.FS
@@ -17,4 +17,5 @@ type ExchangeRate struct {
 	Source    string
 	AsOf      pgtype.Timestamptz
 	FetchedAt pgtype.Timestamptz
+	Metadata  []byte
 }
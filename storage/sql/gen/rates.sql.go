@@ -14,17 +14,18 @@ import (
 const rateAsOf = `-- name: RateAsOf :many
 WITH latest AS (
   SELECT DISTINCT ON (target, source, rate_type)
-    id, base, target, rate, rate_type, source, as_of, fetched_at
+    id, base, target, rate, rate_type, source, as_of, fetched_at, metadata
   FROM exchange_rates
   WHERE base = $3
     AND ($4::text IS NULL OR target = $4::text)
     AND ($5::text IS NULL OR source = $5::text)
     AND ($6::text IS NULL OR rate_type = $6::text)
     AND as_of <= $7
-  ORDER BY target, source, rate_type, as_of DESC
+    AND ($8::timestamptz IS NULL OR fetched_at <= $8::timestamptz)
+  ORDER BY target, source, rate_type, as_of DESC, fetched_at DESC
 )
 SELECT
-  latest.id, latest.base, latest.target, latest.rate, latest.rate_type, latest.source, latest.as_of, latest.fetched_at,
+  latest.id, latest.base, latest.target, latest.rate, latest.rate_type, latest.source, latest.as_of, latest.fetched_at, latest.metadata,
   COUNT(*) OVER()::bigint AS total
 FROM latest
 ORDER BY target, source, rate_type
@@ -40,6 +41,7 @@ type RateAsOfParams struct {
 	Source   pgtype.Text
 	RateType pgtype.Text
 	AsOf     pgtype.Timestamptz
+	KnownAt  pgtype.Timestamptz
 }
 
 type RateAsOfRow struct {
@@ -51,6 +53,7 @@ type RateAsOfRow struct {
 	Source    string
 	AsOf      pgtype.Timestamptz
 	FetchedAt pgtype.Timestamptz
+	Metadata  []byte
 	Total     int64
 }
 
@@ -63,6 +66,7 @@ func (q *Queries) RateAsOf(ctx context.Context, arg RateAsOfParams) ([]RateAsOfR
 		arg.Source,
 		arg.RateType,
 		arg.AsOf,
+		arg.KnownAt,
 	)
 	if err != nil {
 		return nil, err
@@ -80,6 +84,101 @@ func (q *Queries) RateAsOf(ctx context.Context, arg RateAsOfParams) ([]RateAsOfR
 			&i.Source,
 			&i.AsOf,
 			&i.FetchedAt,
+			&i.Metadata,
+			&i.Total,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const rateHistory = `-- name: RateHistory :many
+WITH points AS (
+  SELECT DISTINCT ON (source, rate_type, bucket)
+    id, base, target, rate, rate_type, source, as_of, fetched_at, metadata,
+    CASE
+      WHEN $3::interval > INTERVAL '0'
+        THEN date_bin($3::interval, as_of, $4::timestamptz)
+      ELSE as_of
+    END AS bucket
+  FROM exchange_rates
+  WHERE base = $5
+    AND target = $6
+    AND ($7::text IS NULL OR source = $7::text)
+    AND ($8::text IS NULL OR rate_type = $8::text)
+    AND as_of >= $4::timestamptz
+    AND as_of <= $9::timestamptz
+  ORDER BY source, rate_type, bucket, as_of DESC, fetched_at DESC
+)
+SELECT
+  points.id, points.base, points.target, points.rate, points.rate_type,
+  points.source, points.as_of, points.fetched_at, points.metadata,
+  COUNT(*) OVER()::bigint AS total
+FROM points
+ORDER BY as_of, source, rate_type
+LIMIT LEAST($2::int, 500)
+OFFSET $1::bigint
+`
+
+type RateHistoryParams struct {
+	Offset         int64
+	Limit          int32
+	BucketInterval pgtype.Interval
+	FromTime       pgtype.Timestamptz
+	Base           string
+	Target         string
+	Source         pgtype.Text
+	RateType       pgtype.Text
+	ToTime         pgtype.Timestamptz
+}
+
+type RateHistoryRow struct {
+	ID        int64
+	Base      string
+	Target    string
+	Rate      pgtype.Numeric
+	RateType  string
+	Source    string
+	AsOf      pgtype.Timestamptz
+	FetchedAt pgtype.Timestamptz
+	Metadata  []byte
+	Total     int64
+}
+
+func (q *Queries) RateHistory(ctx context.Context, arg RateHistoryParams) ([]RateHistoryRow, error) {
+	rows, err := q.db.Query(ctx, rateHistory,
+		arg.Offset,
+		arg.Limit,
+		arg.BucketInterval,
+		arg.FromTime,
+		arg.Base,
+		arg.Target,
+		arg.Source,
+		arg.RateType,
+		arg.ToTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RateHistoryRow
+	for rows.Next() {
+		var i RateHistoryRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Base,
+			&i.Target,
+			&i.Rate,
+			&i.RateType,
+			&i.Source,
+			&i.AsOf,
+			&i.FetchedAt,
+			&i.Metadata,
 			&i.Total,
 		); err != nil {
 			return nil, err
@@ -94,10 +193,33 @@ func (q *Queries) RateAsOf(ctx context.Context, arg RateAsOfParams) ([]RateAsOfR
 
 const saveExchangeRate = `-- name: SaveExchangeRate :exec
 INSERT INTO exchange_rates (
-  base, target, rate, rate_type, source, as_of, fetched_at
-) VALUES (
-  $1, $2, $3, $4, $5, $6, $7
-) ON CONFLICT (base, target, rate_type, source, as_of)
+  base, target, rate, rate_type, source, as_of, fetched_at, metadata
+)
+SELECT
+  $1::varchar,
+  $2::varchar,
+  $3::numeric,
+  $4::varchar,
+  $5::varchar,
+  $6::timestamptz,
+  $7::timestamptz,
+  $8::jsonb
+WHERE NOT EXISTS (
+  SELECT 1
+  FROM (
+    SELECT rate
+    FROM exchange_rates
+    WHERE base = $1::varchar
+      AND target = $2::varchar
+      AND rate_type = $4::varchar
+      AND source = $5::varchar
+      AND as_of = $6::timestamptz
+    ORDER BY fetched_at DESC
+    LIMIT 1
+  ) latest
+  WHERE latest.rate = $3::numeric
+)
+ON CONFLICT (base, target, rate_type, source, as_of, fetched_at)
 DO NOTHING
 `
 
@@ -109,6 +231,7 @@ type SaveExchangeRateParams struct {
 	Source    string
 	AsOf      pgtype.Timestamptz
 	FetchedAt pgtype.Timestamptz
+	Metadata  []byte
 }
 
 func (q *Queries) SaveExchangeRate(ctx context.Context, arg SaveExchangeRateParams) error {
@@ -120,6 +243,7 @@ func (q *Queries) SaveExchangeRate(ctx context.Context, arg SaveExchangeRatePara
 		arg.Source,
 		arg.AsOf,
 		arg.FetchedAt,
+		arg.Metadata,
 	)
 	return err
 }
@@ -0,0 +1,568 @@
+package sql
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// migrationLockID is the advisory lock key held while migrating,
+// so concurrent migrators (e.g. replicas auto-migrating on startup) don't race
+const migrationLockID = int64(0x66787261746573) // "fxrates"
+
+const (
+	upSuffix   = ".up.sql"
+	downSuffix = ".down.sql"
+)
+
+var (
+	ErrChecksumMismatch = errors.New("applied migration checksum mismatch")
+	ErrUnknownMigration = errors.New("unknown migration version")
+	ErrMissingMigration = errors.New("applied migration is missing")
+	ErrUntrackedSchema  = errors.New("untracked DB schema, mark its migrations as applied with `sql migrate baseline`")
+
+	errInvalidMigrationName = errors.New("invalid migration file name")
+	errDuplicateMigration   = errors.New("duplicate migration version")
+	errMissingUp            = errors.New("migration has no up file")
+	errMissingDown          = errors.New("migration has no down file")
+)
+
+// MigrationDB is the database the migrations are run on.
+// Both pgx.Conn and pgxpool.Pool satisfy it
+type MigrationDB interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Migration is a single versioned schema migration
+type Migration struct {
+	Name     string
+	Up       string
+	Down     string
+	Checksum string // hex SHA-256 of the up file
+	Version  int64
+}
+
+// MigrationStatus is the state of a single migration in the database
+type MigrationStatus struct {
+	AppliedAt *time.Time
+	Migration *Migration // nil if the applied migration is missing from the files
+	Checksum  string     // checksum recorded when applied, if any
+	Version   int64
+}
+
+// Applied returns true if the migration is applied
+func (s *MigrationStatus) Applied() bool {
+	return s.AppliedAt != nil
+}
+
+// Modified returns true if the applied migration file changed since it was applied
+func (s *MigrationStatus) Modified() bool {
+	return s.Applied() && s.Migration != nil && s.Migration.Checksum != s.Checksum
+}
+
+// Migrator applies and reverts the schema migrations,
+// tracking them in the schema_migrations table
+type Migrator struct {
+	db         MigrationDB
+	logger     *slog.Logger
+	migrations []*Migration // sorted by version
+}
+
+// NewMigrator creates a new migrator for the embedded schema migrations
+func NewMigrator(db MigrationDB, logger *slog.Logger) (*Migrator, error) {
+	migrations, err := LoadMigrations(SchemaFS, "schema")
+	if err != nil {
+		return nil, err
+	}
+
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	return &Migrator{
+		db:         db,
+		logger:     logger,
+		migrations: migrations,
+	}, nil
+}
+
+// LoadMigrations loads the <version>_<name>.up.sql / .down.sql
+// migration pairs from the given directory, sorted by version
+func LoadMigrations(fsys fs.FS, dir string) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		var (
+			file = entry.Name()
+			stem string
+			up   bool
+		)
+
+		switch {
+		case strings.HasSuffix(file, upSuffix):
+			stem, up = strings.TrimSuffix(file, upSuffix), true
+		case strings.HasSuffix(file, downSuffix):
+			stem = strings.TrimSuffix(file, downSuffix)
+		default:
+			return nil, fmt.Errorf("%w: %q", errInvalidMigrationName, file)
+		}
+
+		rawVersion, name, ok := strings.Cut(stem, "_")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%w: %q", errInvalidMigrationName, file)
+		}
+
+		version, err := strconv.ParseInt(rawVersion, 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("%w: %q", errInvalidMigrationName, file)
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, file))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read migration %q: %w", file, err)
+		}
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &Migration{
+				Version: version,
+				Name:    name,
+			}
+
+			byVersion[version] = m
+		}
+
+		if m.Name != name {
+			return nil, fmt.Errorf("%w: %d", errDuplicateMigration, version)
+		}
+
+		if up {
+			if m.Up != "" {
+				return nil, fmt.Errorf("%w: %d", errDuplicateMigration, version)
+			}
+
+			sum := sha256.Sum256(content)
+
+			m.Up = string(content)
+			m.Checksum = hex.EncodeToString(sum[:])
+
+			continue
+		}
+
+		if m.Down != "" {
+			return nil, fmt.Errorf("%w: %d", errDuplicateMigration, version)
+		}
+
+		m.Down = string(content)
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+
+	for _, m := range byVersion {
+		if m.Checksum == "" {
+			return nil, fmt.Errorf("%w: %d_%s", errMissingUp, m.Version, m.Name)
+		}
+
+		if m.Down == "" {
+			return nil, fmt.Errorf("%w: %d_%s", errMissingDown, m.Version, m.Name)
+		}
+
+		migrations = append(migrations, m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Migrations returns the known migrations, sorted by version
+func (m *Migrator) Migrations() []*Migration {
+	return m.migrations
+}
+
+// Latest returns the latest known migration version, or 0 if there are none
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Status returns the status of all known and applied migrations, sorted by version
+func (m *Migrator) Status(ctx context.Context) ([]*MigrationStatus, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+
+	applied, err := m.applied(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*MigrationStatus, 0, len(m.migrations))
+
+	for _, migration := range m.migrations {
+		status := &MigrationStatus{
+			Version:   migration.Version,
+			Migration: migration,
+		}
+
+		if a, ok := applied[migration.Version]; ok {
+			status.AppliedAt = a.AppliedAt
+			status.Checksum = a.Checksum
+
+			delete(applied, migration.Version)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	// Applied migrations without files
+	for _, a := range applied {
+		statuses = append(statuses, a)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+
+	return statuses, nil
+}
+
+// Current returns the latest applied migration version, or 0 if there are none
+func (m *Migrator) Current(ctx context.Context) (int64, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return 0, err
+	}
+
+	applied, err := m.applied(ctx, m.db)
+	if err != nil {
+		return 0, err
+	}
+
+	var current int64
+
+	for version := range applied {
+		current = max(current, version)
+	}
+
+	return current, nil
+}
+
+// Up applies all pending migrations
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.To(ctx, m.Latest())
+}
+
+// Down reverts the latest applied migration, if any
+func (m *Migrator) Down(ctx context.Context) error {
+	if err := m.ensureTable(ctx); err != nil {
+		return err
+	}
+
+	applied, err := m.applied(ctx, m.db)
+	if err != nil {
+		return err
+	}
+
+	if err = m.verify(applied); err != nil {
+		return err
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		if _, ok := applied[m.migrations[i].Version]; ok {
+			return m.run(ctx, m.migrations[i], false)
+		}
+	}
+
+	return nil
+}
+
+// To migrates up or down to the given version. Version 0 reverts all migrations.
+// Applied migrations are verified against their checksums before anything is run
+func (m *Migrator) To(ctx context.Context, version int64) error {
+	if version != 0 && m.find(version) == nil {
+		return fmt.Errorf("%w: %d", ErrUnknownMigration, version)
+	}
+
+	if err := m.ensureTable(ctx); err != nil {
+		return err
+	}
+
+	applied, err := m.applied(ctx, m.db)
+	if err != nil {
+		return err
+	}
+
+	if err = m.verify(applied); err != nil {
+		return err
+	}
+
+	// Migrating an existing, untracked schema would fail on the first migration
+	if len(applied) == 0 && version != 0 {
+		if err = m.checkUntracked(ctx); err != nil {
+			return err
+		}
+	}
+
+	// Revert the applied migrations above the target, latest first
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if migration.Version <= version {
+			break
+		}
+
+		if err = m.run(ctx, migration, false); err != nil {
+			return err
+		}
+	}
+
+	// Apply the pending migrations up to the target, in order
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			break
+		}
+
+		if err = m.run(ctx, migration, true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Baseline marks all migrations up to the given version as applied, without running them.
+// It's meant for databases migrated before the migrations were tracked
+func (m *Migrator) Baseline(ctx context.Context, version int64) error {
+	if m.find(version) == nil {
+		return fmt.Errorf("%w: %d", ErrUnknownMigration, version)
+	}
+
+	if err := m.ensureTable(ctx); err != nil {
+		return err
+	}
+
+	return pgx.BeginFunc(ctx, m.db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", migrationLockID); err != nil {
+			return fmt.Errorf("unable to acquire migration lock: %w", err)
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+
+			if _, err := tx.Exec(
+				ctx,
+				`INSERT INTO schema_migrations (version, name, checksum)
+				VALUES ($1, $2, $3)
+				ON CONFLICT (version) DO NOTHING`,
+				migration.Version,
+				migration.Name,
+				migration.Checksum,
+			); err != nil {
+				return fmt.Errorf("unable to record migration %d: %w", migration.Version, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// run applies (or reverts) the migration in a transaction, if it's pending (or applied)
+func (m *Migrator) run(ctx context.Context, migration *Migration, up bool) error {
+	return pgx.BeginFunc(ctx, m.db, func(tx pgx.Tx) error {
+		// Serialize with other migrators, and re-check the state under the lock
+		if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", migrationLockID); err != nil {
+			return fmt.Errorf("unable to acquire migration lock: %w", err)
+		}
+
+		applied, err := m.applied(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		if _, isApplied := applied[migration.Version]; isApplied == up {
+			return nil
+		}
+
+		script, action := migration.Up, "applying"
+		if !up {
+			script, action = migration.Down, "reverting"
+		}
+
+		m.logger.Info(
+			action+" migration",
+			"version", migration.Version,
+			"name", migration.Name,
+		)
+
+		if _, err = tx.Exec(ctx, stripTransaction(script)); err != nil {
+			return fmt.Errorf("unable to run migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		if up {
+			_, err = tx.Exec(
+				ctx,
+				"INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+				migration.Version,
+				migration.Name,
+				migration.Checksum,
+			)
+		} else {
+			_, err = tx.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to record migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		return nil
+	})
+}
+
+// stripTransaction removes the script's own BEGIN / COMMIT statements,
+// since the migrator already runs it in a transaction
+func stripTransaction(script string) string {
+	lines := strings.Split(script, "\n")
+	kept := lines[:0]
+
+	for _, line := range lines {
+		statement := strings.ToUpper(strings.TrimSpace(line))
+		if statement == "BEGIN;" || statement == "COMMIT;" {
+			continue
+		}
+
+		kept = append(kept, line)
+	}
+
+	return strings.Join(kept, "\n")
+}
+
+// checkUntracked returns an error if the exchange_rates table
+// exists without any tracked migrations (i.e. created before they were tracked)
+func (m *Migrator) checkUntracked(ctx context.Context) error {
+	var exists bool
+
+	if err := m.db.QueryRow(
+		ctx,
+		"SELECT to_regclass('exchange_rates') IS NOT NULL",
+	).Scan(&exists); err != nil {
+		return fmt.Errorf("unable to check the DB schema: %w", err)
+	}
+
+	if exists {
+		return ErrUntrackedSchema
+	}
+
+	return nil
+}
+
+// verify checks the applied migrations are known, and unchanged since they were applied
+func (m *Migrator) verify(applied map[int64]*MigrationStatus) error {
+	for version, status := range applied {
+		migration := m.find(version)
+		if migration == nil {
+			return fmt.Errorf("%w: %d", ErrMissingMigration, version)
+		}
+
+		if migration.Checksum != status.Checksum {
+			return fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, version, migration.Name)
+		}
+	}
+
+	return nil
+}
+
+// find returns the migration with the given version, if any
+func (m *Migrator) find(version int64) *Migration {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration
+		}
+	}
+
+	return nil
+}
+
+// ensureTable creates the schema_migrations table, if needed
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	if _, err := m.db.Exec(
+		ctx,
+		`CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    BIGINT      PRIMARY KEY,
+			name       TEXT        NOT NULL,
+			checksum   TEXT        NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)`,
+	); err != nil {
+		return fmt.Errorf("unable to create migrations table: %w", err)
+	}
+
+	return nil
+}
+
+// querier runs queries, on the DB or in a transaction
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// applied fetches the applied migrations, by version
+func (m *Migrator) applied(ctx context.Context, q querier) (map[int64]*MigrationStatus, error) {
+	rows, err := q.Query(ctx, "SELECT version, checksum, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch applied migrations: %w", err)
+	}
+
+	defer rows.Close()
+
+	applied := make(map[int64]*MigrationStatus)
+
+	for rows.Next() {
+		var (
+			status    MigrationStatus
+			appliedAt time.Time
+		)
+
+		if err = rows.Scan(&status.Version, &status.Checksum, &appliedAt); err != nil {
+			return nil, fmt.Errorf("unable to scan applied migration: %w", err)
+		}
+
+		status.AppliedAt = &appliedAt
+		applied[status.Version] = &status
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to fetch applied migrations: %w", err)
+	}
+
+	return applied, nil
+}
@@ -0,0 +1,260 @@
+package sql
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	t.Parallel()
+
+	t.Run("embedded schema", func(t *testing.T) {
+		t.Parallel()
+
+		migrations, err := LoadMigrations(SchemaFS, "schema")
+		require.NoError(t, err)
+		require.NotEmpty(t, migrations)
+
+		for i, m := range migrations {
+			assert.NotEmpty(t, m.Up)
+			assert.NotEmpty(t, m.Down)
+			assert.Len(t, m.Checksum, 64)
+
+			if i > 0 {
+				assert.Greater(t, m.Version, migrations[i-1].Version)
+			}
+
+			// Migrations run in the migrator's transaction
+			assert.NotContains(t, stripTransaction(m.Up), "BEGIN;", m.Name)
+			assert.NotContains(t, stripTransaction(m.Up), "COMMIT;", m.Name)
+		}
+	})
+
+	t.Run("maintenance scripts are not migrations", func(t *testing.T) {
+		t.Parallel()
+
+		migrations, err := LoadMigrations(SchemaFS, "schema")
+		require.NoError(t, err)
+
+		for _, m := range migrations {
+			assert.NotContains(t, m.Up, "TRUNCATE", m.Name)
+		}
+	})
+
+	t.Run("sorted by version", func(t *testing.T) {
+		t.Parallel()
+
+		fsys := fstest.MapFS{
+			"schema/010_later.up.sql":   {Data: []byte("SELECT 10;")},
+			"schema/010_later.down.sql": {Data: []byte("SELECT -10;")},
+			"schema/2_first.up.sql":     {Data: []byte("SELECT 2;")},
+			"schema/2_first.down.sql":   {Data: []byte("SELECT -2;")},
+		}
+
+		migrations, err := LoadMigrations(fsys, "schema")
+		require.NoError(t, err)
+		require.Len(t, migrations, 2)
+
+		assert.Equal(t, int64(2), migrations[0].Version)
+		assert.Equal(t, "first", migrations[0].Name)
+		assert.Equal(t, "SELECT 2;", migrations[0].Up)
+		assert.Equal(t, "SELECT -2;", migrations[0].Down)
+
+		assert.Equal(t, int64(10), migrations[1].Version)
+		assert.Equal(t, "later", migrations[1].Name)
+	})
+
+	t.Run("checksum follows the up file", func(t *testing.T) {
+		t.Parallel()
+
+		load := func(up, down string) *Migration {
+			migrations, err := LoadMigrations(fstest.MapFS{
+				"schema/001_init.up.sql":   {Data: []byte(up)},
+				"schema/001_init.down.sql": {Data: []byte(down)},
+			}, "schema")
+			require.NoError(t, err)
+
+			return migrations[0]
+		}
+
+		var (
+			original    = load("CREATE TABLE a ();", "DROP TABLE a;")
+			downChanged = load("CREATE TABLE a ();", "DROP TABLE IF EXISTS a;")
+			upChanged   = load("CREATE TABLE b ();", "DROP TABLE a;")
+		)
+
+		assert.Equal(t, original.Checksum, downChanged.Checksum)
+		assert.NotEqual(t, original.Checksum, upChanged.Checksum)
+	})
+
+	t.Run("invalid files", func(t *testing.T) {
+		t.Parallel()
+
+		testTable := []struct {
+			name        string
+			fsys        fstest.MapFS
+			expectedErr error
+		}{
+			{
+				"no direction",
+				fstest.MapFS{"schema/001_init.sql": {Data: []byte("SELECT 1;")}},
+				errInvalidMigrationName,
+			},
+			{
+				"no version",
+				fstest.MapFS{"schema/init.up.sql": {Data: []byte("SELECT 1;")}},
+				errInvalidMigrationName,
+			},
+			{
+				"no name",
+				fstest.MapFS{"schema/001.up.sql": {Data: []byte("SELECT 1;")}},
+				errInvalidMigrationName,
+			},
+			{
+				"missing down",
+				fstest.MapFS{"schema/001_init.up.sql": {Data: []byte("SELECT 1;")}},
+				errMissingDown,
+			},
+			{
+				"missing up",
+				fstest.MapFS{"schema/001_init.down.sql": {Data: []byte("SELECT 1;")}},
+				errMissingUp,
+			},
+			{
+				"duplicate version",
+				fstest.MapFS{
+					"schema/001_init.up.sql":   {Data: []byte("SELECT 1;")},
+					"schema/001_init.down.sql": {Data: []byte("SELECT 1;")},
+					"schema/1_other.up.sql":    {Data: []byte("SELECT 1;")},
+					"schema/1_other.down.sql":  {Data: []byte("SELECT 1;")},
+				},
+				errDuplicateMigration,
+			},
+		}
+
+		for _, testCase := range testTable {
+			t.Run(testCase.name, func(t *testing.T) {
+				t.Parallel()
+
+				_, err := LoadMigrations(testCase.fsys, "schema")
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			})
+		}
+	})
+}
+
+func TestStripTransaction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("transaction statements removed", func(t *testing.T) {
+		t.Parallel()
+
+		script := "-- comment\n\nBEGIN;\n\nALTER TABLE a ADD COLUMN b INT;\n\ncommit;\n"
+
+		assert.Equal(
+			t,
+			"-- comment\n\n\nALTER TABLE a ADD COLUMN b INT;\n\n",
+			stripTransaction(script),
+		)
+	})
+
+	t.Run("scripts without transactions unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		script := "CREATE TABLE a (\n  begin_at TIMESTAMPTZ\n);\n"
+
+		assert.Equal(t, script, stripTransaction(script))
+	})
+}
+
+func TestMigrator(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx  = context.Background()
+		conn = newTestSchemaConn(t)
+	)
+
+	migrator, err := NewMigrator(conn, nil)
+	require.NoError(t, err)
+
+	pending := func(t *testing.T) int {
+		t.Helper()
+
+		statuses, err := migrator.Status(ctx)
+		require.NoError(t, err)
+
+		count := 0
+
+		for _, status := range statuses {
+			if !status.Applied() {
+				count++
+			}
+		}
+
+		return count
+	}
+
+	// Fresh schema, nothing applied
+	assert.Equal(t, len(migrator.Migrations()), pending(t))
+
+	// Apply everything, twice
+	require.NoError(t, migrator.Up(ctx))
+	require.NoError(t, migrator.Up(ctx))
+	assert.Zero(t, pending(t))
+
+	// Revert the latest migration
+	require.NoError(t, migrator.Down(ctx))
+	assert.Equal(t, 1, pending(t))
+
+	// Revert everything, and reapply
+	require.NoError(t, migrator.To(ctx, 0))
+	assert.Equal(t, len(migrator.Migrations()), pending(t))
+
+	require.NoError(t, migrator.Up(ctx))
+	assert.Zero(t, pending(t))
+
+	// Unknown target versions are rejected
+	assert.ErrorIs(t, migrator.To(ctx, migrator.Latest()+1), ErrUnknownMigration)
+
+	// Modified applied migrations are rejected
+	_, err = conn.Exec(ctx, "UPDATE schema_migrations SET checksum = 'modified' WHERE version = $1", migrator.Latest())
+	require.NoError(t, err)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[len(statuses)-1].Modified())
+
+	assert.ErrorIs(t, migrator.Up(ctx), ErrChecksumMismatch)
+	assert.ErrorIs(t, migrator.Down(ctx), ErrChecksumMismatch)
+}
+
+func TestMigrator_UntrackedSchema(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx  = context.Background()
+		conn = newTestSchemaConn(t)
+	)
+
+	migrator, err := NewMigrator(conn, nil)
+	require.NoError(t, err)
+
+	// Schema created before the migrations were tracked
+	_, err = conn.Exec(ctx, migrator.Migrations()[0].Up)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, migrator.Up(ctx), ErrUntrackedSchema)
+
+	// Baselined schemas are migrated
+	require.NoError(t, migrator.Baseline(ctx, migrator.Migrations()[0].Version))
+	require.NoError(t, migrator.Up(ctx))
+
+	current, err := migrator.Current(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, migrator.Latest(), current)
+}
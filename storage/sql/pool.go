@@ -0,0 +1,193 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// initialRetryDelay is the delay before the first startup connection retry
+	initialRetryDelay = 500 * time.Millisecond
+
+	// maxRetryDelay is the maximum delay between startup connection retries
+	maxRetryDelay = 5 * time.Second
+)
+
+// PoolConfig defines the DB connection pool sizing and timeouts.
+// Zero values keep the pgxpool defaults
+type PoolConfig struct {
+	// The maximum number of open connections
+	MaxConns int32
+
+	// The minimum number of open connections, kept warm
+	MinConns int32
+
+	// The maximum lifetime of a connection, after which it's replaced
+	MaxConnLifetime time.Duration
+
+	// The maximum time a connection can stay idle, before it's closed
+	MaxConnIdleTime time.Duration
+
+	// The period at which idle connections are health-checked.
+	// Broken connections are dropped, and replaced as needed
+	HealthCheckPeriod time.Duration
+
+	// The timeout for establishing a single connection
+	ConnectTimeout time.Duration
+
+	// How long to keep retrying to reach the DB on startup.
+	// If zero, the DB is only pinged once
+	StartupTimeout time.Duration
+}
+
+// PoolStats is a snapshot of the DB connection pool statistics
+type PoolStats struct {
+	TotalConns              int32  `json:"total_conns"`
+	IdleConns               int32  `json:"idle_conns"`
+	AcquiredConns           int32  `json:"acquired_conns"`
+	ConstructingConns       int32  `json:"constructing_conns"`
+	MaxConns                int32  `json:"max_conns"`
+	AcquireCount            int64  `json:"acquire_count"`
+	EmptyAcquireCount       int64  `json:"empty_acquire_count"`
+	CanceledAcquireCount    int64  `json:"canceled_acquire_count"`
+	NewConnsCount           int64  `json:"new_conns_count"`
+	MaxLifetimeDestroyCount int64  `json:"max_lifetime_destroy_count"`
+	MaxIdleDestroyCount     int64  `json:"max_idle_destroy_count"`
+	AcquireDuration         string `json:"acquire_duration"`
+}
+
+// NewPool creates a new DB connection pool, and waits until the DB is reachable,
+// retrying for up to the startup timeout.
+// Once started, the pool replaces broken connections on its own,
+// so the service recovers from dropped connections and DB restarts
+func NewPool(
+	ctx context.Context,
+	dsn string,
+	cfg PoolConfig,
+	logger *slog.Logger,
+) (*pgxpool.Pool, error) {
+	poolCfg, err := newPoolConfig(dsn, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create DB pool: %w", err)
+	}
+
+	if err = waitForDB(ctx, pool, cfg.StartupTimeout, logger); err != nil {
+		pool.Close()
+
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+// CheckPool pings the DB, and returns the connection pool statistics
+func CheckPool(ctx context.Context, pool *pgxpool.Pool) (*PoolStats, error) {
+	err := pool.Ping(ctx)
+
+	stat := pool.Stat()
+
+	return &PoolStats{
+		TotalConns:              stat.TotalConns(),
+		IdleConns:               stat.IdleConns(),
+		AcquiredConns:           stat.AcquiredConns(),
+		ConstructingConns:       stat.ConstructingConns(),
+		MaxConns:                stat.MaxConns(),
+		AcquireCount:            stat.AcquireCount(),
+		EmptyAcquireCount:       stat.EmptyAcquireCount(),
+		CanceledAcquireCount:    stat.CanceledAcquireCount(),
+		NewConnsCount:           stat.NewConnsCount(),
+		MaxLifetimeDestroyCount: stat.MaxLifetimeDestroyCount(),
+		MaxIdleDestroyCount:     stat.MaxIdleDestroyCount(),
+		AcquireDuration:         stat.AcquireDuration().String(),
+	}, err
+}
+
+// newPoolConfig parses the DSN, and applies the pool config over it
+func newPoolConfig(dsn string, cfg PoolConfig) (*pgxpool.Config, error) {
+	poolCfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse DB URL: %w", err)
+	}
+
+	if cfg.MaxConns > 0 {
+		poolCfg.MaxConns = cfg.MaxConns
+	}
+
+	if cfg.MinConns > 0 {
+		poolCfg.MinConns = cfg.MinConns
+	}
+
+	if cfg.MaxConnLifetime > 0 {
+		poolCfg.MaxConnLifetime = cfg.MaxConnLifetime
+	}
+
+	if cfg.MaxConnIdleTime > 0 {
+		poolCfg.MaxConnIdleTime = cfg.MaxConnIdleTime
+	}
+
+	if cfg.HealthCheckPeriod > 0 {
+		poolCfg.HealthCheckPeriod = cfg.HealthCheckPeriod
+	}
+
+	if cfg.ConnectTimeout > 0 {
+		poolCfg.ConnConfig.ConnectTimeout = cfg.ConnectTimeout
+	}
+
+	if poolCfg.MinConns > poolCfg.MaxConns {
+		return nil, fmt.Errorf(
+			"min conns (%d) exceed max conns (%d)",
+			poolCfg.MinConns,
+			poolCfg.MaxConns,
+		)
+	}
+
+	return poolCfg, nil
+}
+
+// waitForDB pings the DB until it's reachable, backing off between attempts,
+// or until the startup timeout expires
+func waitForDB(
+	ctx context.Context,
+	pool *pgxpool.Pool,
+	timeout time.Duration,
+	logger *slog.Logger,
+) error {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	for delay := initialRetryDelay; ; delay = min(delay*2, maxRetryDelay) {
+		err := pool.Ping(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if time.Now().Add(delay).After(deadline) {
+			return fmt.Errorf("unable to reach DB (ping): %w", err)
+		}
+
+		logger.Warn(
+			"DB not reachable, retrying",
+			"retry_in", delay,
+			"err", err,
+		)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
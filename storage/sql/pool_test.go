@@ -0,0 +1,81 @@
+package sql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPoolConfig(t *testing.T) {
+	t.Parallel()
+
+	const dsn = "postgres://fxrates@localhost:5432/fxrates?pool_max_conns=7"
+
+	t.Run("config applied", func(t *testing.T) {
+		t.Parallel()
+
+		cfg, err := newPoolConfig(dsn, PoolConfig{
+			MaxConns:          20,
+			MinConns:          2,
+			MaxConnLifetime:   time.Hour,
+			MaxConnIdleTime:   10 * time.Minute,
+			HealthCheckPeriod: 15 * time.Second,
+			ConnectTimeout:    3 * time.Second,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(20), cfg.MaxConns)
+		assert.Equal(t, int32(2), cfg.MinConns)
+		assert.Equal(t, time.Hour, cfg.MaxConnLifetime)
+		assert.Equal(t, 10*time.Minute, cfg.MaxConnIdleTime)
+		assert.Equal(t, 15*time.Second, cfg.HealthCheckPeriod)
+		assert.Equal(t, 3*time.Second, cfg.ConnConfig.ConnectTimeout)
+	})
+
+	t.Run("zero values keep the DSN settings", func(t *testing.T) {
+		t.Parallel()
+
+		cfg, err := newPoolConfig(dsn, PoolConfig{})
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(7), cfg.MaxConns)
+	})
+
+	t.Run("min conns exceed max conns", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newPoolConfig(dsn, PoolConfig{MaxConns: 2, MinConns: 5})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid DSN", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newPoolConfig("postgres://fxrates@localhost:port/fxrates", PoolConfig{})
+		assert.Error(t, err)
+	})
+}
+
+func TestNewPool_Unreachable(t *testing.T) {
+	t.Parallel()
+
+	var (
+		cfg = PoolConfig{
+			ConnectTimeout: 100 * time.Millisecond,
+			StartupTimeout: time.Second,
+		}
+
+		start = time.Now()
+	)
+
+	// Nothing listens on the discard port, so every attempt fails
+	_, err := NewPool(context.Background(), "postgres://fxrates@127.0.0.1:9/fxrates", cfg, nil)
+	require.Error(t, err)
+
+	// The DB was retried, but not past the startup timeout
+	assert.GreaterOrEqual(t, time.Since(start), initialRetryDelay)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
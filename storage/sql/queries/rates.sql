@@ -1,11 +1,77 @@
 -- name: SaveExchangeRate :exec
 INSERT INTO exchange_rates (
-  base, target, rate, rate_type, source, as_of, fetched_at
-) VALUES (
-  $1, $2, $3, $4, $5, $6, $7
-) ON CONFLICT (base, target, rate_type, source, as_of)
+  base, target, rate, rate_type, source, as_of, fetched_at, metadata
+)
+SELECT
+  sqlc.arg('base')::varchar,
+  sqlc.arg('target')::varchar,
+  sqlc.arg('rate')::numeric,
+  sqlc.arg('rate_type')::varchar,
+  sqlc.arg('source')::varchar,
+  sqlc.arg('as_of')::timestamptz,
+  sqlc.arg('fetched_at')::timestamptz,
+  sqlc.narg('metadata')::jsonb
+WHERE NOT EXISTS (
+  SELECT 1
+  FROM (
+    SELECT rate
+    FROM exchange_rates
+    WHERE base = sqlc.arg('base')::varchar
+      AND target = sqlc.arg('target')::varchar
+      AND rate_type = sqlc.arg('rate_type')::varchar
+      AND source = sqlc.arg('source')::varchar
+      AND as_of = sqlc.arg('as_of')::timestamptz
+    ORDER BY fetched_at DESC
+    LIMIT 1
+  ) latest
+  WHERE latest.rate = sqlc.arg('rate')::numeric
+)
+ON CONFLICT (base, target, rate_type, source, as_of, fetched_at)
 DO NOTHING;
 
+-- name: SaveExchangeRates :batchone
+WITH input AS (
+  SELECT
+    sqlc.arg('base')::varchar AS base,
+    sqlc.arg('target')::varchar AS target,
+    sqlc.arg('rate')::numeric AS rate,
+    sqlc.arg('rate_type')::varchar AS rate_type,
+    sqlc.arg('source')::varchar AS source,
+    sqlc.arg('as_of')::timestamptz AS as_of,
+    sqlc.arg('fetched_at')::timestamptz AS fetched_at,
+    sqlc.narg('metadata')::jsonb AS metadata
+),
+latest AS (
+  SELECT er.rate
+  FROM exchange_rates er
+  JOIN input
+    ON er.base = input.base
+    AND er.target = input.target
+    AND er.rate_type = input.rate_type
+    AND er.source = input.source
+    AND er.as_of = input.as_of
+  ORDER BY er.fetched_at DESC
+  LIMIT 1
+),
+inserted AS (
+  INSERT INTO exchange_rates (
+    base, target, rate, rate_type, source, as_of, fetched_at, metadata
+  )
+  SELECT
+    input.base, input.target, input.rate, input.rate_type, input.source, input.as_of, input.fetched_at,
+    input.metadata
+  FROM input
+  WHERE NOT EXISTS (
+    SELECT 1 FROM latest WHERE latest.rate = input.rate
+  )
+  ON CONFLICT (base, target, rate_type, source, as_of, fetched_at)
+  DO NOTHING
+  RETURNING id
+)
+SELECT
+  EXISTS (SELECT 1 FROM inserted)::bool AS saved,
+  EXISTS (SELECT 1 FROM latest JOIN input ON latest.rate = input.rate)::bool AS unchanged;
+
 -- name: RateAsOf :many
 WITH latest AS (
   SELECT DISTINCT ON (target, source, rate_type)
@@ -16,7 +82,8 @@ WITH latest AS (
     AND (sqlc.narg('source')::text IS NULL OR source = sqlc.narg('source')::text)
     AND (sqlc.narg('rate_type')::text IS NULL OR rate_type = sqlc.narg('rate_type')::text)
     AND as_of <= sqlc.arg('as_of')
-  ORDER BY target, source, rate_type, as_of DESC
+    AND (sqlc.narg('known_at')::timestamptz IS NULL OR fetched_at <= sqlc.narg('known_at')::timestamptz)
+  ORDER BY target, source, rate_type, as_of DESC, fetched_at DESC
 )
 SELECT
   latest.*,
@@ -25,3 +92,30 @@ FROM latest
 ORDER BY target, source, rate_type
 LIMIT LEAST(sqlc.arg('limit')::int, 500)
 OFFSET sqlc.arg('offset')::bigint;
+
+-- name: RateHistory :many
+WITH points AS (
+  SELECT DISTINCT ON (source, rate_type, bucket)
+    id, base, target, rate, rate_type, source, as_of, fetched_at, metadata,
+    CASE
+      WHEN sqlc.arg('bucket_interval')::interval > INTERVAL '0'
+        THEN date_bin(sqlc.arg('bucket_interval')::interval, as_of, sqlc.arg('from_time')::timestamptz)
+      ELSE as_of
+    END AS bucket
+  FROM exchange_rates
+  WHERE base = sqlc.arg('base')
+    AND target = sqlc.arg('target')
+    AND (sqlc.narg('source')::text IS NULL OR source = sqlc.narg('source')::text)
+    AND (sqlc.narg('rate_type')::text IS NULL OR rate_type = sqlc.narg('rate_type')::text)
+    AND as_of >= sqlc.arg('from_time')::timestamptz
+    AND as_of <= sqlc.arg('to_time')::timestamptz
+  ORDER BY source, rate_type, bucket, as_of DESC, fetched_at DESC
+)
+SELECT
+  points.id, points.base, points.target, points.rate, points.rate_type,
+  points.source, points.as_of, points.fetched_at, points.metadata,
+  COUNT(*) OVER()::bigint AS total
+FROM points
+ORDER BY as_of, source, rate_type
+LIMIT LEAST(sqlc.arg('limit')::int, 500)
+OFFSET sqlc.arg('offset')::bigint;
@@ -0,0 +1 @@
+-- 001_init already allows 4 character currencies, so there is nothing to revert
@@ -0,0 +1,14 @@
+-- Restores a single revision per as_of.
+-- Fails if corrections were stored, they need to be cleaned up first
+
+DROP INDEX IF EXISTS exchange_rates_asof_latest_idx;
+
+ALTER TABLE exchange_rates
+  DROP CONSTRAINT IF EXISTS exchange_rates_uniq;
+
+ALTER TABLE exchange_rates
+  ADD CONSTRAINT exchange_rates_uniq
+    UNIQUE (base, target, rate_type, source, as_of);
+
+CREATE INDEX exchange_rates_asof_latest_idx
+  ON exchange_rates (base, target, source, rate_type, as_of DESC);
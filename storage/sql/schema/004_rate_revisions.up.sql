@@ -0,0 +1,17 @@
+-- Stores rate corrections as new revisions, keyed by fetched_at
+
+BEGIN;
+
+ALTER TABLE exchange_rates
+  DROP CONSTRAINT IF EXISTS exchange_rates_uniq;
+
+ALTER TABLE exchange_rates
+  ADD CONSTRAINT exchange_rates_uniq
+    UNIQUE (base, target, rate_type, source, as_of, fetched_at);
+
+DROP INDEX IF EXISTS exchange_rates_asof_latest_idx;
+
+CREATE INDEX exchange_rates_asof_latest_idx
+  ON exchange_rates (base, target, source, rate_type, as_of DESC, fetched_at DESC);
+
+COMMIT;
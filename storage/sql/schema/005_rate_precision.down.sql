@@ -0,0 +1,4 @@
+-- Narrows the rate precision back to 4 decimal places, rounding the stored rates
+
+ALTER TABLE exchange_rates
+  ALTER COLUMN rate TYPE NUMERIC(20,4);
@@ -0,0 +1,8 @@
+-- Widens the rate precision, to fit small inverse rates and crypto pairs
+
+BEGIN;
+
+ALTER TABLE exchange_rates
+  ALTER COLUMN rate TYPE NUMERIC(38,18);
+
+COMMIT;
@@ -0,0 +1,4 @@
+-- Drops the provider-specific rate details
+
+ALTER TABLE exchange_rates
+  DROP COLUMN IF EXISTS metadata;
@@ -0,0 +1,4 @@
+-- Stores the provider-specific rate details (i.e. the P2P order book depth)
+
+ALTER TABLE exchange_rates
+  ADD COLUMN metadata JSONB;
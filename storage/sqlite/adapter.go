@@ -0,0 +1,371 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+// Storage is the SQLite storage adapter
+type Storage struct {
+	db *sql.DB
+}
+
+// NewStorage creates a new SQLite storage, using the given (migrated) database
+func NewStorage(db *sql.DB) *Storage {
+	return &Storage{
+		db: db,
+	}
+}
+
+func (s *Storage) SaveExchangeRate(
+	ctx context.Context,
+	rate *types.ExchangeRate,
+) error {
+	if _, err := saveRate(ctx, s.db, rate); err != nil {
+		return fmt.Errorf("unable to save exchange rate: %w", err)
+	}
+
+	return nil
+}
+
+// SaveExchangeRates saves the rates in a single transaction
+func (s *Storage) SaveExchangeRates(
+	ctx context.Context,
+	rates []*types.ExchangeRate,
+) ([]types.SaveStatus, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin transaction: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback() //nolint:errcheck // no-op after commit
+	}()
+
+	statuses := make([]types.SaveStatus, len(rates))
+
+	for i, rate := range rates {
+		if statuses[i], err = saveRate(ctx, tx, rate); err != nil {
+			return nil, fmt.Errorf("unable to save exchange rates: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("unable to commit exchange rates: %w", err)
+	}
+
+	return statuses, nil
+}
+
+// execQuerier is the common interface of sql.DB and sql.Tx
+type execQuerier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// saveRate saves the rate, and returns its save status
+func saveRate(ctx context.Context, db execQuerier, rate *types.ExchangeRate) (types.SaveStatus, error) {
+	exact := rate.Exact().Round(types.MaxRateScale)
+
+	metadata, err := encodeMetadata(rate.Metadata)
+	if err != nil {
+		return "", err
+	}
+
+	args := []any{
+		sql.Named("base", rate.Base.String()),
+		sql.Named("target", rate.Target.String()),
+		sql.Named("rate", exact.Float64()),
+		sql.Named("rate_exact", exact.String()),
+		sql.Named("rate_type", rate.RateType.String()),
+		sql.Named("source", rate.Source.String()),
+		sql.Named("as_of", timeToMicros(rate.AsOf)),
+		sql.Named("fetched_at", timeToMicros(rate.FetchedAt)),
+		sql.Named("metadata", metadata),
+	}
+
+	res, err := db.ExecContext(ctx, saveExchangeRateQuery, args...)
+	if err != nil {
+		return "", err
+	}
+
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return "", err
+	}
+
+	if inserted > 0 {
+		return types.SaveStatusSaved, nil
+	}
+
+	// Check why the rate was ignored
+	var latest string
+	if err = db.QueryRowContext(ctx, latestRateExactQuery, args...).Scan(&latest); err != nil {
+		return "", err
+	}
+
+	if latest == exact.String() {
+		return types.SaveStatusUnchanged, nil
+	}
+
+	return types.SaveStatusConflict, nil
+}
+
+func (s *Storage) RateAsOf(
+	ctx context.Context,
+	query *types.RateQuery,
+	t time.Time,
+) (*types.Page[*types.ExchangeRate], error) {
+	var knownAt any
+	if query.KnownAt != nil {
+		knownAt = timeToMicros(*query.KnownAt)
+	}
+
+	args := []any{
+		sql.Named("base", query.Base.String()),
+		sql.Named("target", stringArg(query.Target)),
+		sql.Named("source", stringArg(query.Source)),
+		sql.Named("rate_type", stringArg(query.RateType)),
+		sql.Named("as_of", timeToMicros(t)),
+		sql.Named("known_at", knownAt),
+	}
+
+	page, err := s.queryPage(ctx, rateAsOfQuery, args, query.Limit, query.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch rates: %w", err)
+	}
+
+	return page, nil
+}
+
+func (s *Storage) RateHistory(
+	ctx context.Context,
+	query *types.HistoryQuery,
+) (*types.Page[*types.ExchangeRate], error) {
+	args := []any{
+		sql.Named("base", query.Base.String()),
+		sql.Named("target", query.Target.String()),
+		sql.Named("source", stringArg(query.Source)),
+		sql.Named("rate_type", stringArg(query.RateType)),
+		sql.Named("from_time", timeToMicros(query.From)),
+		sql.Named("to_time", timeToMicros(query.To)),
+		sql.Named("bucket_interval", query.Interval.Microseconds()),
+	}
+
+	page, err := s.queryPage(ctx, rateHistoryQuery, args, query.Limit, query.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch rate history: %w", err)
+	}
+
+	return page, nil
+}
+
+func (s *Storage) ListSources(ctx context.Context) ([]types.Source, error) {
+	results, err := queryStrings(ctx, s.db, listSourcesQuery)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch sources: %w", err)
+	}
+
+	if len(results) == 0 {
+		return nil, nil //nolint:nilnil // valid case
+	}
+
+	out := make([]types.Source, 0, len(results))
+
+	for _, src := range results {
+		out = append(out, types.Source(src))
+	}
+
+	return out, nil
+}
+
+func (s *Storage) ListCurrencies(ctx context.Context) ([]types.Currency, error) {
+	results, err := queryStrings(ctx, s.db, listCurrenciesQuery)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch currencies: %w", err)
+	}
+
+	if len(results) == 0 {
+		return nil, nil //nolint:nilnil // valid case
+	}
+
+	out := make([]types.Currency, 0, len(results))
+
+	for _, code := range results {
+		out = append(out, types.Currency(code))
+	}
+
+	return out, nil
+}
+
+// queryPage runs the paginated rate query, with the given filter args
+func (s *Storage) queryPage(
+	ctx context.Context,
+	query string,
+	args []any,
+	limit int32,
+	offset int64,
+) (*types.Page[*types.ExchangeRate], error) {
+	results, total, err := s.queryRates(ctx, query, args, types.PageLimit(limit), offset)
+	if err != nil {
+		return nil, err
+	}
+
+	// The offset can be past the last row, so fetch the total separately
+	if len(results) == 0 && offset > 0 {
+		if _, total, err = s.queryRates(ctx, query, args, 1, 0); err != nil {
+			return nil, fmt.Errorf("unable to fetch total: %w", err)
+		}
+	}
+
+	return &types.Page[*types.ExchangeRate]{
+		Results: results,
+		Total:   total,
+	}, nil
+}
+
+// queryRates runs the rate query, returning the rates and the total row count
+func (s *Storage) queryRates(
+	ctx context.Context,
+	query string,
+	args []any,
+	limit int32,
+	offset int64,
+) ([]*types.ExchangeRate, int64, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		query,
+		append(
+			args,
+			sql.Named("limit", limit),
+			sql.Named("offset", offset),
+		)...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer rows.Close()
+
+	var (
+		out   []*types.ExchangeRate
+		total int64
+	)
+
+	for rows.Next() {
+		var (
+			rate            types.ExchangeRate
+			exact, metadata string
+			asOf, fetchedAt int64
+		)
+
+		if err = rows.Scan(
+			&rate.Base,
+			&rate.Target,
+			&exact,
+			&rate.RateType,
+			&rate.Source,
+			&asOf,
+			&fetchedAt,
+			&metadata,
+			&total,
+		); err != nil {
+			return nil, 0, err
+		}
+
+		if rate.Metadata, err = decodeMetadata(metadata); err != nil {
+			return nil, 0, err
+		}
+
+		if rate.RateExact, err = types.ParseDecimal(exact); err != nil {
+			return nil, 0, err
+		}
+
+		rate.Rate = rate.RateExact.Float64()
+		rate.AsOf = microsToTime(asOf)
+		rate.FetchedAt = microsToTime(fetchedAt)
+
+		out = append(out, &rate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return out, total, nil
+}
+
+// encodeMetadata encodes the rate metadata as a JSON object, or an empty string if there is none
+func encodeMetadata(metadata map[string]string) (string, error) {
+	if len(metadata) == 0 {
+		return "", nil
+	}
+
+	raw, err := json.Marshal(metadata)
+	if err != nil {
+		return "", fmt.Errorf("unable to encode rate metadata: %w", err)
+	}
+
+	return string(raw), nil
+}
+
+// decodeMetadata decodes the stored rate metadata
+func decodeMetadata(raw string) (map[string]string, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	var metadata map[string]string
+	if err := json.Unmarshal([]byte(raw), &metadata); err != nil {
+		return nil, fmt.Errorf("unable to decode rate metadata: %w", err)
+	}
+
+	return metadata, nil
+}
+
+// queryStrings runs the single-column query, returning the values
+func queryStrings(ctx context.Context, db *sql.DB, query string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var out []string
+
+	for rows.Next() {
+		var v string
+		if err = rows.Scan(&v); err != nil {
+			return nil, err
+		}
+
+		out = append(out, v)
+	}
+
+	return out, rows.Err()
+}
+
+// timeToMicros converts the time value to unix microseconds
+func timeToMicros(t time.Time) int64 {
+	return t.UTC().UnixMicro()
+}
+
+// microsToTime converts the unix microseconds to a UTC time
+func microsToTime(v int64) time.Time {
+	return time.UnixMicro(v).UTC()
+}
+
+// stringArg converts the given optional string value to a nullable query arg
+func stringArg[T ~string](p *T) any {
+	if p == nil {
+		return nil
+	}
+
+	return string(*p)
+}
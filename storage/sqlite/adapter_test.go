@@ -0,0 +1,172 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/storage"
+	"github.com/sig-0/fxrates/storage/storagetest"
+)
+
+// newTestDB opens and migrates a fresh database in the test's temp dir
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := Open(context.Background(), filepath.Join(t.TempDir(), "fxrates.db"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	require.NoError(t, Migrate(context.Background(), db))
+
+	return db
+}
+
+func TestStorage_Conformance(t *testing.T) {
+	t.Parallel()
+
+	storagetest.Run(t, func(t *testing.T) storage.Storage {
+		t.Helper()
+
+		return NewStorage(newTestDB(t))
+	})
+}
+
+func TestMigrate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("migrations are applied once", func(t *testing.T) {
+		t.Parallel()
+
+		db := newTestDB(t)
+
+		// Re-running the migrations is a no-op
+		require.NoError(t, Migrate(context.Background(), db))
+
+		migrations, err := fs.Glob(SchemaFS, "schema/*.sql")
+		require.NoError(t, err)
+
+		var applied int
+		require.NoError(
+			t,
+			db.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&applied),
+		)
+
+		assert.Equal(t, len(migrations), applied)
+	})
+
+	t.Run("data survives reopening", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			ctx    = context.Background()
+			dbPath = filepath.Join(t.TempDir(), "fxrates.db")
+		)
+
+		db, err := Open(ctx, dbPath)
+		require.NoError(t, err)
+		require.NoError(t, Migrate(ctx, db))
+
+		_, err = db.Exec(
+			`INSERT INTO exchange_rates (base, target, rate, rate_exact, rate_type, source, as_of, fetched_at)
+			VALUES ('USD', 'VES', 341.7412, '341.7412', 'MID', 'BCV', 1, 2)`,
+		)
+		require.NoError(t, err)
+		require.NoError(t, db.Close())
+
+		db, err = Open(ctx, dbPath)
+		require.NoError(t, err)
+
+		defer db.Close()
+
+		require.NoError(t, Migrate(ctx, db))
+
+		sources, err := NewStorage(db).ListSources(ctx)
+		require.NoError(t, err)
+
+		assert.Len(t, sources, 1)
+	})
+
+	t.Run("modified migrations are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		db := newTestDB(t)
+
+		_, err := db.Exec("UPDATE schema_migrations SET checksum = 'modified' WHERE version = '001_init.sql'")
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, Migrate(context.Background(), db), ErrChecksumMismatch)
+	})
+
+	t.Run("missing migrations are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		db := newTestDB(t)
+
+		_, err := db.Exec(
+			"INSERT INTO schema_migrations (version, checksum, applied_at) VALUES ('999_future.sql', 'future', 0)",
+		)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, Migrate(context.Background(), db), ErrMissingMigration)
+	})
+
+	t.Run("legacy rates are backfilled", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			ctx = context.Background()
+			db  = newTestDB(t)
+		)
+
+		// Roll back to the initial schema, with REAL rates only
+		_, err := db.Exec(
+			`DROP TABLE exchange_rates;
+			DELETE FROM schema_migrations WHERE version <> '001_init.sql'`,
+		)
+		require.NoError(t, err)
+
+		initSQL, err := SchemaFS.ReadFile("schema/001_init.sql")
+		require.NoError(t, err)
+
+		_, err = db.Exec(string(initSQL))
+		require.NoError(t, err)
+
+		_, err = db.Exec(
+			`INSERT INTO exchange_rates (base, target, rate, rate_type, source, as_of, fetched_at)
+			VALUES
+				('USD', 'VES', 341.7412, 'MID', 'BCV', 1, 2),
+				('EUR', 'VES', 370.0, 'MID', 'BCV', 1, 2),
+				('USD', 'EUR', 0.0001, 'MID', 'BCV', 1, 2)`,
+		)
+		require.NoError(t, err)
+
+		require.NoError(t, Migrate(ctx, db))
+
+		rows, err := db.Query("SELECT rate_exact FROM exchange_rates ORDER BY id")
+		require.NoError(t, err)
+
+		defer rows.Close()
+
+		var exact []string
+
+		for rows.Next() {
+			var v string
+			require.NoError(t, rows.Scan(&v))
+
+			exact = append(exact, v)
+		}
+
+		require.NoError(t, rows.Err())
+
+		assert.Equal(t, []string{"341.7412", "370", "0.0001"}, exact)
+	})
+}
@@ -0,0 +1,210 @@
+package sqlite
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"time"
+
+	_ "modernc.org/sqlite" // registers the sqlite driver
+)
+
+// driverName is the database/sql driver name of the SQLite driver
+const driverName = "sqlite"
+
+// pragmas are applied to every opened connection.
+// WAL lets readers run alongside the ingest writes,
+// and the busy timeout makes concurrent writers wait instead of failing
+const pragmas = "_pragma=busy_timeout(5000)" +
+	"&_pragma=journal_mode(WAL)" +
+	"&_pragma=synchronous(NORMAL)" +
+	"&_pragma=foreign_keys(1)"
+
+var (
+	ErrChecksumMismatch = errors.New("applied migration checksum mismatch")
+	ErrMissingMigration = errors.New("applied migration is missing")
+)
+
+// Open opens the SQLite database at the given path, creating it if needed
+func Open(ctx context.Context, dbPath string) (*sql.DB, error) {
+	db, err := sql.Open(driverName, fmt.Sprintf("file:%s?%s", dbPath, pragmas))
+	if err != nil {
+		return nil, fmt.Errorf("unable to open DB: %w", err)
+	}
+
+	if err = db.PingContext(ctx); err != nil {
+		_ = db.Close()
+
+		return nil, fmt.Errorf("unable to reach DB (ping): %w", err)
+	}
+
+	return db, nil
+}
+
+// Migrate applies the pending schema migrations, in order.
+// Applied migrations are tracked (with their checksums) in the schema_migrations table,
+// and verified against the migration files before anything is run
+func Migrate(ctx context.Context, db *sql.DB) error {
+	if _, err := db.ExecContext(
+		ctx,
+		`CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    TEXT    PRIMARY KEY,
+			checksum   TEXT    NOT NULL,
+			applied_at INTEGER NOT NULL
+		)`,
+	); err != nil {
+		return fmt.Errorf("unable to create migrations table: %w", err)
+	}
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	applied, err := appliedMigrations(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	// Verify the applied migrations are known, and unchanged since they were applied
+	for version, checksum := range applied {
+		m, ok := migrations[version]
+		if !ok {
+			return fmt.Errorf("%w: %q", ErrMissingMigration, version)
+		}
+
+		if m.checksum != checksum {
+			return fmt.Errorf("%w: %q", ErrChecksumMismatch, version)
+		}
+	}
+
+	versions := slices.Sorted(maps.Keys(migrations))
+
+	for _, version := range versions {
+		if _, ok := applied[version]; ok {
+			continue
+		}
+
+		if err = migrate(ctx, db, migrations[version]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migration is a single schema migration file
+type migration struct {
+	version  string // file name
+	script   string
+	checksum string // hex SHA-256 of the script
+}
+
+// loadMigrations loads the embedded migration files, by version
+func loadMigrations() (map[string]*migration, error) {
+	files, err := fs.Glob(SchemaFS, "schema/*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("unable to list migrations: %w", err)
+	}
+
+	migrations := make(map[string]*migration, len(files))
+
+	for _, file := range files {
+		content, err := SchemaFS.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read migration %q: %w", file, err)
+		}
+
+		sum := sha256.Sum256(content)
+		version := path.Base(file)
+
+		migrations[version] = &migration{
+			version:  version,
+			script:   string(content),
+			checksum: hex.EncodeToString(sum[:]),
+		}
+	}
+
+	return migrations, nil
+}
+
+// appliedMigrations fetches the checksums of the applied migrations, by version
+func appliedMigrations(ctx context.Context, db *sql.DB) (map[string]string, error) {
+	rows, err := db.QueryContext(ctx, "SELECT version, checksum FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch applied migrations: %w", err)
+	}
+
+	defer rows.Close()
+
+	applied := make(map[string]string)
+
+	for rows.Next() {
+		var version, checksum string
+
+		if err = rows.Scan(&version, &checksum); err != nil {
+			return nil, fmt.Errorf("unable to scan applied migration: %w", err)
+		}
+
+		applied[version] = checksum
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to fetch applied migrations: %w", err)
+	}
+
+	return applied, nil
+}
+
+// migrate applies a single migration, if it wasn't applied already
+func migrate(ctx context.Context, db *sql.DB, m *migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("unable to begin migration %q: %w", m.version, err)
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var applied int
+
+	if err = tx.QueryRowContext(
+		ctx,
+		"SELECT COUNT(*) FROM schema_migrations WHERE version = ?",
+		m.version,
+	).Scan(&applied); err != nil {
+		return fmt.Errorf("unable to check migration %q: %w", m.version, err)
+	}
+
+	if applied > 0 {
+		return nil
+	}
+
+	if _, err = tx.ExecContext(ctx, m.script); err != nil {
+		return fmt.Errorf("unable to run migration %q: %w", m.version, err)
+	}
+
+	if _, err = tx.ExecContext(
+		ctx,
+		"INSERT INTO schema_migrations (version, checksum, applied_at) VALUES (?, ?, ?)",
+		m.version,
+		m.checksum,
+		time.Now().UTC().UnixMicro(),
+	); err != nil {
+		return fmt.Errorf("unable to record migration %q: %w", m.version, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("unable to commit migration %q: %w", m.version, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,8 @@
+package sqlite
+
+import "embed"
+
+// SchemaFS contains all SQLite migration files under schema/
+//
+//go:embed schema/*.sql
+var SchemaFS embed.FS
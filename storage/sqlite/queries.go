@@ -0,0 +1,110 @@
+package sqlite
+
+// The queries mirror the Postgres ones in storage/sql/queries,
+// with window functions in place of DISTINCT ON and date_bin
+
+const saveExchangeRateQuery = `
+INSERT OR IGNORE INTO exchange_rates (
+  base, target, rate, rate_exact, rate_type, source, as_of, fetched_at, metadata
+)
+SELECT @base, @target, @rate, @rate_exact, @rate_type, @source, @as_of, @fetched_at, @metadata
+WHERE NOT EXISTS (
+  SELECT 1
+  FROM (
+    SELECT rate_exact
+    FROM exchange_rates
+    WHERE base = @base
+      AND target = @target
+      AND rate_type = @rate_type
+      AND source = @source
+      AND as_of = @as_of
+    ORDER BY fetched_at DESC
+    LIMIT 1
+  ) latest
+  WHERE latest.rate_exact = @rate_exact
+)`
+
+const latestRateExactQuery = `
+SELECT rate_exact
+FROM exchange_rates
+WHERE base = @base
+  AND target = @target
+  AND rate_type = @rate_type
+  AND source = @source
+  AND as_of = @as_of
+ORDER BY fetched_at DESC
+LIMIT 1`
+
+const rateAsOfQuery = `
+WITH latest AS (
+  SELECT
+    base, target, rate_exact, rate_type, source, as_of, fetched_at, metadata,
+    ROW_NUMBER() OVER (
+      PARTITION BY target, source, rate_type
+      ORDER BY as_of DESC, fetched_at DESC
+    ) AS rn
+  FROM exchange_rates
+  WHERE base = @base
+    AND (@target IS NULL OR target = @target)
+    AND (@source IS NULL OR source = @source)
+    AND (@rate_type IS NULL OR rate_type = @rate_type)
+    AND as_of <= @as_of
+    AND (@known_at IS NULL OR fetched_at <= @known_at)
+)
+SELECT
+  base, target, rate_exact, rate_type, source, as_of, fetched_at, metadata,
+  COUNT(*) OVER () AS total
+FROM latest
+WHERE rn = 1
+ORDER BY target, source, rate_type
+LIMIT @limit
+OFFSET @offset`
+
+const rateHistoryQuery = `
+WITH bucketed AS (
+  SELECT
+    base, target, rate_exact, rate_type, source, as_of, fetched_at, metadata,
+    CASE
+      WHEN @bucket_interval > 0
+        THEN @from_time + ((as_of - @from_time) / @bucket_interval) * @bucket_interval
+      ELSE as_of
+    END AS bucket
+  FROM exchange_rates
+  WHERE base = @base
+    AND target = @target
+    AND (@source IS NULL OR source = @source)
+    AND (@rate_type IS NULL OR rate_type = @rate_type)
+    AND as_of >= @from_time
+    AND as_of <= @to_time
+),
+points AS (
+  SELECT
+    *,
+    ROW_NUMBER() OVER (
+      PARTITION BY source, rate_type, bucket
+      ORDER BY as_of DESC, fetched_at DESC
+    ) AS rn
+  FROM bucketed
+)
+SELECT
+  base, target, rate_exact, rate_type, source, as_of, fetched_at, metadata,
+  COUNT(*) OVER () AS total
+FROM points
+WHERE rn = 1
+ORDER BY as_of, source, rate_type
+LIMIT @limit
+OFFSET @offset`
+
+const listSourcesQuery = `
+SELECT DISTINCT source
+FROM exchange_rates
+ORDER BY source`
+
+const listCurrenciesQuery = `
+SELECT code
+FROM (
+  SELECT base AS code FROM exchange_rates
+  UNION
+  SELECT target AS code FROM exchange_rates
+) c
+ORDER BY code`
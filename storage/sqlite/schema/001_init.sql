@@ -0,0 +1,26 @@
+-- Times are stored as unix microseconds (UTC)
+
+CREATE TABLE exchange_rates (
+  id         INTEGER PRIMARY KEY AUTOINCREMENT,
+  base       TEXT    NOT NULL,
+  target     TEXT    NOT NULL,
+  rate       REAL    NOT NULL,
+  rate_type  TEXT    NOT NULL,
+  source     TEXT    NOT NULL,
+  as_of      INTEGER NOT NULL,
+  fetched_at INTEGER NOT NULL,
+
+  CONSTRAINT exchange_rates_base_target_diff CHECK (base <> target),
+  CONSTRAINT exchange_rates_base_fmt
+    CHECK (base GLOB '[A-Z][A-Z][A-Z]' OR base GLOB '[A-Z][A-Z][A-Z][A-Z]'),
+  CONSTRAINT exchange_rates_target_fmt
+    CHECK (target GLOB '[A-Z][A-Z][A-Z]' OR target GLOB '[A-Z][A-Z][A-Z][A-Z]'),
+  CONSTRAINT exchange_rates_rate_type_len CHECK (length(rate_type) <= 16),
+  CONSTRAINT exchange_rates_source_len CHECK (length(source) <= 50),
+
+  CONSTRAINT exchange_rates_uniq
+    UNIQUE (base, target, rate_type, source, as_of, fetched_at)
+);
+
+CREATE INDEX exchange_rates_asof_latest_idx
+  ON exchange_rates (base, target, source, rate_type, as_of DESC, fetched_at DESC);
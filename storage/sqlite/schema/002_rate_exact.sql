@@ -0,0 +1,12 @@
+-- Stores the exact decimal rate as text, keeping the REAL rate as its float approximation
+
+ALTER TABLE exchange_rates
+  ADD COLUMN rate_exact TEXT NOT NULL DEFAULT '';
+
+-- Backfill from the REAL rates, trimming trailing zeros ("341.0" -> "341")
+UPDATE exchange_rates
+SET rate_exact = CASE
+  WHEN instr(CAST(rate AS TEXT), '.') > 0 AND instr(CAST(rate AS TEXT), 'e') = 0
+    THEN rtrim(rtrim(CAST(rate AS TEXT), '0'), '.')
+  ELSE CAST(rate AS TEXT)
+END;
@@ -0,0 +1,4 @@
+-- Stores the provider-specific rate details as a JSON object (empty if there are none)
+
+ALTER TABLE exchange_rates
+  ADD COLUMN metadata TEXT NOT NULL DEFAULT '';
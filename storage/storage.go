@@ -9,15 +9,32 @@ import (
 
 // Storage is an abstraction over exchange rate data
 type Storage interface {
-	// SaveExchangeRate saves the given exchange rate data point
+	// SaveExchangeRate saves the given exchange rate data point.
+	// A changed rate for an already saved data point is saved as a new revision,
+	// while an unchanged one is ignored
 	SaveExchangeRate(context.Context, *types.ExchangeRate) error
 
-	// RateAsOf fetches the rate as of the given time
+	// RateAsOf fetches the rate as of the given time, using the latest revisions
+	// (or the ones known at the query's KnownAt, if set)
 	RateAsOf(context.Context, *types.RateQuery, time.Time) (*types.Page[*types.ExchangeRate], error)
 
+	// RateHistory fetches the rate series for a pair within the given time range.
+	// If an interval is set, only the latest point in each interval bucket is returned.
+	// The latest revision of each point is used
+	RateHistory(context.Context, *types.HistoryQuery) (*types.Page[*types.ExchangeRate], error)
+
 	// ListSources lists all present sources for fx rates
 	ListSources(context.Context) ([]types.Source, error)
 
 	// ListCurrencies lists all currencies present
 	ListCurrencies(context.Context) ([]types.Currency, error)
 }
+
+// BatchSaver is an optional Storage capability for saving a batch of exchange rates atomically,
+// i.e. all the rates of a single provider run. Either the whole batch is saved, or none of it is
+type BatchSaver interface {
+	// SaveExchangeRates saves the given exchange rates in order, following the
+	// SaveExchangeRate revision rules. It returns the save status of each rate,
+	// matching the order of the given rates
+	SaveExchangeRates(context.Context, []*types.ExchangeRate) ([]types.SaveStatus, error)
+}
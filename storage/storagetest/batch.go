@@ -0,0 +1,110 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/storage"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+func testSaveExchangeRates(t *testing.T, newStorage Factory) {
+	t.Helper()
+
+	// newBatchSaver creates a new storage, skipping the test if it can't save batches
+	newBatchSaver := func(t *testing.T) (storage.Storage, storage.BatchSaver) {
+		t.Helper()
+
+		s := newStorage(t)
+
+		saver, ok := s.(storage.BatchSaver)
+		if !ok {
+			t.Skip("storage does not implement storage.BatchSaver")
+		}
+
+		return s, saver
+	}
+
+	t.Run("batch saved", func(t *testing.T) {
+		s, saver := newBatchSaver(t)
+
+		rates := []*types.ExchangeRate{
+			newRate(usd, ves, bcv, types.RateTypeMID, epoch, 341.7412),
+			newRate(eur, ves, bcv, types.RateTypeMID, epoch, 398.12),
+			newRate(usd, ves, binance, types.RateTypeBUY, epoch, 500.5),
+		}
+
+		statuses, err := saver.SaveExchangeRates(context.Background(), rates)
+		require.NoError(t, err)
+
+		assert.Equal(
+			t,
+			[]types.SaveStatus{types.SaveStatusSaved, types.SaveStatusSaved, types.SaveStatusSaved},
+			statuses,
+		)
+
+		page := rateAsOf(t, s, &types.RateQuery{Base: usd}, epoch)
+		assertRates(t, []*types.ExchangeRate{rates[0], rates[2]}, page.Results)
+
+		page = rateAsOf(t, s, &types.RateQuery{Base: eur}, epoch)
+		assertRates(t, []*types.ExchangeRate{rates[1]}, page.Results)
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		_, saver := newBatchSaver(t)
+
+		statuses, err := saver.SaveExchangeRates(context.Background(), nil)
+		require.NoError(t, err)
+
+		assert.Empty(t, statuses)
+	})
+
+	t.Run("per-rate statuses", func(t *testing.T) {
+		s, saver := newBatchSaver(t)
+
+		var (
+			original   = newRate(usd, ves, bcv, types.RateTypeMID, epoch, 341.7412)
+			unchanged  = newRate(usd, ves, bcv, types.RateTypeMID, epoch, 341.7412)
+			correction = newRate(usd, ves, bcv, types.RateTypeMID, epoch, 341.8)
+			conflict   = newRate(usd, ves, bcv, types.RateTypeMID, epoch, 342)
+			fresh      = newRate(eur, ves, bcv, types.RateTypeMID, epoch, 398.12)
+		)
+
+		save(t, s, original)
+
+		unchanged.FetchedAt = original.FetchedAt.Add(time.Hour)
+		correction.FetchedAt = original.FetchedAt.Add(2 * time.Hour)
+
+		// A different rate, fetched at the same time as the correction in the same batch
+		conflict.FetchedAt = correction.FetchedAt
+
+		statuses, err := saver.SaveExchangeRates(
+			context.Background(),
+			[]*types.ExchangeRate{unchanged, correction, conflict, fresh},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(
+			t,
+			[]types.SaveStatus{
+				types.SaveStatusUnchanged,
+				types.SaveStatusSaved,
+				types.SaveStatusConflict,
+				types.SaveStatusSaved,
+			},
+			statuses,
+		)
+
+		page := rateAsOf(t, s, &types.RateQuery{Base: usd}, epoch)
+		assertRates(t, []*types.ExchangeRate{correction}, page.Results)
+
+		// The original is still the revision known before the correction
+		known := correction.FetchedAt.Add(-time.Second)
+		page = rateAsOf(t, s, &types.RateQuery{Base: usd, KnownAt: &known}, epoch)
+		assertRates(t, []*types.ExchangeRate{original}, page.Results)
+	})
+}
@@ -0,0 +1,150 @@
+package storagetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sig-0/fxrates/storage"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+func testRateHistory(t *testing.T, newStorage Factory) {
+	t.Helper()
+
+	var (
+		// BCV USD/VES MID, every 6 hours over a day
+		bcv1 = newRate(usd, ves, bcv, types.RateTypeMID, epoch, 340)
+		bcv2 = newRate(usd, ves, bcv, types.RateTypeMID, epoch.Add(6*time.Hour), 341)
+		bcv3 = newRate(usd, ves, bcv, types.RateTypeMID, epoch.Add(12*time.Hour), 342)
+		bcv4 = newRate(usd, ves, bcv, types.RateTypeMID, epoch.Add(18*time.Hour), 343)
+
+		// Binance USD/VES BUY, every 12 hours over a day
+		binance1 = newRate(usd, ves, binance, types.RateTypeBUY, epoch.Add(time.Hour), 500)
+		binance2 = newRate(usd, ves, binance, types.RateTypeBUY, epoch.Add(13*time.Hour), 510)
+
+		// Different pair
+		bcvEUR = newRate(usd, eur, bcv, types.RateTypeMID, epoch, 0.92)
+	)
+
+	newFilled := func(t *testing.T) storage.Storage {
+		t.Helper()
+
+		s := newStorage(t)
+
+		save(t, s, bcv1, bcv2, bcv3, bcv4, binance1, binance2, bcvEUR)
+
+		return s
+	}
+
+	newQuery := func() *types.HistoryQuery {
+		return &types.HistoryQuery{
+			Base:   usd,
+			Target: ves,
+			From:   epoch,
+			To:     epoch.Add(24 * time.Hour),
+		}
+	}
+
+	t.Run("empty storage", func(t *testing.T) {
+		page := rateHistory(t, newStorage(t), newQuery())
+
+		assert.Empty(t, page.Results)
+		assert.Zero(t, page.Total)
+	})
+
+	t.Run("every point", func(t *testing.T) {
+		s := newFilled(t)
+
+		page := rateHistory(t, s, newQuery())
+
+		// Ordered by as-of, source and rate type
+		assert.Equal(t, int64(6), page.Total)
+		assertRates(
+			t,
+			[]*types.ExchangeRate{bcv1, binance1, bcv2, bcv3, binance2, bcv4},
+			page.Results,
+		)
+	})
+
+	t.Run("range is inclusive", func(t *testing.T) {
+		s := newFilled(t)
+
+		query := newQuery()
+		query.From = bcv2.AsOf
+		query.To = bcv3.AsOf
+
+		page := rateHistory(t, s, query)
+
+		assertRates(t, []*types.ExchangeRate{bcv2, bcv3}, page.Results)
+	})
+
+	t.Run("interval keeps the latest point per bucket", func(t *testing.T) {
+		s := newFilled(t)
+
+		query := newQuery()
+		query.Interval = 12 * time.Hour
+
+		page := rateHistory(t, s, query)
+
+		assert.Equal(t, int64(4), page.Total)
+		assertRates(
+			t,
+			[]*types.ExchangeRate{binance1, bcv2, binance2, bcv4},
+			page.Results,
+		)
+	})
+
+	t.Run("buckets are aligned to the range start", func(t *testing.T) {
+		s := newFilled(t)
+
+		query := newQuery()
+		query.From = epoch.Add(-6 * time.Hour)
+		query.Interval = 12 * time.Hour
+		query.Source = ptr(bcv)
+
+		page := rateHistory(t, s, query)
+
+		assertRates(t, []*types.ExchangeRate{bcv1, bcv3, bcv4}, page.Results)
+	})
+
+	t.Run("source filter", func(t *testing.T) {
+		s := newFilled(t)
+
+		query := newQuery()
+		query.Source = ptr(binance)
+
+		page := rateHistory(t, s, query)
+
+		assertRates(t, []*types.ExchangeRate{binance1, binance2}, page.Results)
+	})
+
+	t.Run("rate type filter", func(t *testing.T) {
+		s := newFilled(t)
+
+		query := newQuery()
+		query.RateType = ptr(types.RateTypeMID)
+
+		page := rateHistory(t, s, query)
+
+		assertRates(t, []*types.ExchangeRate{bcv1, bcv2, bcv3, bcv4}, page.Results)
+	})
+
+	t.Run("latest revision is used", func(t *testing.T) {
+		s := newFilled(t)
+
+		corrected := newRate(usd, ves, bcv, types.RateTypeMID, bcv1.AsOf, 339.5)
+		corrected.FetchedAt = bcv1.FetchedAt.Add(time.Hour)
+
+		save(t, s, corrected)
+
+		query := newQuery()
+		query.To = bcv1.AsOf
+		query.Source = ptr(bcv)
+
+		page := rateHistory(t, s, query)
+
+		assertRates(t, []*types.ExchangeRate{corrected}, page.Results)
+	})
+}
@@ -0,0 +1,70 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+func testListSources(t *testing.T, newStorage Factory) {
+	t.Helper()
+
+	t.Run("empty storage", func(t *testing.T) {
+		sources, err := newStorage(t).ListSources(context.Background())
+		require.NoError(t, err)
+
+		assert.Empty(t, sources)
+	})
+
+	t.Run("distinct and sorted", func(t *testing.T) {
+		s := newStorage(t)
+
+		save(
+			t,
+			s,
+			newRate(usd, ves, binance, types.RateTypeBUY, epoch, 500),
+			newRate(usd, ves, binance, types.RateTypeSELL, epoch, 505),
+			newRate(usd, ves, bcv, types.RateTypeMID, epoch, 340),
+			newRate(eur, ves, bcv, types.RateTypeMID, epoch.Add(time.Hour), 370),
+		)
+
+		sources, err := s.ListSources(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, []types.Source{bcv, binance}, sources)
+	})
+}
+
+func testListCurrencies(t *testing.T, newStorage Factory) {
+	t.Helper()
+
+	t.Run("empty storage", func(t *testing.T) {
+		currencies, err := newStorage(t).ListCurrencies(context.Background())
+		require.NoError(t, err)
+
+		assert.Empty(t, currencies)
+	})
+
+	t.Run("bases and targets, distinct and sorted", func(t *testing.T) {
+		s := newStorage(t)
+
+		save(
+			t,
+			s,
+			newRate(usd, ves, bcv, types.RateTypeMID, epoch, 340),
+			newRate(eur, ves, bcv, types.RateTypeMID, epoch, 370),
+			newRate(usd, cny, bcv, types.RateTypeMID, epoch, 7.1),
+			newRate(usd, eur, bcv, types.RateTypeMID, epoch.Add(time.Hour), 0.92),
+		)
+
+		currencies, err := s.ListCurrencies(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, []types.Currency{cny, eur, usd, ves}, currencies)
+	})
+}
@@ -0,0 +1,170 @@
+package storagetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/storage"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+// numSources is the number of sources quoting the paginated pair,
+// enough to exceed the maximum page limit
+const numSources = int(types.MaxPageLimit) + 20
+
+func testPagination(t *testing.T, newStorage Factory) {
+	t.Helper()
+
+	// One rate per source, and one history point per hour
+	rates := make([]*types.ExchangeRate, 0, numSources)
+	for i := range numSources {
+		rates = append(
+			rates,
+			newRate(usd, ves, sourceName(i), types.RateTypeMID, epoch.Add(time.Duration(i)*time.Hour), float64(i+1)),
+		)
+	}
+
+	newFilled := func(t *testing.T) storage.Storage {
+		t.Helper()
+
+		s := newStorage(t)
+
+		save(t, s, rates...)
+
+		return s
+	}
+
+	asOf := epoch.Add(time.Duration(numSources) * time.Hour)
+
+	historyQuery := func(limit int32, offset int64) *types.HistoryQuery {
+		return &types.HistoryQuery{
+			Base:   usd,
+			Target: ves,
+			From:   epoch,
+			To:     asOf,
+			Limit:  limit,
+			Offset: offset,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		limit         int32
+		offset        int64
+		expectedStart int
+		expectedLen   int
+	}{
+		{
+			name:        "default limit",
+			limit:       0,
+			expectedLen: int(types.DefaultPageLimit),
+		},
+		{
+			name:        "negative limit",
+			limit:       -1,
+			expectedLen: int(types.DefaultPageLimit),
+		},
+		{
+			name:        "limit is capped",
+			limit:       types.MaxPageLimit + 1,
+			expectedLen: int(types.MaxPageLimit),
+		},
+		{
+			name:          "middle page",
+			limit:         10,
+			offset:        25,
+			expectedStart: 25,
+			expectedLen:   10,
+		},
+		{
+			name:          "last partial page",
+			limit:         50,
+			offset:        int64(numSources - 5),
+			expectedStart: numSources - 5,
+			expectedLen:   5,
+		},
+		{
+			name:          "offset at the end",
+			limit:         10,
+			offset:        int64(numSources),
+			expectedStart: numSources,
+		},
+		{
+			name:          "offset past the end",
+			limit:         10,
+			offset:        int64(numSources + 100),
+			expectedStart: numSources,
+		},
+	}
+
+	// The suite fills the storage once, since the cases only read
+	s := newFilled(t)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			expected := rates[testCase.expectedStart : testCase.expectedStart+testCase.expectedLen]
+
+			t.Run("RateAsOf", func(t *testing.T) {
+				page := rateAsOf(
+					t,
+					s,
+					&types.RateQuery{
+						Base:   usd,
+						Limit:  testCase.limit,
+						Offset: testCase.offset,
+					},
+					asOf,
+				)
+
+				assert.Equal(t, int64(numSources), page.Total)
+
+				if len(expected) == 0 {
+					assert.Empty(t, page.Results)
+
+					return
+				}
+
+				// Sources are ordered by name, matching the generation order
+				assertRates(t, expected, page.Results)
+			})
+
+			t.Run("RateHistory", func(t *testing.T) {
+				page := rateHistory(t, s, historyQuery(testCase.limit, testCase.offset))
+
+				assert.Equal(t, int64(numSources), page.Total)
+
+				if len(expected) == 0 {
+					assert.Empty(t, page.Results)
+
+					return
+				}
+
+				assertRates(t, expected, page.Results)
+			})
+		})
+	}
+
+	t.Run("pages cover all results", func(t *testing.T) {
+		var (
+			collected = make([]*types.ExchangeRate, 0, numSources)
+			limit     = int32(64)
+		)
+
+		for offset := int64(0); ; offset += int64(limit) {
+			page := rateHistory(t, s, historyQuery(limit, offset))
+
+			require.Equal(t, int64(numSources), page.Total)
+
+			if len(page.Results) == 0 {
+				break
+			}
+
+			collected = append(collected, page.Results...)
+		}
+
+		assertRates(t, rates, collected)
+	})
+}
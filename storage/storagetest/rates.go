@@ -0,0 +1,133 @@
+package storagetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sig-0/fxrates/storage"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+func testRateAsOf(t *testing.T, newStorage Factory) {
+	t.Helper()
+
+	var (
+		day = time.Hour * 24
+
+		// BCV USD/VES MID series
+		bcvUSD1 = newRate(usd, ves, bcv, types.RateTypeMID, epoch, 340)
+		bcvUSD2 = newRate(usd, ves, bcv, types.RateTypeMID, epoch.Add(day), 341)
+		bcvUSD3 = newRate(usd, ves, bcv, types.RateTypeMID, epoch.Add(day*2), 342)
+
+		// Binance USD/VES BUY / SELL
+		binanceBuy  = newRate(usd, ves, binance, types.RateTypeBUY, epoch.Add(time.Hour), 500)
+		binanceSell = newRate(usd, ves, binance, types.RateTypeSELL, epoch.Add(time.Hour), 505)
+
+		// BCV USD/EUR MID
+		bcvEUR = newRate(usd, eur, bcv, types.RateTypeMID, epoch, 0.92)
+
+		// Different base
+		bcvEURVES = newRate(eur, ves, bcv, types.RateTypeMID, epoch, 370)
+	)
+
+	newFilled := func(t *testing.T) storage.Storage {
+		t.Helper()
+
+		s := newStorage(t)
+
+		save(t, s, bcvUSD1, bcvUSD2, bcvUSD3, binanceBuy, binanceSell, bcvEUR, bcvEURVES)
+
+		return s
+	}
+
+	t.Run("empty storage", func(t *testing.T) {
+		page := rateAsOf(t, newStorage(t), &types.RateQuery{Base: usd}, epoch)
+
+		assert.Empty(t, page.Results)
+		assert.Zero(t, page.Total)
+	})
+
+	t.Run("latest rate per bucket", func(t *testing.T) {
+		s := newFilled(t)
+
+		page := rateAsOf(t, s, &types.RateQuery{Base: usd}, epoch.Add(day+time.Hour))
+
+		// Ordered by target, source and rate type
+		assert.Equal(t, int64(4), page.Total)
+		assertRates(
+			t,
+			[]*types.ExchangeRate{bcvEUR, bcvUSD2, binanceBuy, binanceSell},
+			page.Results,
+		)
+	})
+
+	t.Run("as-of is inclusive", func(t *testing.T) {
+		s := newFilled(t)
+
+		page := rateAsOf(
+			t,
+			s,
+			&types.RateQuery{Base: usd, Target: ptr(ves), Source: ptr(bcv)},
+			bcvUSD3.AsOf,
+		)
+
+		assertRates(t, []*types.ExchangeRate{bcvUSD3}, page.Results)
+	})
+
+	t.Run("future rates are excluded", func(t *testing.T) {
+		s := newFilled(t)
+
+		page := rateAsOf(t, s, &types.RateQuery{Base: usd}, epoch.Add(-time.Second))
+
+		assert.Empty(t, page.Results)
+		assert.Zero(t, page.Total)
+	})
+
+	t.Run("target filter", func(t *testing.T) {
+		s := newFilled(t)
+
+		page := rateAsOf(t, s, &types.RateQuery{Base: usd, Target: ptr(eur)}, epoch.Add(day*3))
+
+		assertRates(t, []*types.ExchangeRate{bcvEUR}, page.Results)
+	})
+
+	t.Run("source filter", func(t *testing.T) {
+		s := newFilled(t)
+
+		page := rateAsOf(t, s, &types.RateQuery{Base: usd, Source: ptr(binance)}, epoch.Add(day*3))
+
+		assertRates(t, []*types.ExchangeRate{binanceBuy, binanceSell}, page.Results)
+	})
+
+	t.Run("rate type filter", func(t *testing.T) {
+		s := newFilled(t)
+
+		page := rateAsOf(
+			t,
+			s,
+			&types.RateQuery{Base: usd, RateType: ptr(types.RateTypeSELL)},
+			epoch.Add(day*3),
+		)
+
+		assertRates(t, []*types.ExchangeRate{binanceSell}, page.Results)
+	})
+
+	t.Run("base filter", func(t *testing.T) {
+		s := newFilled(t)
+
+		page := rateAsOf(t, s, &types.RateQuery{Base: eur}, epoch.Add(day*3))
+
+		assertRates(t, []*types.ExchangeRate{bcvEURVES}, page.Results)
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		s := newFilled(t)
+
+		page := rateAsOf(t, s, &types.RateQuery{Base: cny}, epoch.Add(day*3))
+
+		assert.Empty(t, page.Results)
+		assert.Zero(t, page.Total)
+	})
+}
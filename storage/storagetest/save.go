@@ -0,0 +1,175 @@
+package storagetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+func testSaveExchangeRate(t *testing.T, newStorage Factory) {
+	t.Helper()
+
+	t.Run("round trip", func(t *testing.T) {
+		var (
+			s    = newStorage(t)
+			rate = newRate(usd, ves, bcv, types.RateTypeMID, epoch, 341.7412)
+		)
+
+		save(t, s, rate)
+
+		page := rateAsOf(t, s, &types.RateQuery{Base: usd, Target: ptr(ves)}, epoch)
+
+		assert.Equal(t, int64(1), page.Total)
+		assertRates(t, []*types.ExchangeRate{rate}, page.Results)
+	})
+
+	t.Run("exact rates are preserved", func(t *testing.T) {
+		var (
+			s       = newStorage(t)
+			inverse = newRate(ves, usd, bcv, types.RateTypeMID, epoch, 0)
+			crypto  = newRate(usd, cny, binance, types.RateTypeMID, epoch, 0)
+		)
+
+		inverse.RateExact = types.MustParseDecimal("0.002926141712380965")
+		inverse.Rate = inverse.RateExact.Float64()
+
+		crypto.RateExact = types.MustParseDecimal("12345678901234567890.123456789012345678")
+		crypto.Rate = crypto.RateExact.Float64()
+
+		save(t, s, inverse, crypto)
+
+		page := rateAsOf(t, s, &types.RateQuery{Base: ves}, epoch)
+		assertRates(t, []*types.ExchangeRate{inverse}, page.Results)
+
+		page = rateAsOf(t, s, &types.RateQuery{Base: usd}, epoch)
+		assertRates(t, []*types.ExchangeRate{crypto}, page.Results)
+	})
+
+	t.Run("exact rates are rounded to the max scale", func(t *testing.T) {
+		var (
+			s    = newStorage(t)
+			rate = newRate(ves, usd, bcv, types.RateTypeMID, epoch, 0)
+		)
+
+		rate.RateExact = types.MustParseDecimal("0.00292614171238096512")
+		rate.Rate = rate.RateExact.Float64()
+
+		// Saving the same rate again is not a correction
+		save(t, s, rate, rate)
+
+		page := rateAsOf(t, s, &types.RateQuery{Base: ves}, epoch)
+
+		require.Len(t, page.Results, 1)
+		assert.Equal(t, "0.002926141712380965", page.Results[0].RateExact.String())
+	})
+
+	t.Run("exact rates are derived from float rates", func(t *testing.T) {
+		var (
+			s    = newStorage(t)
+			rate = newRate(usd, ves, bcv, types.RateTypeMID, epoch, 341.7412)
+		)
+
+		save(t, s, rate)
+
+		page := rateAsOf(t, s, &types.RateQuery{Base: usd}, epoch)
+
+		require.Len(t, page.Results, 1)
+		assert.Equal(t, "341.7412", page.Results[0].RateExact.String())
+	})
+
+	t.Run("time zones are normalized", func(t *testing.T) {
+		var (
+			s    = newStorage(t)
+			loc  = time.FixedZone("VET", -4*60*60)
+			rate = newRate(usd, ves, bcv, types.RateTypeMID, epoch.In(loc), 341.7412)
+		)
+
+		save(t, s, rate)
+
+		page := rateAsOf(t, s, &types.RateQuery{Base: usd, Target: ptr(ves)}, epoch)
+
+		require.Len(t, page.Results, 1)
+		assertRate(t, rate, page.Results[0])
+	})
+
+	t.Run("metadata is preserved", func(t *testing.T) {
+		var (
+			s    = newStorage(t)
+			rate = newRate(usd, ves, binance, types.RateTypeBUY, epoch, 512.3)
+		)
+
+		rate.Metadata = map[string]string{
+			"offers": "12",
+			"depth":  "8450.5",
+		}
+
+		// Refetching the same rate with new metadata is not a new revision
+		refetched := newRate(usd, ves, binance, types.RateTypeBUY, epoch, 512.3)
+		refetched.FetchedAt = rate.FetchedAt.Add(time.Hour)
+		refetched.Metadata = map[string]string{"offers": "10"}
+
+		save(t, s, rate, refetched)
+
+		page := rateAsOf(t, s, &types.RateQuery{Base: usd}, epoch)
+		assertRates(t, []*types.ExchangeRate{rate}, page.Results)
+
+		page = rateHistory(t, s, &types.HistoryQuery{Base: usd, Target: ves, From: epoch, To: epoch})
+		assertRates(t, []*types.ExchangeRate{rate}, page.Results)
+	})
+
+	t.Run("unchanged rate is saved once", func(t *testing.T) {
+		var (
+			s         = newStorage(t)
+			rate      = newRate(usd, ves, bcv, types.RateTypeMID, epoch, 341.7412)
+			refetched = newRate(usd, ves, bcv, types.RateTypeMID, epoch, 341.7412)
+		)
+
+		refetched.FetchedAt = rate.FetchedAt.Add(time.Hour)
+
+		save(t, s, rate, rate, refetched)
+
+		page := rateAsOf(t, s, &types.RateQuery{Base: usd, Target: ptr(ves)}, epoch)
+
+		assert.Equal(t, int64(1), page.Total)
+		assertRates(t, []*types.ExchangeRate{rate}, page.Results)
+	})
+
+	t.Run("corrections are saved as revisions", func(t *testing.T) {
+		var (
+			s         = newStorage(t)
+			published = newRate(usd, ves, bcv, types.RateTypeMID, epoch, 341.7412)
+			corrected = newRate(usd, ves, bcv, types.RateTypeMID, epoch, 342.1)
+		)
+
+		corrected.FetchedAt = published.FetchedAt.Add(time.Hour)
+
+		save(t, s, published, corrected)
+
+		query := &types.RateQuery{Base: usd, Target: ptr(ves)}
+
+		// The latest revision is served by default
+		page := rateAsOf(t, s, query, epoch)
+
+		assert.Equal(t, int64(1), page.Total)
+		assertRates(t, []*types.ExchangeRate{corrected}, page.Results)
+
+		// Earlier revisions are served as they were known
+		query.KnownAt = ptr(corrected.FetchedAt.Add(-time.Second))
+
+		page = rateAsOf(t, s, query, epoch)
+
+		assertRates(t, []*types.ExchangeRate{published}, page.Results)
+
+		// Nothing was known before the first revision
+		query.KnownAt = ptr(published.FetchedAt.Add(-time.Second))
+
+		page = rateAsOf(t, s, query, epoch)
+
+		assert.Empty(t, page.Results)
+		assert.Zero(t, page.Total)
+	})
+}
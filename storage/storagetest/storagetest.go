@@ -0,0 +1,179 @@
+// Package storagetest provides a conformance test suite for storage.Storage adapters.
+//
+// Adapters run the suite from their own tests, providing a factory for empty storage instances:
+//
+//	func TestStorage_Conformance(t *testing.T) {
+//		storagetest.Run(t, func(t *testing.T) storage.Storage {
+//			return NewStorage(newTestDB(t))
+//		})
+//	}
+//
+// The suite runs sequentially, so adapters backed by a shared database
+// can reset it in the factory
+package storagetest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sig-0/fxrates/storage"
+	"github.com/sig-0/fxrates/storage/types"
+)
+
+// Factory creates a new, empty storage instance for a single test
+type Factory func(t *testing.T) storage.Storage
+
+const (
+	usd types.Currency = "USD"
+	eur types.Currency = "EUR"
+	ves types.Currency = "VES"
+	cny types.Currency = "CNY"
+
+	bcv     types.Source = "BCV"
+	binance types.Source = "BinanceP2P"
+)
+
+// epoch is the reference time for the suite's data points.
+// Times are whole seconds, to fit all adapters' precision
+var epoch = time.Date(2026, time.January, 5, 0, 0, 0, 0, time.UTC)
+
+// Run runs the storage conformance suite against the adapter created by the factory
+func Run(t *testing.T, newStorage Factory) {
+	t.Helper()
+
+	t.Run("SaveExchangeRate", func(t *testing.T) {
+		testSaveExchangeRate(t, newStorage)
+	})
+
+	t.Run("SaveExchangeRates", func(t *testing.T) {
+		testSaveExchangeRates(t, newStorage)
+	})
+
+	t.Run("RateAsOf", func(t *testing.T) {
+		testRateAsOf(t, newStorage)
+	})
+
+	t.Run("RateHistory", func(t *testing.T) {
+		testRateHistory(t, newStorage)
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		testPagination(t, newStorage)
+	})
+
+	t.Run("ListSources", func(t *testing.T) {
+		testListSources(t, newStorage)
+	})
+
+	t.Run("ListCurrencies", func(t *testing.T) {
+		testListCurrencies(t, newStorage)
+	})
+}
+
+// newRate creates a new exchange rate data point, fetched a minute after its as-of time
+func newRate(
+	base, target types.Currency,
+	source types.Source,
+	rateType types.RateType,
+	asOf time.Time,
+	rate float64,
+) *types.ExchangeRate {
+	return &types.ExchangeRate{
+		AsOf:      asOf,
+		FetchedAt: asOf.Add(time.Minute),
+		Base:      base,
+		Target:    target,
+		RateType:  rateType,
+		Source:    source,
+		Rate:      rate,
+	}
+}
+
+// save saves the given rates to the storage
+func save(t *testing.T, s storage.Storage, rates ...*types.ExchangeRate) {
+	t.Helper()
+
+	for _, rate := range rates {
+		require.NoError(t, s.SaveExchangeRate(context.Background(), rate))
+	}
+}
+
+// rateAsOf fetches the rates as of the given time
+func rateAsOf(
+	t *testing.T,
+	s storage.Storage,
+	query *types.RateQuery,
+	asOf time.Time,
+) *types.Page[*types.ExchangeRate] {
+	t.Helper()
+
+	page, err := s.RateAsOf(context.Background(), query, asOf)
+	require.NoError(t, err)
+	require.NotNil(t, page)
+
+	return page
+}
+
+// rateHistory fetches the rate history
+func rateHistory(
+	t *testing.T,
+	s storage.Storage,
+	query *types.HistoryQuery,
+) *types.Page[*types.ExchangeRate] {
+	t.Helper()
+
+	page, err := s.RateHistory(context.Background(), query)
+	require.NoError(t, err)
+	require.NotNil(t, page)
+
+	return page
+}
+
+// assertRate asserts the rate matches the expected one
+func assertRate(t *testing.T, expected, actual *types.ExchangeRate) {
+	t.Helper()
+
+	require.NotNil(t, actual)
+
+	assert.Equal(t, expected.Base, actual.Base)
+	assert.Equal(t, expected.Target, actual.Target)
+	assert.Equal(t, expected.Source, actual.Source)
+	assert.Equal(t, expected.RateType, actual.RateType)
+	assert.InDelta(t, expected.Rate, actual.Rate, 1e-9)
+	assert.Equal(t, expected.Exact().String(), actual.RateExact.String())
+	assert.Equal(t, expected.Metadata, actual.Metadata)
+	assert.True(t, expected.AsOf.Equal(actual.AsOf), "as_of: expected %s, got %s", expected.AsOf, actual.AsOf)
+	assert.True(
+		t,
+		expected.FetchedAt.Equal(actual.FetchedAt),
+		"fetched_at: expected %s, got %s",
+		expected.FetchedAt,
+		actual.FetchedAt,
+	)
+}
+
+// assertRates asserts the rates match the expected ones, in order
+func assertRates(t *testing.T, expected, actual []*types.ExchangeRate) {
+	t.Helper()
+
+	require.Len(t, actual, len(expected))
+
+	for i := range expected {
+		assertRate(t, expected[i], actual[i])
+	}
+}
+
+// ptr returns a pointer to the given value
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// sourceName returns the i-th generated source name
+func sourceName(i int) types.Source {
+	return types.Source(fmt.Sprintf("Source %03d", i))
+}
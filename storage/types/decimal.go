@@ -0,0 +1,323 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// MaxRateScale is the maximum number of decimal places stored for a rate
+const MaxRateScale = 18
+
+// maxParsedExponent bounds the exponent of parsed decimals, beyond the float range,
+// so untrusted input (i.e. "1e2000000000") can't expand to huge plain notations
+const maxParsedExponent = 400
+
+var ErrInvalidDecimal = errors.New("invalid decimal")
+
+var (
+	bigTen = big.NewInt(10)
+	bigOne = big.NewInt(1)
+)
+
+// Decimal is an exact decimal number, represented as coefficient * 10^exponent.
+// Decimals are immutable, and kept normalized (without trailing zeros in the coefficient),
+// so equal values have equal representations. The zero value is 0
+type Decimal struct {
+	coef *big.Int
+	exp  int32
+}
+
+// NewDecimal creates a new decimal with the value coef * 10^exp
+func NewDecimal(coef *big.Int, exp int32) Decimal {
+	if coef == nil {
+		return Decimal{}
+	}
+
+	return normalize(new(big.Int).Set(coef), exp)
+}
+
+// NewDecimalFromFloat creates a new decimal from the shortest
+// representation of the float. NaN and infinities are converted to 0
+func NewDecimalFromFloat(f float64) Decimal {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}
+	}
+
+	d, _ := ParseDecimal(strconv.FormatFloat(f, 'g', -1, 64))
+
+	return d
+}
+
+// ParseDecimal parses the decimal string, in plain ("-123.45")
+// or scientific ("1.2345e-3") notation. Exponents beyond ±400 are out of range
+func ParseDecimal(s string) (Decimal, error) {
+	var (
+		value    = strings.TrimSpace(s)
+		mantissa = value
+		exp      int64
+	)
+
+	// Split the exponent, if any
+	if i := strings.IndexAny(value, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(value[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+		}
+
+		mantissa, exp = value[:i], e
+	}
+
+	intPart, fracPart, _ := strings.Cut(mantissa, ".")
+
+	digits := intPart + fracPart
+	if len(digits) > 0 && (digits[0] == '+' || digits[0] == '-') {
+		digits = digits[1:]
+	}
+
+	if digits == "" {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+	}
+
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return Decimal{}, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+		}
+	}
+
+	coef, ok := new(big.Int).SetString(intPart+fracPart, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+	}
+
+	exp -= int64(len(fracPart))
+	if exp < -maxParsedExponent || exp > maxParsedExponent {
+		return Decimal{}, fmt.Errorf("%w: %q is out of range", ErrInvalidDecimal, s)
+	}
+
+	return normalize(coef, int32(exp)), nil
+}
+
+// MustParseDecimal parses the decimal string, and panics if it's invalid
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+// Coefficient returns a copy of the decimal coefficient
+func (d Decimal) Coefficient() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+
+	return new(big.Int).Set(d.coef)
+}
+
+// Exponent returns the decimal (base 10) exponent
+func (d Decimal) Exponent() int32 {
+	return d.exp
+}
+
+// IsZero returns true if the decimal is 0
+func (d Decimal) IsZero() bool {
+	return d.coef == nil || d.coef.Sign() == 0
+}
+
+// Sign returns -1, 0 or +1, depending on the decimal sign
+func (d Decimal) Sign() int {
+	if d.coef == nil {
+		return 0
+	}
+
+	return d.coef.Sign()
+}
+
+// Equal returns true if both decimals have the same value
+func (d Decimal) Equal(other Decimal) bool {
+	return d.exp == other.exp && d.Coefficient().Cmp(other.Coefficient()) == 0
+}
+
+// Round rounds the decimal to the given number of decimal places, half away from zero
+func (d Decimal) Round(places int32) Decimal {
+	if d.IsZero() || d.exp >= -places {
+		return d
+	}
+
+	var (
+		shift   = new(big.Int).Exp(bigTen, big.NewInt(int64(-places-d.exp)), nil)
+		coef, r = new(big.Int).QuoRem(d.coef, shift, new(big.Int))
+	)
+
+	// Round half away from zero
+	if r.Abs(r).Lsh(r, 1).Cmp(shift) >= 0 {
+		if d.coef.Sign() < 0 {
+			coef.Sub(coef, bigOne)
+		} else {
+			coef.Add(coef, bigOne)
+		}
+	}
+
+	return normalize(coef, -places)
+}
+
+// Mul returns the exact product of the decimals
+func (d Decimal) Mul(other Decimal) Decimal {
+	if d.IsZero() || other.IsZero() {
+		return Decimal{}
+	}
+
+	return normalize(new(big.Int).Mul(d.coef, other.coef), d.exp+other.exp)
+}
+
+// Rat returns the decimal as a rational number
+func (d Decimal) Rat() *big.Rat {
+	if d.IsZero() {
+		return new(big.Rat)
+	}
+
+	scale := new(big.Int).Exp(bigTen, big.NewInt(int64(abs32(d.exp))), nil)
+
+	if d.exp >= 0 {
+		return new(big.Rat).SetInt(new(big.Int).Mul(d.coef, scale))
+	}
+
+	return new(big.Rat).SetFrac(d.coef, scale)
+}
+
+// NewDecimalFromRat creates a new decimal from the rational number,
+// rounded to the given number of decimal places, half away from zero
+func NewDecimalFromRat(r *big.Rat, places int32) Decimal {
+	var (
+		scale   = new(big.Int).Exp(bigTen, big.NewInt(int64(places)), nil)
+		num     = new(big.Int).Mul(r.Num(), scale)
+		coef, m = new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+	)
+
+	// Round half away from zero
+	if m.Abs(m).Lsh(m, 1).Cmp(r.Denom()) >= 0 {
+		if r.Sign() < 0 {
+			coef.Sub(coef, bigOne)
+		} else {
+			coef.Add(coef, bigOne)
+		}
+	}
+
+	return normalize(coef, -places)
+}
+
+// Float64 returns the nearest float value of the decimal
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+
+	return f
+}
+
+// String returns the plain notation of the decimal, e.g. "0.0031"
+func (d Decimal) String() string {
+	if d.IsZero() {
+		return "0"
+	}
+
+	var (
+		digits = d.coef.String()
+		sign   = ""
+	)
+
+	if digits[0] == '-' {
+		sign, digits = "-", digits[1:]
+	}
+
+	if d.exp >= 0 {
+		return sign + digits + strings.Repeat("0", int(d.exp))
+	}
+
+	scale := int(-d.exp)
+	if len(digits) <= scale {
+		return sign + "0." + strings.Repeat("0", scale-len(digits)) + digits
+	}
+
+	return sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+}
+
+// MarshalText encodes the decimal in plain notation
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText decodes the decimal from plain or scientific notation
+func (d *Decimal) UnmarshalText(text []byte) error {
+	parsed, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+
+	return nil
+}
+
+// UnmarshalJSON decodes the decimal from a JSON string or number
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+
+	// Leave the decimal as is, matching the encoding/json convention
+	if string(data) == "null" {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+
+		data = []byte(s)
+	}
+
+	return d.UnmarshalText(data)
+}
+
+// normalize strips the trailing zeros from the coefficient
+func normalize(coef *big.Int, exp int32) Decimal {
+	if coef.Sign() == 0 {
+		return Decimal{}
+	}
+
+	var (
+		q = new(big.Int)
+		r = new(big.Int)
+	)
+
+	for exp < math.MaxInt32 {
+		q.QuoRem(coef, bigTen, r)
+		if r.Sign() != 0 {
+			break
+		}
+
+		coef.Set(q)
+		exp++
+	}
+
+	return Decimal{
+		coef: coef,
+		exp:  exp,
+	}
+}
+
+// abs32 returns the absolute value of the exponent
+func abs32(v int32) int64 {
+	if v < 0 {
+		return -int64(v)
+	}
+
+	return int64(v)
+}
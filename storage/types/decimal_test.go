@@ -0,0 +1,203 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDecimal(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"integer", "341", "341"},
+		{"fraction", "341.7412", "341.7412"},
+		{"trailing zeros", "341.74120000", "341.7412"},
+		{"small fraction", "0.0029158798", "0.0029158798"},
+		{"no integer part", ".5", "0.5"},
+		{"no fraction part", "5.", "5"},
+		{"negative", "-12.50", "-12.5"},
+		{"positive sign", "+12.5", "12.5"},
+		{"zero", "0.000", "0"},
+		{"scientific", "1.2345e-3", "0.0012345"},
+		{"scientific positive", "12E2", "1200"},
+		{"whitespace", " 36.5 ", "36.5"},
+		{"many digits", "12345678901234567890.123456789012345678", "12345678901234567890.123456789012345678"},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := ParseDecimal(testCase.input)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expected, d.String())
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		for _, input := range []string{"", "-", ".", "abc", "1.2.3", "1,5", "--1", "1e", "e5", "1e99999999999"} {
+			_, err := ParseDecimal(input)
+			assert.ErrorIs(t, err, ErrInvalidDecimal, input)
+		}
+	})
+
+	t.Run("exponent out of range", func(t *testing.T) {
+		t.Parallel()
+
+		// i.e. scraped from an untrusted page, would expand to 2GB of zeros
+		for _, input := range []string{"1e2000000000", "1e-2000000000", "1e401", "0.1e-400"} {
+			_, err := ParseDecimal(input)
+			assert.ErrorIs(t, err, ErrInvalidDecimal, input)
+		}
+
+		d, err := ParseDecimal("1.5e300")
+		require.NoError(t, err)
+
+		assert.Equal(t, 1.5e300, d.Float64())
+	})
+}
+
+func TestDecimal_Equal(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, MustParseDecimal("341.74").Equal(MustParseDecimal("341.7400")))
+	assert.True(t, MustParseDecimal("1200").Equal(NewDecimal(big.NewInt(12), 2)))
+	assert.True(t, Decimal{}.Equal(MustParseDecimal("0.00")))
+	assert.False(t, MustParseDecimal("341.74").Equal(MustParseDecimal("341.741")))
+	assert.False(t, MustParseDecimal("-1").Equal(MustParseDecimal("1")))
+}
+
+func TestDecimal_Round(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		input    string
+		expected string
+		places   int32
+	}{
+		{"341.74125", "341.7413", 4},
+		{"341.74124", "341.7412", 4},
+		{"-341.74125", "-341.7413", 4},
+		{"0.00004", "0", 4},
+		{"0.00005", "0.0001", 4},
+		{"1.5", "1.5", 4},
+		{"9.99995", "10", 4},
+		{"1250", "1250", 0},
+	}
+
+	for _, testCase := range testTable {
+		assert.Equal(
+			t,
+			testCase.expected,
+			MustParseDecimal(testCase.input).Round(testCase.places).String(),
+			testCase.input,
+		)
+	}
+}
+
+func TestNewDecimalFromFloat(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "0.0031", NewDecimalFromFloat(0.0031).String())
+	assert.Equal(t, "341.7412", NewDecimalFromFloat(341.7412).String())
+	assert.Equal(t, "0.00001", NewDecimalFromFloat(1e-5).String())
+	assert.Equal(t, "100000000000000000000", NewDecimalFromFloat(1e20).String())
+	assert.InDelta(t, 0.0031, NewDecimalFromFloat(0.0031).Float64(), 0)
+}
+
+func TestDecimal_Mul(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "42896.7283", MustParseDecimal("125.5").Mul(MustParseDecimal("341.8066")).String())
+	assert.Equal(t, "-0.000001", MustParseDecimal("-0.001").Mul(MustParseDecimal("0.001")).String())
+	assert.True(t, MustParseDecimal("341.7412").Mul(Decimal{}).IsZero())
+}
+
+func TestNewDecimalFromRat(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rounded half away from zero", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "0.3333", NewDecimalFromRat(big.NewRat(1, 3), 4).String())
+		assert.Equal(t, "0.6667", NewDecimalFromRat(big.NewRat(2, 3), 4).String())
+		assert.Equal(t, "-0.6667", NewDecimalFromRat(big.NewRat(-2, 3), 4).String())
+		assert.Equal(t, "0.13", NewDecimalFromRat(big.NewRat(1, 8), 2).String())
+		assert.Equal(t, "1250", NewDecimalFromRat(big.NewRat(1250, 1), 4).String())
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+
+		d := MustParseDecimal("0.00291587981023")
+
+		assert.True(t, d.Equal(NewDecimalFromRat(d.Rat(), MaxRateScale)))
+		assert.Equal(t, "-1/8", MustParseDecimal("-0.125").Rat().String())
+	})
+}
+
+func TestDecimal_JSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("encoded as a string", func(t *testing.T) {
+		t.Parallel()
+
+		encoded, err := json.Marshal(MustParseDecimal("0.0029158798"))
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `"0.0029158798"`, string(encoded))
+	})
+
+	t.Run("decoded from a string or number", func(t *testing.T) {
+		t.Parallel()
+
+		var fromString, fromNumber Decimal
+
+		require.NoError(t, json.Unmarshal([]byte(`"341.7412"`), &fromString))
+		require.NoError(t, json.Unmarshal([]byte(`341.7412`), &fromNumber))
+
+		assert.Equal(t, "341.7412", fromString.String())
+		assert.True(t, fromString.Equal(fromNumber))
+	})
+
+	t.Run("exchange rate", func(t *testing.T) {
+		t.Parallel()
+
+		rate := &ExchangeRate{
+			Rate:      0.0029158798,
+			RateExact: MustParseDecimal("0.0029158798"),
+		}
+
+		encoded, err := json.Marshal(rate)
+		require.NoError(t, err)
+
+		assert.Contains(t, string(encoded), `"rate":0.0029158798`)
+		assert.Contains(t, string(encoded), `"rate_exact":"0.0029158798"`)
+
+		// Unset exact rates are omitted
+		encoded, err = json.Marshal(&ExchangeRate{Rate: 1})
+		require.NoError(t, err)
+
+		assert.NotContains(t, string(encoded), "rate_exact")
+	})
+}
+
+func TestExchangeRate_Exact(t *testing.T) {
+	t.Parallel()
+
+	exact := MustParseDecimal("0.00291587981023")
+
+	assert.Equal(t, exact, (&ExchangeRate{Rate: 0.0029, RateExact: exact}).Exact())
+	assert.Equal(t, "0.0029", (&ExchangeRate{Rate: 0.0029}).Exact().String())
+}
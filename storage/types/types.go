@@ -47,7 +47,34 @@ type ExchangeRate struct {
 	Target    Currency  `json:"target"`
 	RateType  RateType  `json:"rate_type"`
 	Source    Source    `json:"source"`
-	Rate      float64   `json:"rate"`
+	Rate      float64   `json:"rate"`                // float approximation of the rate, kept for compatibility
+	RateExact Decimal   `json:"rate_exact,omitzero"` // exact rate. Derived from Rate if not set
+
+	// Provider-specific details of the rate (i.e. the P2P order book depth), if any.
+	// Saved with the rate revision, so unchanged rates keep their original metadata
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Exact returns the exact rate, or the decimal representation of Rate if it's not set
+func (r *ExchangeRate) Exact() Decimal {
+	if !r.RateExact.IsZero() {
+		return r.RateExact
+	}
+
+	return NewDecimalFromFloat(r.Rate)
+}
+
+// SaveStatus is the outcome of saving a single exchange rate
+type SaveStatus string
+
+const (
+	SaveStatusSaved     SaveStatus = "saved"     // saved as a new revision
+	SaveStatusUnchanged SaveStatus = "unchanged" // same rate as the latest revision, ignored
+	SaveStatusConflict  SaveStatus = "conflict"  // a different revision was already fetched at the same time, ignored
+)
+
+func (s SaveStatus) String() string {
+	return string(s)
 }
 
 type Pair struct {
@@ -56,16 +83,52 @@ type Pair struct {
 }
 
 type RateQuery struct {
-	Target   *Currency `json:"target"`
-	RateType *RateType `json:"rate_type"`
-	Source   *Source   `json:"source"`
-	Base     Currency  `json:"base"`
-	Offset   int64     `json:"offset"`
-	Limit    int32     `json:"limit"`
+	// KnownAt, if set, reads the rates as they were known at this time,
+	// ignoring revisions fetched after it. The latest revision is used otherwise
+	KnownAt  *time.Time `json:"known_at"`
+	Target   *Currency  `json:"target"`
+	RateType *RateType  `json:"rate_type"`
+	Source   *Source    `json:"source"`
+	Base     Currency   `json:"base"`
+	Offset   int64      `json:"offset"`
+	Limit    int32      `json:"limit"`
+}
+
+// HistoryQuery defines the filter for a historical rate series
+type HistoryQuery struct {
+	From     time.Time     `json:"from"`
+	To       time.Time     `json:"to"`
+	Source   *Source       `json:"source"`
+	RateType *RateType     `json:"rate_type"`
+	Base     Currency      `json:"base"`
+	Target   Currency      `json:"target"`
+	Interval time.Duration `json:"interval"` // downsampling interval, 0 returns every point
+	Offset   int64         `json:"offset"`
+	Limit    int32         `json:"limit"`
 }
 
+const (
+	// DefaultPageLimit is the page size used if no limit is requested
+	DefaultPageLimit int32 = 100
+
+	// MaxPageLimit is the maximum page size
+	MaxPageLimit int32 = 500
+)
+
 // Page wraps the results for pagination
 type Page[T any] struct {
 	Results []T   `json:"results"`
 	Total   int64 `json:"total"`
 }
+
+// PageLimit returns the effective page size for the requested limit
+func PageLimit(limit int32) int32 {
+	switch {
+	case limit <= 0:
+		return DefaultPageLimit
+	case limit > MaxPageLimit:
+		return MaxPageLimit
+	default:
+		return limit
+	}
+}